@@ -7,17 +7,24 @@ package sql
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
+
+	time_ "github.com/searKing/golang/go/time"
 )
 
 var _ sql.Scanner = (*NullDuration)(nil)
 var _ driver.Valuer = NullDuration{}
+var _ json.Marshaler = NullDuration{}
+var _ json.Unmarshaler = (*NullDuration)(nil)
 
 var nilTimeDurationValue = func() (val time.Duration) { return }()
 
 // NullDuration represents an interface that may be null.
 // NullDuration implements the Scanner interface so it can be used as a scan destination, similar to sql.NullString.
+// Strings are scanned in any form accepted by time_.ParseDuration, such as "36h", "1.5d" or "P1DT12H",
+// and integers as nanoseconds.
 type NullDuration struct {
 	Data time.Duration
 
@@ -35,16 +42,18 @@ func (nj *NullDuration) Scan(src any) error {
 	var err error
 	switch src := src.(type) {
 	case string:
-		nj.Data, err = time.ParseDuration(src)
+		nj.Data, err = time_.ParseDuration(src)
 	case []byte:
-		nj.Data, err = time.ParseDuration(string(src))
+		nj.Data, err = time_.ParseDuration(string(src))
 	case time.Duration:
-		nj.Data, err = time.ParseDuration(src.String())
+		nj.Data = src
+	case int64:
+		nj.Data = time.Duration(src)
 	case nil:
 		nj.Data = nilTimeDurationValue
 		err = nil
 	default:
-		nj.Data, err = time.ParseDuration(fmt.Sprintf("%s", src))
+		nj.Data, err = time_.ParseDuration(fmt.Sprintf("%v", src))
 	}
 	if err == nil {
 		return nil
@@ -60,3 +69,25 @@ func (nj NullDuration) Value() (driver.Value, error) {
 	}
 	return nj.Data.String(), nil
 }
+
+// MarshalJSON implements the json.Marshaler interface, null if not Valid.
+func (nj NullDuration) MarshalJSON() ([]byte, error) {
+	if !nj.Valid {
+		return []byte("null"), nil
+	}
+	return time_.Duration(nj.Data).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, null is unmarshalled as not Valid.
+func (nj *NullDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		nj.Data, nj.Valid = nilTimeDurationValue, false
+		return nil
+	}
+	var d time_.Duration
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	nj.Data, nj.Valid = time.Duration(d), true
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sql_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/database/sql"
+)
+
+func TestNullDurationScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want sql.NullDuration
+	}{
+		{nil, sql.NullDuration{}},
+		{"1h30m", sql.NullDuration{Data: 90 * time.Minute, Valid: true}},
+		{[]byte("P1DT12H"), sql.NullDuration{Data: 36 * time.Hour, Valid: true}},
+		{"2w", sql.NullDuration{Data: 14 * 24 * time.Hour, Valid: true}},
+		{int64(time.Second), sql.NullDuration{Data: time.Second, Valid: true}},
+		{time.Minute, sql.NullDuration{Data: time.Minute, Valid: true}},
+	}
+	for _, tt := range tests {
+		var got sql.NullDuration
+		if err := got.Scan(tt.src); err != nil || got != tt.want {
+			t.Errorf("Scan(%v) = %+v, %v; want %+v", tt.src, got, err, tt.want)
+		}
+	}
+	var got sql.NullDuration
+	if err := got.Scan("forever"); err == nil {
+		t.Errorf("Scan(forever) = nil error; want error")
+	}
+}
+
+func TestNullDurationJSON(t *testing.T) {
+	for _, nd := range []sql.NullDuration{{}, {Data: 36 * time.Hour, Valid: true}} {
+		data, err := json.Marshal(nd)
+		if err != nil {
+			t.Fatalf("json.Marshal(%+v): %v", nd, err)
+		}
+		var got sql.NullDuration
+		if err := json.Unmarshal(data, &got); err != nil || got != nd {
+			t.Errorf("json.Unmarshal(%s) = %+v, %v; want %+v", data, got, err, nd)
+		}
+	}
+}
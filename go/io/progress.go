@@ -0,0 +1,157 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package io
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of the bytes transferred.
+type Progress struct {
+	Bytes   int64         // bytes transferred
+	Total   int64         // total bytes to transfer, -1 if unknown
+	Rate    float64       // bytes per second, a moving average of recent intervals
+	ETA     time.Duration // estimated time left, -1 if unknown
+	Elapsed time.Duration // time since the first Read, until done
+	Done    bool          // io.EOF or an error is met
+	Err     error         // error met, io.EOF excluded
+}
+
+// Percent returns the percentage transferred, -1 if Total is unknown.
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return float64(p.Bytes) * 100 / float64(p.Total)
+}
+
+//go:generate go-option -type "progress"
+type progress struct {
+	// Total is the total bytes to read, unknown if not bigger than 0.
+	Total int64
+	// Interval is the min interval between calls of ReportFunc, 1s if not set.
+	Interval time.Duration
+	// ReportFunc is called with the progress every Interval when read, and once when done.
+	ReportFunc func(p Progress)
+}
+
+// ProgressReader is a Reader that reports the progress of reading, such as bytes, rate and ETA.
+//
+// The progress is reported by Read, so no progress is reported when the reading stalls,
+// call Progress to poll the progress instead.
+type ProgressReader struct {
+	source io.Reader
+	opts   progress
+
+	mu           sync.Mutex
+	bytes        int64
+	start        time.Time
+	end          time.Time // time when done
+	lastReport   time.Time
+	lastBytes    int64
+	rate         float64
+	done         bool
+	err          error
+	reportedDone bool
+}
+
+// NewProgressReader returns a ProgressReader reading from r.
+func NewProgressReader(r io.Reader, opts ...ProgressOption) *ProgressReader {
+	pr := &ProgressReader{source: r}
+	pr.opts.ApplyOptions(opts...)
+	if pr.opts.Interval <= 0 {
+		pr.opts.Interval = time.Second
+	}
+	return pr
+}
+
+func (r *ProgressReader) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	if r.start.IsZero() {
+		r.start = time.Now()
+		r.lastReport = r.start
+	}
+	r.mu.Unlock()
+
+	n, err = r.source.Read(p)
+
+	now := time.Now()
+	r.mu.Lock()
+	r.bytes += int64(n)
+	if err != nil && !r.done {
+		r.done = true
+		r.end = now
+		if err != io.EOF {
+			r.err = err
+		}
+	}
+	var report bool
+	if now.Sub(r.lastReport) >= r.opts.Interval || (r.done && !r.reportedDone) {
+		r.sampleRateLocked(now)
+		report = r.opts.ReportFunc != nil
+		r.reportedDone = r.done
+	}
+	progress := r.progressLocked(now)
+	r.mu.Unlock()
+
+	if report {
+		r.opts.ReportFunc(progress)
+	}
+	return n, err
+}
+
+// Progress returns the progress of reading so far.
+func (r *ProgressReader) Progress() Progress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.progressLocked(time.Now())
+}
+
+// sampleRateLocked updates the moving average of rate by the bytes read since the last sample.
+func (r *ProgressReader) sampleRateLocked(now time.Time) {
+	elapsed := now.Sub(r.lastReport)
+	if elapsed <= 0 {
+		return
+	}
+	rate := float64(r.bytes-r.lastBytes) / elapsed.Seconds()
+	if r.lastBytes == 0 && r.rate == 0 {
+		r.rate = rate
+	} else {
+		// exponentially weighted, the weight of a sample halves every 2 intervals or so
+		const alpha = 0.3
+		r.rate = alpha*rate + (1-alpha)*r.rate
+	}
+	r.lastReport = now
+	r.lastBytes = r.bytes
+}
+
+func (r *ProgressReader) progressLocked(now time.Time) Progress {
+	p := Progress{
+		Bytes: r.bytes,
+		Total: -1,
+		Rate:  r.rate,
+		ETA:   -1,
+		Done:  r.done,
+		Err:   r.err,
+	}
+	if r.done {
+		now = r.end
+	}
+	if !r.start.IsZero() {
+		p.Elapsed = now.Sub(r.start)
+	}
+	if r.opts.Total > 0 {
+		p.Total = r.opts.Total
+	}
+	switch {
+	case r.done:
+		p.ETA = 0
+	case p.Total > 0 && p.Rate > 0:
+		p.ETA = time.Duration(float64(max(p.Total-p.Bytes, 0)) / p.Rate * float64(time.Second))
+	}
+	return p
+}
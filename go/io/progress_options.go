@@ -0,0 +1,69 @@
+// Code generated by "go-option -type progress"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package io
+
+import "time"
+
+// A ProgressOption sets options.
+type ProgressOption interface {
+	apply(*progress)
+}
+
+// EmptyProgressOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyProgressOption struct{}
+
+func (EmptyProgressOption) apply(*progress) {}
+
+// ProgressOptionFunc wraps a function that modifies progress into an
+// implementation of the ProgressOption interface.
+type ProgressOptionFunc func(*progress)
+
+func (f ProgressOptionFunc) apply(do *progress) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *progress) ApplyOptions(options ...ProgressOption) *progress {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withProgress sets progress.
+func withProgress(v progress) ProgressOption {
+	return ProgressOptionFunc(func(o *progress) {
+		*o = v
+	})
+}
+
+// WithProgressTotal sets Total in progress.
+// Total is the total bytes to read, unknown if not bigger than 0.
+func WithProgressTotal(v int64) ProgressOption {
+	return ProgressOptionFunc(func(o *progress) {
+		o.Total = v
+	})
+}
+
+// WithProgressInterval sets Interval in progress.
+// Interval is the min interval between calls of ReportFunc, 1s if not set.
+func WithProgressInterval(v time.Duration) ProgressOption {
+	return ProgressOptionFunc(func(o *progress) {
+		o.Interval = v
+	})
+}
+
+// WithProgressReportFunc sets ReportFunc in progress.
+// ReportFunc is called with the progress every Interval when read, and once when done.
+func WithProgressReportFunc(v func(p Progress)) ProgressOption {
+	return ProgressOptionFunc(func(o *progress) {
+		o.ReportFunc = v
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package io_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	io_ "github.com/searKing/golang/go/io"
+)
+
+func TestProgressReader(t *testing.T) {
+	for _, total := range []int64{0, 1000} {
+		var reports []io_.Progress
+		r := io_.NewProgressReader(iotest.OneByteReader(strings.NewReader(strings.Repeat("x", 1000))),
+			io_.WithProgressTotal(total),
+			io_.WithProgressInterval(1), // reports every Read
+			io_.WithProgressReportFunc(func(p io_.Progress) { reports = append(reports, p) }))
+		if n, err := io.Copy(io.Discard, r); err != nil || n != 1000 {
+			t.Fatalf("Copy = %d, %v", n, err)
+		}
+		if len(reports) < 2 {
+			t.Fatalf("total %d: reported %d times", total, len(reports))
+		}
+
+		p := reports[len(reports)/2]
+		if p.Done || p.Bytes <= 0 || p.Bytes >= 1000 {
+			t.Errorf("total %d: Progress = %+v", total, p)
+		}
+		if unknown := p.Total < 0 || p.ETA < 0 || p.Percent() < 0; unknown != (total == 0) {
+			t.Errorf("total %d: Progress = %+v, total unknown = %t", total, p, unknown)
+		}
+
+		last := reports[len(reports)-1]
+		if !last.Done || last.Err != nil || last.Bytes != 1000 || last.ETA != 0 {
+			t.Errorf("total %d: last Progress = %+v", total, last)
+		}
+		if p := r.Progress(); p != last {
+			t.Errorf("total %d: Progress() = %+v, want %+v", total, p, last)
+		}
+	}
+}
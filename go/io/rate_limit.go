@@ -0,0 +1,152 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package io
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/searKing/golang/go/time/rate"
+)
+
+// defaultRateLimitChunkSize is the max bytes read or written at a time, if no smaller bursts of limiters.
+const defaultRateLimitChunkSize = 32 * 1024
+
+// RateLimitedReader is a Reader that limits the bytes read per second by limiters, one token a byte.
+//
+// A limiter can be shared by many readers and writers, as a group limit of their total bandwidth,
+// along with a limiter of each own.
+type RateLimitedReader struct {
+	ctx      context.Context
+	source   io.Reader
+	limiters []rate.Limiter
+}
+
+// NewRateLimitedReader returns a RateLimitedReader reading from r, limited by all the limiters not nil.
+// ctx cancels the waiting for limiters.
+func NewRateLimitedReader(ctx context.Context, r io.Reader, limiters ...rate.Limiter) *RateLimitedReader {
+	return &RateLimitedReader{ctx: ctx, source: r, limiters: nonNilLimiters(limiters)}
+}
+
+// Read reads up to the burst of limiters, and waits until the bytes read are permitted.
+func (r *RateLimitedReader) Read(p []byte) (n int, err error) {
+	if chunk := rateLimitChunkSize(r.limiters); len(p) > chunk {
+		p = p[:chunk]
+	}
+	n, err = r.source.Read(p)
+	if n <= 0 {
+		return n, err
+	}
+	if waitErr := waitLimiters(r.ctx, n, r.limiters); waitErr != nil {
+		return n, waitErr
+	}
+	return n, err
+}
+
+// RateLimitedWriter is a Writer that limits the bytes written per second by limiters, one token a byte.
+//
+// A limiter can be shared by many readers and writers, as a group limit of their total bandwidth,
+// along with a limiter of each own.
+type RateLimitedWriter struct {
+	ctx      context.Context
+	target   io.Writer
+	limiters []rate.Limiter
+}
+
+// NewRateLimitedWriter returns a RateLimitedWriter writing to w, limited by all the limiters not nil.
+// ctx cancels the waiting for limiters.
+func NewRateLimitedWriter(ctx context.Context, w io.Writer, limiters ...rate.Limiter) *RateLimitedWriter {
+	return &RateLimitedWriter{ctx: ctx, target: w, limiters: nonNilLimiters(limiters)}
+}
+
+// Write writes p in chunks up to the burst of limiters, each waits until permitted before written.
+func (w *RateLimitedWriter) Write(p []byte) (n int, err error) {
+	chunk := rateLimitChunkSize(w.limiters)
+	for len(p) > 0 {
+		b := p
+		if len(b) > chunk {
+			b = b[:chunk]
+		}
+		if err := waitLimiters(w.ctx, len(b), w.limiters); err != nil {
+			return n, err
+		}
+		m, err := w.target.Write(b)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func nonNilLimiters(limiters []rate.Limiter) []rate.Limiter {
+	var ls []rate.Limiter
+	for _, l := range limiters {
+		if l != nil {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// rateLimitChunkSize returns the max bytes permitted at a time by all the limiters,
+// the burst of token buckets, or the limit per window of sliding windows.
+func rateLimitChunkSize(limiters []rate.Limiter) int {
+	chunk := defaultRateLimitChunkSize
+	for _, l := range limiters {
+		var b int
+		switch l := l.(type) {
+		case interface{ Burst() int }:
+			b = l.Burst()
+		case interface{ Limit() int }:
+			b = l.Limit()
+		}
+		if b > 0 && b < chunk {
+			chunk = b
+		}
+	}
+	return chunk
+}
+
+// waitLimiters reserves n tokens of all the limiters at once, and waits until all are permitted,
+// so waiting on the limiters costs the longest delay, not the sum of them.
+func waitLimiters(ctx context.Context, n int, limiters []rate.Limiter) error {
+	if len(limiters) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rs := make([]*rate.DelayReservation, 0, len(limiters))
+	// tokens are restored as of the time canceled, not reserved
+	cancel := func(at time.Time) {
+		for _, r := range rs {
+			r.CancelAt(at)
+		}
+	}
+	var delay time.Duration
+	for _, l := range limiters {
+		r := l.ReserveN(now, n)
+		if !r.OK() {
+			cancel(now)
+			return fmt.Errorf("rate: Wait(n=%d) exceeds limiter's burst", n)
+		}
+		rs = append(rs, r)
+		delay = max(delay, r.DelayFrom(now))
+	}
+	if delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		cancel(time.Now())
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package io_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	io_ "github.com/searKing/golang/go/io"
+	"github.com/searKing/golang/go/time/rate"
+)
+
+func TestRateLimitedReader(t *testing.T) {
+	// 1000 bytes at once, and 10000 bytes per second then
+	lim := rate.NewTokenBucketLimiter(10000, 1000)
+	r := io_.NewRateLimitedReader(context.Background(), strings.NewReader(strings.Repeat("x", 5000)), lim)
+
+	start := time.Now()
+	buf := make([]byte, 4096)
+	var total int
+	for {
+		n, err := r.Read(buf)
+		if n > 1000 {
+			t.Fatalf("Read = %d bytes, more than the burst", n)
+		}
+		total += n
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+	}
+	if total != 5000 {
+		t.Errorf("Read %d bytes, want %d", total, 5000)
+	}
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("Read 5000 bytes in %v, want 400ms at least", elapsed)
+	}
+}
+
+func TestRateLimitedWriterGroup(t *testing.T) {
+	group := rate.NewTokenBucketLimiter(10000, 1000)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			// each stream may go faster, but the group is capped
+			w := io_.NewRateLimitedWriter(context.Background(), &buf, rate.NewTokenBucketLimiter(100000, 1000), group)
+			if n, err := w.Write(make([]byte, 2500)); err != nil || n != 2500 {
+				t.Errorf("Write = %d, %v", n, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("Write 5000 bytes in %v, want 400ms at least", elapsed)
+	}
+}
+
+func TestRateLimitedSlidingWindow(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		lim  func() rate.Limiter
+	}{
+		{"log", func() rate.Limiter { return rate.NewSlidingWindowLogLimiter(100, 50*time.Millisecond) }},
+		{"counter", func() rate.Limiter { return rate.NewSlidingWindowCounterLimiter(100, 50*time.Millisecond) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// chunked by the limit per window
+			var buf bytes.Buffer
+			w := io_.NewRateLimitedWriter(context.Background(), &buf, tt.lim())
+			if n, err := w.Write(make([]byte, 200)); err != nil || n != 200 {
+				t.Fatalf("Write = %d, %v; want 200 bytes written", n, err)
+			}
+
+			r := io_.NewRateLimitedReader(context.Background(), bytes.NewReader(make([]byte, 200)), tt.lim())
+			got, err := io.ReadAll(r)
+			if err != nil || len(got) != 200 {
+				t.Fatalf("ReadAll = %d, %v; want 200 bytes read", len(got), err)
+			}
+		})
+	}
+}
+
+func TestRateLimitedWriterCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := io_.NewRateLimitedWriter(ctx, io.Discard, rate.NewTokenBucketLimiter(1, 1))
+	if n, err := w.Write([]byte("ab")); err == nil || n != 1 {
+		t.Errorf("Write = %d, %v; want 1 byte written and canceled", n, err)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy controls what AsyncHandler does with a record when its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the caller until the queue has room, no record is lost.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop drops the record, the number of records dropped is reported by a
+	// record logged once the queue has room.
+	OverflowDrop
+	// OverflowDropBelowLevel drops records below DropLevel as OverflowDrop does,
+	// and blocks the caller for others as OverflowBlock does.
+	OverflowDropBelowLevel
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDrop:
+		return "drop"
+	case OverflowDropBelowLevel:
+		return "drop_below_level"
+	default:
+		return "unknown"
+	}
+}
+
+//go:generate go-option -type "async"
+type async struct {
+	// QueueSize is the number of records buffered, 1024 if not positive.
+	QueueSize int
+	// Workers is the number of goroutines handling records, 1 if not positive.
+	// Records are handled in the order they are logged only if Workers is 1.
+	Workers int
+	// BatchSize is the maximum number of records a worker takes from the queue at once,
+	// handled in a row before the worker reports progress to Flush, 64 if not positive.
+	BatchSize int
+	// OverflowPolicy controls what to do with a record when the queue is full.
+	OverflowPolicy OverflowPolicy
+	// DropLevel is the level below which records are dropped by OverflowDropBelowLevel,
+	// slog.LevelWarn if nil.
+	DropLevel slog.Leveler
+	// OnError is called with errors returned by the wrapped handler on worker goroutines,
+	// errors are ignored if nil.
+	OnError func(ctx context.Context, r slog.Record, err error)
+}
+
+// AsyncHandler is a slog.Handler handing records over to a bounded queue, which is drained
+// by background workers calling the wrapped handler, so that callers are not stalled by
+// slow writers, such as a disk during log bursts.
+//
+// Records are cloned and their values are resolved before queued, so that callers may
+// reuse attrs, and LogValuers are evaluated at the time of the log call.
+// Call Close to drain the queue and stop the workers.
+type AsyncHandler struct {
+	handler slog.Handler // the wrapped handler, with attrs and groups of this AsyncHandler
+	q       *asyncQueue
+}
+
+var _ slog.Handler = (*AsyncHandler)(nil)
+
+// asyncQueue is shared by an AsyncHandler and the handlers derived by WithAttrs and WithGroup.
+type asyncQueue struct {
+	opts    async
+	handler slog.Handler // the wrapped handler, to report dropped records
+	entries chan asyncEntry
+	dropped atomic.Int64
+
+	closeMu sync.RWMutex // held for reading by senders, for writing by Close
+	closed  bool
+
+	mu   sync.Mutex
+	gen  uint64                      // generation of records queued now, bumped by every Flush
+	gens map[uint64]*asyncGeneration // generations with records queued or being handled
+
+	workers sync.WaitGroup
+	done    chan struct{} // closed when all workers return
+}
+
+// asyncGeneration is the records queued between two Flush calls,
+// so that Flush waits only for the records queued before it is called.
+type asyncGeneration struct {
+	pending int           // records queued or being handled
+	drained chan struct{} // closed when pending drops to 0
+}
+
+type asyncEntry struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+	gen     uint64
+}
+
+// NewAsyncHandler returns an AsyncHandler handing records over to h by background workers.
+func NewAsyncHandler(h slog.Handler, opts ...AsyncOption) *AsyncHandler {
+	q := &asyncQueue{handler: h, gens: make(map[uint64]*asyncGeneration), done: make(chan struct{})}
+	q.opts.ApplyOptions(opts...)
+	if q.opts.QueueSize <= 0 {
+		q.opts.QueueSize = 1024
+	}
+	if q.opts.Workers <= 0 {
+		q.opts.Workers = 1
+	}
+	if q.opts.BatchSize <= 0 {
+		q.opts.BatchSize = 64
+	}
+	if q.opts.DropLevel == nil {
+		q.opts.DropLevel = slog.LevelWarn
+	}
+	q.entries = make(chan asyncEntry, q.opts.QueueSize)
+	q.workers.Add(q.opts.Workers)
+	for range q.opts.Workers {
+		go q.work()
+	}
+	go func() {
+		q.workers.Wait()
+		close(q.done)
+	}()
+	return &AsyncHandler{handler: h, q: q}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level.
+func (h *AsyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle queues a copy of r, to be handled by the wrapped handler on a worker goroutine.
+// The values of ctx are kept, but not its cancellation.
+// Handle handles r on the caller's goroutine once the AsyncHandler is closed.
+func (h *AsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	q := h.q
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+	if q.closed {
+		return h.handler.Handle(ctx, r)
+	}
+
+	e := asyncEntry{ctx: context.WithoutCancel(ctx), handler: h.handler, record: cloneRecord(r)}
+	e.gen = q.enqueue()
+	block := q.opts.OverflowPolicy == OverflowBlock ||
+		(q.opts.OverflowPolicy == OverflowDropBelowLevel && r.Level >= q.opts.DropLevel.Level())
+	if block {
+		q.entries <- e
+		return nil
+	}
+	select {
+	case q.entries <- e:
+	default:
+		q.dropped.Add(1)
+		q.dequeue(e.gen, 1)
+	}
+	return nil
+}
+
+// WithAttrs returns a new AsyncHandler sharing the queue and workers of h,
+// whose wrapped handler has the attrs.
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &AsyncHandler{handler: h.handler.WithAttrs(attrs), q: h.q}
+}
+
+// WithGroup returns a new AsyncHandler sharing the queue and workers of h,
+// whose wrapped handler has the group.
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	return &AsyncHandler{handler: h.handler.WithGroup(name), q: h.q}
+}
+
+// Dropped returns the number of records dropped since the last report of dropped records.
+func (h *AsyncHandler) Dropped() int64 {
+	return h.q.dropped.Load()
+}
+
+// Flush blocks until all records queued before the call are handled, or ctx is done.
+// Records logged meanwhile are not waited for, so that Flush returns under steady logging.
+func (h *AsyncHandler) Flush(ctx context.Context) error {
+	q := h.q
+	q.mu.Lock()
+	flushed := q.gen
+	q.gen++
+	var drained []chan struct{}
+	for gen, g := range q.gens {
+		if gen <= flushed {
+			drained = append(drained, g.drained)
+		}
+	}
+	q.mu.Unlock()
+	for _, d := range drained {
+		select {
+		case <-d:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Close stops queueing records, and blocks until all records queued are handled and
+// the workers return, or ctx is done. Records logged after Close are handled synchronously.
+// Close is shared by the handlers derived by WithAttrs and WithGroup.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	q := h.q
+	q.closeMu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.entries)
+	}
+	q.closeMu.Unlock()
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue counts a record pending in the current generation, and returns the generation.
+func (q *asyncQueue) enqueue() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	g, ok := q.gens[q.gen]
+	if !ok {
+		g = &asyncGeneration{drained: make(chan struct{})}
+		q.gens[q.gen] = g
+	}
+	g.pending++
+	return q.gen
+}
+
+// dequeue counts n records of generation gen as handled.
+func (q *asyncQueue) dequeue(gen uint64, n int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	g := q.gens[gen]
+	g.pending -= n
+	if g.pending == 0 {
+		close(g.drained)
+		delete(q.gens, gen)
+	}
+}
+
+func (q *asyncQueue) work() {
+	defer q.workers.Done()
+	batch := make([]asyncEntry, 0, q.opts.BatchSize)
+	for e := range q.entries {
+		batch = append(batch[:0], e)
+	fill:
+		for len(batch) < q.opts.BatchSize {
+			select {
+			case e, ok := <-q.entries:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, e)
+			default:
+				break fill
+			}
+		}
+		for i := range batch {
+			q.handle(batch[i].ctx, batch[i].handler, batch[i].record)
+		}
+		q.reportDropped()
+		// entries of a generation are mostly adjacent, count them by runs
+		for i := 0; i < len(batch); {
+			j := i + 1
+			for j < len(batch) && batch[j].gen == batch[i].gen {
+				j++
+			}
+			q.dequeue(batch[i].gen, j-i)
+			i = j
+		}
+		clear(batch) // release the records
+	}
+	q.reportDropped()
+}
+
+func (q *asyncQueue) handle(ctx context.Context, h slog.Handler, r slog.Record) {
+	if !h.Enabled(ctx, r.Level) {
+		return
+	}
+	if err := h.Handle(ctx, r); err != nil && q.opts.OnError != nil {
+		q.opts.OnError(ctx, r, err)
+	}
+}
+
+// reportDropped logs the number of records dropped since the last report, if any.
+func (q *asyncQueue) reportDropped() {
+	n := q.dropped.Swap(0)
+	if n == 0 {
+		return
+	}
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "slog: records dropped by AsyncHandler, queue is full", 0)
+	r.AddAttrs(slog.Int64("dropped", n), slog.String("overflow_policy", q.opts.OverflowPolicy.String()))
+	q.handle(context.Background(), q.handler, r)
+}
+
+// cloneRecord returns a copy of r which shares no state with r, with values resolved,
+// so that it can be handled after the log call returns.
+func cloneRecord(r slog.Record) slog.Record {
+	c := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, resolveAttr(a))
+		return true
+	})
+	c.AddAttrs(attrs...)
+	return c
+}
+
+// resolveAttr resolves LogValuers in a, and in groups of a recursively.
+func resolveAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+	group := a.Value.Group()
+	attrs := make([]slog.Attr, len(group))
+	for i, ga := range group {
+		attrs[i] = resolveAttr(ga)
+	}
+	a.Value = slog.GroupValue(attrs...)
+	return a
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// gatedHandler blocks Handle until gate is closed.
+type gatedHandler struct {
+	slog.Handler
+	gate <-chan struct{}
+}
+
+func (h gatedHandler) Handle(ctx context.Context, r slog.Record) error {
+	<-h.gate
+	return h.Handler.Handle(ctx, r)
+}
+
+type counterValuer struct{ n *int }
+
+func (v counterValuer) LogValue() slog.Value { return slog.IntValue(*v.n) }
+
+func TestAsyncHandler(t *testing.T) {
+	var buf lockedBuffer
+	h := slog_.NewAsyncHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		}}))
+	logger := slog.New(h).With("svc", "api").WithGroup("req")
+
+	n := 1
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.InfoContext(ctx, "first", "n", counterValuer{&n}, slog.Group("g", "v", counterValuer{&n}))
+	cancel() // records outlive the context of the log call
+	n = 2
+	logger.InfoContext(ctx, "second", "n", counterValuer{&n})
+
+	if err := h.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	want := `level=INFO msg=first svc=api req.n=1 req.g.v=1
+level=INFO msg=second svc=api req.n=2
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	// handled synchronously after Close
+	logger.Info("third")
+	if got := buf.String(); !strings.HasSuffix(got, "msg=third svc=api\n") {
+		t.Fatalf("record after Close is not handled: %q", got)
+	}
+}
+
+func TestAsyncHandlerOverflow(t *testing.T) {
+	tests := []struct {
+		policy      slog_.OverflowPolicy
+		level       slog.Level
+		wantHandled int
+		wantDropped bool
+	}{
+		{slog_.OverflowBlock, slog.LevelInfo, 10, false},
+		{slog_.OverflowDrop, slog.LevelError, 2, true}, // 1 being handled, 1 queued
+		{slog_.OverflowDropBelowLevel, slog.LevelInfo, 2, true},
+		{slog_.OverflowDropBelowLevel, slog.LevelWarn, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String()+"_"+tt.level.String(), func(t *testing.T) {
+			var buf lockedBuffer
+			gate := make(chan struct{})
+			h := slog_.NewAsyncHandler(gatedHandler{slog.NewTextHandler(&buf, nil), gate},
+				slog_.WithAsyncQueueSize(1), slog_.WithAsyncBatchSize(1),
+				slog_.WithAsyncOverflowPolicy(tt.policy))
+			logger := slog.New(h)
+
+			logger.Log(context.Background(), tt.level, "first")
+			// let the worker take the first record, so that only one more can be queued
+			time.Sleep(10 * time.Millisecond)
+			logged := make(chan struct{})
+			go func() {
+				defer close(logged)
+				for range 9 {
+					logger.Log(context.Background(), tt.level, "more")
+				}
+			}()
+			time.Sleep(10 * time.Millisecond)
+			close(gate)
+			<-logged
+			if err := h.Close(context.Background()); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			out := buf.String()
+			handled := strings.Count(out, "msg=first") + strings.Count(out, "msg=more")
+			if !tt.wantDropped && handled != tt.wantHandled {
+				t.Fatalf("got %d records handled in:\n%s\nwant %d", handled, out, tt.wantHandled)
+			}
+			if tt.wantDropped {
+				if handled != tt.wantHandled || !strings.Contains(out, "dropped=8") {
+					t.Fatalf("got %d records handled in:\n%s\nwant %d handled and 8 dropped", handled, out, tt.wantHandled)
+				}
+			}
+			if !tt.wantDropped && strings.Contains(out, "dropped=") {
+				t.Fatalf("records are dropped:\n%s", out)
+			}
+		})
+	}
+}
+
+// slowHandler sleeps before every Handle.
+type slowHandler struct {
+	slog.Handler
+	d time.Duration
+}
+
+func (h slowHandler) Handle(ctx context.Context, r slog.Record) error {
+	time.Sleep(h.d)
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestAsyncHandlerFlushWhileLogging(t *testing.T) {
+	var buf lockedBuffer
+	h := slog_.NewAsyncHandler(slowHandler{slog.NewTextHandler(&buf, nil), time.Millisecond}, slog_.WithAsyncWorkers(2))
+	logger := slog.New(h)
+	for i := range 10 {
+		logger.Info("before", "i", i)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				logger.Info("during")
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := h.Flush(ctx)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("Flush under steady logging: %v", err)
+	}
+	if got := strings.Count(buf.String(), "msg=before"); got != 10 {
+		t.Errorf("records handled before Flush returned = %d; want 10", got)
+	}
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestAsyncHandlerFlushTimeout(t *testing.T) {
+	gate := make(chan struct{})
+	h := slog_.NewAsyncHandler(gatedHandler{slog.NewTextHandler(&lockedBuffer{}, nil), gate})
+	slog.New(h).Info("blocked")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := h.Flush(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Flush = %v; want %v", err, context.DeadlineExceeded)
+	}
+	close(gate)
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by "go-option -type async"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package slog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// A AsyncOption sets options.
+type AsyncOption interface {
+	apply(*async)
+}
+
+// EmptyAsyncOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyAsyncOption struct{}
+
+func (EmptyAsyncOption) apply(*async) {}
+
+// AsyncOptionFunc wraps a function that modifies async into an
+// implementation of the AsyncOption interface.
+type AsyncOptionFunc func(*async)
+
+func (f AsyncOptionFunc) apply(do *async) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *async) ApplyOptions(options ...AsyncOption) *async {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withAsync sets async.
+func withAsync(v async) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		*o = v
+	})
+}
+
+// WithAsyncQueueSize sets QueueSize in async.
+// QueueSize is the number of records buffered, 1024 if not positive.
+func WithAsyncQueueSize(v int) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.QueueSize = v
+	})
+}
+
+// WithAsyncWorkers sets Workers in async.
+// Workers is the number of goroutines handling records, 1 if not positive.
+// Records are handled in the order they are logged only if Workers is 1.
+func WithAsyncWorkers(v int) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.Workers = v
+	})
+}
+
+// WithAsyncBatchSize sets BatchSize in async.
+// BatchSize is the maximum number of records a worker takes from the queue at once,
+// handled in a row before the worker reports progress to Flush, 64 if not positive.
+func WithAsyncBatchSize(v int) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.BatchSize = v
+	})
+}
+
+// WithAsyncOverflowPolicy sets OverflowPolicy in async.
+// OverflowPolicy controls what to do with a record when the queue is full.
+func WithAsyncOverflowPolicy(v OverflowPolicy) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.OverflowPolicy = v
+	})
+}
+
+// WithAsyncDropLevel sets DropLevel in async.
+// DropLevel is the level below which records are dropped by OverflowDropBelowLevel,
+// slog.LevelWarn if nil.
+func WithAsyncDropLevel(v slog.Leveler) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.DropLevel = v
+	})
+}
+
+// WithAsyncOnError sets OnError in async.
+// OnError is called with errors returned by the wrapped handler on worker goroutines,
+// errors are ignored if nil.
+func WithAsyncOnError(v func(ctx context.Context, r slog.Record, err error)) AsyncOption {
+	return AsyncOptionFunc(func(o *async) {
+		o.OnError = v
+	})
+}
@@ -0,0 +1,410 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go-option -type "entryReader"
+type entryReader struct {
+	// Location is the location of times in glog lines, which have no time zone, time.Local if nil.
+	Location *time.Location
+	// Follow keeps the last line not terminated by a newline at EOF, and the glog entry it may continue,
+	// until the rest is read by All called again, as the reader is followed like "tail -f".
+	Follow bool
+}
+
+// Entry is a record parsed from a log line, see EntryReader.
+type Entry struct {
+	Time     time.Time
+	Level    slog.Level
+	Message  string
+	Source   *slog.Source // nil if not logged
+	ThreadID int          // threadid of glog lines, 0 if not logged
+	// Attrs are the attrs of the record.
+	// Values of glog lines are strings, and groups are flattened as keys joined by ".".
+	// Values of JSON lines are of kinds String, Int64, Float64, Bool, Group, or Any of []any and nil.
+	Attrs []slog.Attr
+}
+
+// Record returns a slog.Record of e, without the source.
+func (e Entry) Record() slog.Record {
+	r := slog.NewRecord(e.Time, e.Level, e.Message, 0)
+	r.AddAttrs(e.Attrs...)
+	return r
+}
+
+// EntryReader reads entries from log lines written by GlogHandler, GlogHumanHandler or
+// slog.JSONHandler, mixed formats included.
+// Lines following a glog line and not beginning a new entry, such as stack traces, are parts of its
+// message and attrs, other lines not beginning an entry, such as headers of rotated files, are skipped.
+//
+// Attrs of glog lines are parsed as ", key=value" following the message, so a message containing
+// ", key=value" is parsed as attrs.
+type EntryReader struct {
+	r    *bufio.Reader
+	opts entryReader
+	err  error
+
+	partial string   // last line not terminated by a newline yet, kept if Follow
+	pending *Entry   // glog entry, whose lines are not all read yet
+	lines   []string // body lines of pending
+}
+
+// NewEntryReader returns an EntryReader reading from r.
+func NewEntryReader(r io.Reader, opts ...EntryReaderOption) *EntryReader {
+	er := &EntryReader{r: bufio.NewReader(r)}
+	er.opts.ApplyOptions(opts...)
+	if er.opts.Location == nil {
+		er.opts.Location = time.Local
+	}
+	return er
+}
+
+// Err returns the first non-EOF error encountered by All.
+func (r *EntryReader) Err() error {
+	return r.err
+}
+
+// All returns an iterator over entries read, until EOF or an error, see Err.
+// An entry of glog lines is yielded once the line beginning the next entry is read, or at EOF.
+// If Follow is set, All can be called again to read on after EOF, and at EOF with a partial line read,
+// the entry it may continue is kept until the line is completed.
+func (r *EntryReader) All() iter.Seq[Entry] {
+	return func(yield func(Entry) bool) {
+		flush := func() bool {
+			if r.pending == nil {
+				return true
+			}
+			e := *r.pending
+			e.Message, e.Attrs = parseGlogBody(strings.Join(r.lines, "\n"))
+			r.pending, r.lines = nil, r.lines[:0]
+			return yield(e)
+		}
+		handle := func(line string) bool {
+			if strings.HasPrefix(line, "{") {
+				if e, err := parseJSONEntry(line); err == nil {
+					return flush() && yield(e)
+				}
+			}
+			if e, body, ok := r.parseGlogHeader(line); ok {
+				if !flush() {
+					return false
+				}
+				r.pending, r.lines = &e, append(r.lines[:0], body)
+				return true
+			}
+			if r.pending != nil {
+				r.lines = append(r.lines, line)
+			}
+			return true
+		}
+		for {
+			line, err := r.r.ReadString('\n')
+			if r.partial != "" {
+				line, r.partial = r.partial+line, ""
+			}
+			if err != nil && r.opts.Follow {
+				// not terminated by a newline, the rest is yet to be written
+				line, r.partial = "", line
+			}
+			line = strings.TrimRight(line, "\r\n")
+			if line != "" || err == nil {
+				if !handle(line) {
+					return
+				}
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					r.err = err
+				}
+				if r.partial == "" {
+					flush()
+				}
+				return
+			}
+		}
+	}
+}
+
+var (
+	// Lyyyymmdd hh:mm:ss.uuuuuu threadid file:line] msg...
+	glogLineRe = regexp.MustCompile(`^([DIWEF])(\d{8} \d\d:\d\d:\d\d\.\d{6}) +(\d+)(?: ([^\]\s]*))?\] ?`)
+	// [LLLLL][yyyymmdd hh:mm:ss.uuuuuu] [threadid] [file:line(func)] msg...
+	glogHumanLineRe = regexp.MustCompile(`^\[([A-Z0-9+\- ]{5})\] ?\[(\d{8} \d\d:\d\d:\d\d\.\d{6})\] \[ *(\d+)\](?: \[([^\]]*)\](?:\(([^)]*)\))?)? ?`)
+	// , key=
+	glogAttrRe = regexp.MustCompile(", ([^\\s=,\"`]+)=")
+)
+
+const glogTimeLayout = "20060102 15:04:05.000000"
+
+func (r *EntryReader) parseGlogHeader(line string) (e Entry, body string, ok bool) {
+	var level, ts, tid, source, function string
+	if m := glogLineRe.FindStringSubmatchIndex(line); m != nil {
+		level, ts, tid, source = submatch(line, m, 1), submatch(line, m, 2), submatch(line, m, 3), submatch(line, m, 4)
+		switch level {
+		case "D":
+			e.Level = slog.LevelDebug
+		case "I":
+			e.Level = slog.LevelInfo
+		case "W":
+			e.Level = slog.LevelWarn
+		case "E":
+			e.Level = slog.LevelError
+		case "F":
+			e.Level = slog.LevelError + 4
+		}
+		body = line[m[1]:]
+	} else if m := glogHumanLineRe.FindStringSubmatchIndex(line); m != nil {
+		level, ts, tid, source, function = submatch(line, m, 1), submatch(line, m, 2), submatch(line, m, 3), submatch(line, m, 4), submatch(line, m, 5)
+		e.Level = parseLevelPrefix(strings.TrimSpace(level))
+		body = line[m[1]:]
+	} else {
+		return Entry{}, "", false
+	}
+
+	t, err := time.ParseInLocation(glogTimeLayout, ts, r.opts.Location)
+	if err != nil {
+		return Entry{}, "", false
+	}
+	e.Time = t
+	e.ThreadID, _ = strconv.Atoi(tid)
+	if source != "" || function != "" {
+		e.Source = &slog.Source{Function: function, File: source}
+		if i := strings.LastIndexByte(source, ':'); i >= 0 {
+			if n, err := strconv.Atoi(source[i+1:]); err == nil {
+				e.Source.File, e.Source.Line = source[:i], n
+			}
+		}
+	}
+	return e, body, true
+}
+
+func submatch(s string, m []int, i int) string {
+	if m[2*i] < 0 {
+		return ""
+	}
+	return s[m[2*i]:m[2*i+1]]
+}
+
+// parseLevelPrefix parses a level, which may be truncated, such as "INFO+" of "INFO+2".
+func parseLevelPrefix(s string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err == nil {
+		return l
+	}
+	for _, l := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		if strings.HasPrefix(s, l.String()) {
+			return l
+		}
+	}
+	return slog.LevelInfo
+}
+
+// parseGlogBody parses "msg, key=value, key=value" of glog lines.
+func parseGlogBody(body string) (msg string, attrs []slog.Attr) {
+	locs := glogAttrRe.FindAllStringSubmatchIndex(body, -1)
+	if len(locs) == 0 {
+		return body, nil
+	}
+	msg = body[:locs[0][0]]
+	for i := 0; i < len(locs); {
+		key := body[locs[i][2]:locs[i][3]]
+		start := locs[i][1]
+		next := i + 1
+		end := len(body)
+		if next < len(locs) {
+			end = locs[next][0]
+		}
+		value := body[start:end]
+		if q := quotedEnd(body, start); q > 0 {
+			if v, err := strconv.Unquote(body[start:q]); err == nil {
+				value = v
+				// skip ", key=" in the quoted value
+				for next < len(locs) && locs[next][0] < q {
+					next++
+				}
+			}
+		}
+		attrs = append(attrs, slog.String(key, value))
+		i = next
+	}
+	return msg, attrs
+}
+
+// quotedEnd returns the end of the quoted string at s[i:], followed by ", " or the end of s,
+// or -1 if not quoted.
+func quotedEnd(s string, i int) int {
+	if i >= len(s) {
+		return -1
+	}
+	end := -1
+	switch s[i] {
+	case '`':
+		if j := strings.IndexByte(s[i+1:], '`'); j >= 0 {
+			end = i + 1 + j + 1
+		}
+	case '"':
+		for j := i + 1; j < len(s); j++ {
+			if s[j] == '\\' {
+				j++
+				continue
+			}
+			if s[j] == '"' {
+				end = j + 1
+				break
+			}
+		}
+	}
+	if end < 0 || (end < len(s) && !strings.HasPrefix(s[end:], ", ")) {
+		return -1
+	}
+	return end
+}
+
+// parseJSONEntry parses a line of slog.JSONHandler, keeping the order of attrs.
+func parseJSONEntry(line string) (Entry, error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return Entry{}, fmt.Errorf("slog: malformed json entry: %s", line)
+	}
+	var e Entry
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return Entry{}, err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case slog.TimeKey:
+			err = dec.Decode(&e.Time)
+		case slog.LevelKey:
+			var level string
+			if err = dec.Decode(&level); err == nil {
+				err = e.Level.UnmarshalText([]byte(level))
+			}
+		case slog.MessageKey:
+			err = dec.Decode(&e.Message)
+		case slog.SourceKey:
+			e.Source = &slog.Source{}
+			err = dec.Decode(e.Source)
+		default:
+			var v slog.Value
+			v, err = decodeJSONValue(dec)
+			e.Attrs = append(e.Attrs, slog.Attr{Key: key, Value: v})
+		}
+		if err != nil {
+			return Entry{}, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return Entry{}, err
+	}
+	return e, nil
+}
+
+func decodeJSONValue(dec *json.Decoder) (slog.Value, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return slog.Value{}, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			var attrs []slog.Attr
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return slog.Value{}, err
+				}
+				key, _ := tok.(string)
+				v, err := decodeJSONValue(dec)
+				if err != nil {
+					return slog.Value{}, err
+				}
+				attrs = append(attrs, slog.Attr{Key: key, Value: v})
+			}
+			_, err = dec.Token()
+			return slog.GroupValue(attrs...), err
+		case '[':
+			var vs []any
+			for dec.More() {
+				v, err := decodeJSONValue(dec)
+				if err != nil {
+					return slog.Value{}, err
+				}
+				vs = append(vs, v.Any())
+			}
+			_, err = dec.Token()
+			return slog.AnyValue(vs), err
+		}
+	case string:
+		return slog.StringValue(t), nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return slog.Int64Value(i), nil
+		}
+		f, err := t.Float64()
+		return slog.Float64Value(f), err
+	case bool:
+		return slog.BoolValue(t), nil
+	case nil:
+		return slog.AnyValue(nil), nil
+	}
+	return slog.Value{}, fmt.Errorf("slog: unexpected json token %v", tok)
+}
+
+// MergeEntries returns an iterator over entries of seqs in time order, such as of rotated files,
+// entries of each seq are supposed in time order, the entries of the same time are in the order of seqs.
+func MergeEntries(seqs ...iter.Seq[Entry]) iter.Seq[Entry] {
+	return func(yield func(Entry) bool) {
+		type head struct {
+			next func() (Entry, bool)
+			stop func()
+			e    Entry
+			ok   bool
+		}
+		heads := make([]*head, 0, len(seqs))
+		defer func() {
+			for _, h := range heads {
+				h.stop()
+			}
+		}()
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			h := &head{next: next, stop: stop}
+			h.e, h.ok = next()
+			heads = append(heads, h)
+		}
+		for {
+			var first *head
+			for _, h := range heads {
+				if h.ok && (first == nil || h.e.Time.Before(first.e.Time)) {
+					first = h
+				}
+			}
+			if first == nil {
+				return
+			}
+			if !yield(first.e) {
+				return
+			}
+			first.e, first.ok = first.next()
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+func TestEntryReaderGlog(t *testing.T) {
+	input := `Log file created at: 2026/10/18 21:40:20
+Running on machine: host
+Log line format: [IWEF]yyyymmdd hh:mm:ss.uuuuuu threadid file:line] msg
+W20261018 21:40:20.560921 32741 main.go:21] line1
+line2, svc=api, req.k=v w, req.q="a, b=c", req.u.id=7
+[ERROR][20261018 21:40:20.561081] [  322] [main.go:22](main.run) failed, err=` + "`boom`" + `
+[INFO+][20261018 21:40:20.561082] [322] no source
+`
+	r := slog_.NewEntryReader(strings.NewReader(input), slog_.WithEntryReaderLocation(time.UTC))
+	got := slices.Collect(r.All())
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+	want := []slog_.Entry{{
+		Time:     time.Date(2026, 10, 18, 21, 40, 20, 560921000, time.UTC),
+		Level:    slog.LevelWarn,
+		Message:  "line1\nline2",
+		Source:   &slog.Source{File: "main.go", Line: 21},
+		ThreadID: 32741,
+		Attrs: []slog.Attr{slog.String("svc", "api"), slog.String("req.k", "v w"),
+			slog.String("req.q", "a, b=c"), slog.String("req.u.id", "7")},
+	}, {
+		Time:     time.Date(2026, 10, 18, 21, 40, 20, 561081000, time.UTC),
+		Level:    slog.LevelError,
+		Message:  "failed",
+		Source:   &slog.Source{Function: "main.run", File: "main.go", Line: 22},
+		ThreadID: 322,
+		Attrs:    []slog.Attr{slog.String("err", "boom")},
+	}, {
+		Time:     time.Date(2026, 10, 18, 21, 40, 20, 561082000, time.UTC),
+		Level:    slog.LevelInfo,
+		Message:  "no source",
+		ThreadID: 322,
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestEntryReaderFollow(t *testing.T) {
+	var buf bytes.Buffer
+	r := slog_.NewEntryReader(&buf, slog_.WithEntryReaderLocation(time.UTC), slog_.WithEntryReaderFollow(true))
+	messages := func() []string {
+		var msgs []string
+		for e := range r.All() {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+
+	// the last line is written partially, its entry and the entry it may continue are kept
+	buf.WriteString("I20261018 21:40:20.000001 1 main.go:1] a1\nstack")
+	if got := messages(); len(got) != 0 {
+		t.Fatalf("got %q, want no entries at a partial line", got)
+	}
+	// the entry is complete once idle at a line end
+	buf.WriteString(" trace\n")
+	if got, want := messages(), []string{"a1\nstack trace"}; !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	buf.WriteString("I20261018 21:40:20.000002 1 main.go:2] a")
+	if got := messages(); len(got) != 0 {
+		t.Fatalf("got %q, want no entries at a partial line", got)
+	}
+	buf.WriteString("2\n")
+	if got, want := messages(), []string{"a2"}; !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+}
+
+func TestEntryReaderJSON(t *testing.T) {
+	input := `{"time":"2026-10-18T21:40:20.5Z","level":"WARN+2","msg":"hello","svc":"api","req":{"n":3,"f":1.5,"ok":true,"ids":[1,"a"],"nil":null}}` + "\n"
+	got := slices.Collect(slog_.NewEntryReader(strings.NewReader(input)).All())
+	if len(got) != 1 {
+		t.Fatalf("got %d entries, want 1", len(got))
+	}
+	e := got[0]
+	if want := time.Date(2026, 10, 18, 21, 40, 20, 500000000, time.UTC); !e.Time.Equal(want) {
+		t.Errorf("time: got %v, want %v", e.Time, want)
+	}
+	if e.Level != slog.LevelWarn+2 || e.Message != "hello" {
+		t.Errorf("got level %v, message %q", e.Level, e.Message)
+	}
+	if len(e.Attrs) != 2 || e.Attrs[0].Key != "svc" || e.Attrs[1].Key != "req" {
+		t.Fatalf("attrs: got %v", e.Attrs)
+	}
+	group := e.Attrs[1].Value.Group()
+	var kinds []slog.Kind
+	for _, a := range group {
+		kinds = append(kinds, a.Value.Kind())
+	}
+	if wantKinds := []slog.Kind{slog.KindInt64, slog.KindFloat64, slog.KindBool, slog.KindAny, slog.KindAny}; !slices.Equal(kinds, wantKinds) {
+		t.Errorf("kinds: got %v, want %v", kinds, wantKinds)
+	}
+}
+
+func TestMergeEntries(t *testing.T) {
+	a := "I20261018 21:40:20.000001 1] a1\nI20261018 21:40:20.000003 1] a3\n"
+	b := `{"time":"2026-10-18T21:40:20.000002Z","level":"INFO","msg":"b2"}` + "\n" +
+		`{"time":"2026-10-18T21:40:20.000003Z","level":"INFO","msg":"b3"}` + "\n"
+	var got []string
+	for e := range slog_.MergeEntries(
+		slog_.NewEntryReader(strings.NewReader(a), slog_.WithEntryReaderLocation(time.UTC)).All(),
+		slog_.NewEntryReader(strings.NewReader(b)).All()) {
+		got = append(got, e.Message)
+	}
+	if want := []string{"a1", "b2", "a3", "b3"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+// Code generated by "go-option -type entryReader"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package slog
+
+import "time"
+
+// A EntryReaderOption sets options.
+type EntryReaderOption interface {
+	apply(*entryReader)
+}
+
+// EmptyEntryReaderOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyEntryReaderOption struct{}
+
+func (EmptyEntryReaderOption) apply(*entryReader) {}
+
+// EntryReaderOptionFunc wraps a function that modifies entryReader into an
+// implementation of the EntryReaderOption interface.
+type EntryReaderOptionFunc func(*entryReader)
+
+func (f EntryReaderOptionFunc) apply(do *entryReader) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *entryReader) ApplyOptions(options ...EntryReaderOption) *entryReader {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withEntryReader sets entryReader.
+func withEntryReader(v entryReader) EntryReaderOption {
+	return EntryReaderOptionFunc(func(o *entryReader) {
+		*o = v
+	})
+}
+
+// WithEntryReaderLocation sets Location in entryReader.
+// Location is the location of times in glog lines, which have no time zone, time.Local if nil.
+func WithEntryReaderLocation(v *time.Location) EntryReaderOption {
+	return EntryReaderOptionFunc(func(o *entryReader) {
+		o.Location = v
+	})
+}
+
+// WithEntryReaderFollow sets Follow in entryReader.
+// Follow keeps the last line not terminated by a newline at EOF, and the glog entry it may continue,
+// until the rest is read by All called again, as the reader is followed like "tail -f".
+func WithEntryReaderFollow(v bool) EntryReaderOption {
+	return EntryReaderOptionFunc(func(o *entryReader) {
+		o.Follow = v
+	})
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+//go:generate go-option -type "flightRecorder"
+type flightRecorder struct {
+	// Key returns the key of the context records are kept by, such as a trace ID or a request ID.
+	// Records of a context without a key are handled as if there were no FlightRecorderHandler.
+	Key func(ctx context.Context) string
+	// Size is the number of records kept per key, the oldest record is discarded if exceeded,
+	// 32 if not positive.
+	Size int
+	// MaxKeys is the maximum number of keys tracked, the records of the least recently used key
+	// are discarded if exceeded, 1000 if not positive.
+	MaxKeys int
+	// TTL is the period the records of a key are kept since the last record kept of the key,
+	// so that the records of requests ended without Dump or Discard are released, 1m if not positive.
+	TTL time.Duration
+	// RecordLevel is the level below which records disabled by the wrapped handler are kept,
+	// instead of being discarded, slog.LevelInfo if nil.
+	RecordLevel slog.Leveler
+	// DumpLevel is the level at or above which a record dumps the records kept of its key,
+	// before being handled, slog.LevelError if nil.
+	DumpLevel slog.Leveler
+
+	// Clock tells the time to expire records by TTL, time_.RealClock if nil.
+	Clock time_.Clock
+}
+
+// FlightRecorderHandler is a slog.Handler keeping the last debug records, disabled by the wrapped
+// handler, in memory per key of the context, such as a trace ID or a request ID.
+// The records kept are written to the wrapped handler only when a record at DumpLevel is logged
+// in a context of the same key, or when Dump is called, which gives the full debug context of
+// failed requests, without paying debug-level I/O for every request.
+// Call Discard when a request succeeds, or the records are kept until TTL expires.
+//
+// Records are cloned and their values are resolved before kept, see NewAsyncHandler.
+type FlightRecorderHandler struct {
+	handler slog.Handler // the wrapped handler, with attrs and groups of this FlightRecorderHandler
+	fr      *flightRecords
+}
+
+var _ slog.Handler = (*FlightRecorderHandler)(nil)
+
+// flightRecords is shared by a FlightRecorderHandler and the handlers derived by WithAttrs and WithGroup.
+type flightRecords struct {
+	opts flightRecorder
+
+	mu    sync.Mutex
+	ll    *list.List // of *flightRing, most recently used at front
+	cache map[string]*list.Element
+}
+
+// flightRing is a ring buffer of records kept of a key.
+type flightRing struct {
+	key     string
+	updated time.Time // when the last record is kept
+	entries []flightEntry
+	start   int // index of the oldest entry
+	n       int // number of entries
+}
+
+type flightEntry struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// NewFlightRecorderHandler returns a FlightRecorderHandler wrapping h.
+func NewFlightRecorderHandler(h slog.Handler, opts ...FlightRecorderOption) *FlightRecorderHandler {
+	fr := &flightRecords{ll: list.New(), cache: make(map[string]*list.Element)}
+	fr.opts.ApplyOptions(opts...)
+	if fr.opts.Size <= 0 {
+		fr.opts.Size = 32
+	}
+	if fr.opts.MaxKeys <= 0 {
+		fr.opts.MaxKeys = 1000
+	}
+	if fr.opts.TTL <= 0 {
+		fr.opts.TTL = time.Minute
+	}
+	fr.opts.Clock = time_.ClockOrDefault(fr.opts.Clock)
+	if fr.opts.RecordLevel == nil {
+		fr.opts.RecordLevel = slog.LevelInfo
+	}
+	if fr.opts.DumpLevel == nil {
+		fr.opts.DumpLevel = slog.LevelError
+	}
+	return &FlightRecorderHandler{handler: h, fr: fr}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level,
+// or records at the given level are kept for ctx.
+func (h *FlightRecorderHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.handler.Enabled(ctx, level) {
+		return true
+	}
+	return level < h.fr.opts.RecordLevel.Level() && h.fr.key(ctx) != ""
+}
+
+// Handle keeps r if it is disabled by the wrapped handler and below RecordLevel, or dumps the
+// records kept of the key of ctx before handling r if r is at or above DumpLevel.
+func (h *FlightRecorderHandler) Handle(ctx context.Context, r slog.Record) error {
+	enabled := h.handler.Enabled(ctx, r.Level)
+	if !enabled && r.Level >= h.fr.opts.RecordLevel.Level() {
+		return nil
+	}
+	key := h.fr.key(ctx)
+	if !enabled {
+		if key != "" {
+			h.fr.add(key, flightEntry{ctx: context.WithoutCancel(ctx), handler: h.handler, record: cloneRecord(r)})
+		}
+		return nil
+	}
+	var errs []error
+	if key != "" && r.Level >= h.fr.opts.DumpLevel.Level() {
+		errs = append(errs, h.fr.dump(key))
+	}
+	errs = append(errs, h.handler.Handle(ctx, r))
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new FlightRecorderHandler sharing the records kept of h,
+// whose wrapped handler has the attrs.
+func (h *FlightRecorderHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &FlightRecorderHandler{handler: h.handler.WithAttrs(attrs), fr: h.fr}
+}
+
+// WithGroup returns a new FlightRecorderHandler sharing the records kept of h,
+// whose wrapped handler has the group.
+func (h *FlightRecorderHandler) WithGroup(name string) slog.Handler {
+	return &FlightRecorderHandler{handler: h.handler.WithGroup(name), fr: h.fr}
+}
+
+// Dump writes the records kept of the key of ctx to the wrapped handler, oldest first,
+// and discards them.
+func (h *FlightRecorderHandler) Dump(ctx context.Context) error {
+	key := h.fr.key(ctx)
+	if key == "" {
+		return nil
+	}
+	return h.fr.dump(key)
+}
+
+// Discard discards the records kept of the key of ctx, such as when a request succeeds.
+func (h *FlightRecorderHandler) Discard(ctx context.Context) {
+	key := h.fr.key(ctx)
+	if key == "" {
+		return
+	}
+	h.fr.mu.Lock()
+	defer h.fr.mu.Unlock()
+	if e, ok := h.fr.cache[key]; ok {
+		h.fr.ll.Remove(e)
+		delete(h.fr.cache, key)
+	}
+}
+
+func (fr *flightRecords) key(ctx context.Context) string {
+	if fr.opts.Key == nil || ctx == nil {
+		return ""
+	}
+	return fr.opts.Key(ctx)
+}
+
+func (fr *flightRecords) add(key string, entry flightEntry) {
+	now := fr.opts.Clock.Now()
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	fr.expireLocked(now)
+	var ring *flightRing
+	if e, ok := fr.cache[key]; ok {
+		fr.ll.MoveToFront(e)
+		ring = e.Value.(*flightRing)
+	} else {
+		ring = &flightRing{key: key, entries: make([]flightEntry, fr.opts.Size)}
+		fr.cache[key] = fr.ll.PushFront(ring)
+		for fr.ll.Len() > fr.opts.MaxKeys {
+			oldest := fr.ll.Back()
+			fr.ll.Remove(oldest)
+			delete(fr.cache, oldest.Value.(*flightRing).key)
+		}
+	}
+	ring.updated = now
+	if ring.n < len(ring.entries) {
+		ring.entries[(ring.start+ring.n)%len(ring.entries)] = entry
+		ring.n++
+		return
+	}
+	// overwrite the oldest
+	ring.entries[ring.start] = entry
+	ring.start = (ring.start + 1) % len(ring.entries)
+}
+
+// expireLocked discards the records of keys not kept for TTL, least recently used first.
+func (fr *flightRecords) expireLocked(now time.Time) {
+	for e := fr.ll.Back(); e != nil; e = fr.ll.Back() {
+		ring := e.Value.(*flightRing)
+		if now.Sub(ring.updated) < fr.opts.TTL {
+			return
+		}
+		fr.ll.Remove(e)
+		delete(fr.cache, ring.key)
+	}
+}
+
+// dump handles the records kept of key, and discards them.
+func (fr *flightRecords) dump(key string) error {
+	now := fr.opts.Clock.Now()
+	fr.mu.Lock()
+	fr.expireLocked(now)
+	e, ok := fr.cache[key]
+	if ok {
+		fr.ll.Remove(e)
+		delete(fr.cache, key)
+	}
+	fr.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	ring := e.Value.(*flightRing)
+	var errs []error
+	for i := range ring.n {
+		entry := ring.entries[(ring.start+i)%len(ring.entries)]
+		errs = append(errs, entry.handler.Handle(entry.ctx, entry.record))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+	time_ "github.com/searKing/golang/go/time"
+)
+
+type requestIDKey struct{}
+
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func TestFlightRecorderHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog_.NewFlightRecorderHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: removeTime}),
+		slog_.WithFlightRecorderKey(func(ctx context.Context) string {
+			id, _ := ctx.Value(requestIDKey{}).(string)
+			return id
+		}),
+		slog_.WithFlightRecorderSize(2))
+	logger := slog.New(h).With("svc", "api")
+
+	a := withRequestID(context.Background(), "a")
+	b := withRequestID(context.Background(), "b")
+	logger.DebugContext(a, "a1")
+	logger.DebugContext(b, "b1")
+	logger.DebugContext(a, "a2")
+	logger.WithGroup("g").DebugContext(a, "a3", "k", "v") // a1 is discarded
+	logger.InfoContext(a, "info")                         // handled as it is, not kept
+	logger.Debug("no key")                                // discarded
+	logger.ErrorContext(a, "failed")
+
+	want := `level=INFO msg=info svc=api
+level=DEBUG msg=a2 svc=api
+level=DEBUG msg=a3 svc=api g.k=v
+level=ERROR msg=failed svc=api
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// records of a are dumped already
+	buf.Reset()
+	logger.ErrorContext(a, "failed again")
+	if got, want := buf.String(), "level=ERROR msg=\"failed again\" svc=api\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := h.Dump(b); err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+	if got, want := buf.String(), "level=DEBUG msg=b1 svc=api\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.DebugContext(b, "b2")
+	h.Discard(b)
+	logger.ErrorContext(b, "failed")
+	if got, want := buf.String(), "level=ERROR msg=failed svc=api\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFlightRecorderHandlerTTL(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	var buf bytes.Buffer
+	h := slog_.NewFlightRecorderHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: removeTime}),
+		slog_.WithFlightRecorderKey(func(ctx context.Context) string {
+			id, _ := ctx.Value(requestIDKey{}).(string)
+			return id
+		}),
+		slog_.WithFlightRecorderTTL(time.Minute),
+		slog_.WithFlightRecorderClock(clock))
+	logger := slog.New(h)
+
+	a := withRequestID(context.Background(), "a")
+	b := withRequestID(context.Background(), "b")
+	logger.DebugContext(a, "a1")
+	clock.Step(30 * time.Second)
+	logger.DebugContext(b, "b1")
+	clock.Step(30 * time.Second) // records of a expire, of b are kept
+	logger.ErrorContext(a, "failed")
+	logger.ErrorContext(b, "failed")
+
+	want := `level=ERROR msg=failed
+level=DEBUG msg=b1
+level=ERROR msg=failed
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFlightRecorderHandlerEnabled(t *testing.T) {
+	h := slog_.NewFlightRecorderHandler(
+		slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		slog_.WithFlightRecorderKey(func(ctx context.Context) string {
+			id, _ := ctx.Value(requestIDKey{}).(string)
+			return id
+		}))
+	ctx := withRequestID(context.Background(), "a")
+	if !h.Enabled(ctx, slog.LevelDebug) {
+		t.Error("debug records of a context with a key should be enabled")
+	}
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("debug records of a context without a key should be disabled")
+	}
+	if !h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("records enabled by the wrapped handler should be enabled")
+	}
+}
@@ -543,7 +543,7 @@ func TestHandlersWithUnavailableSource(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			buf.Reset()
 			r := slog.NewRecord(time.Time{}, slog.LevelInfo, "message", 0)
-			err := test.h.Handle(t.Context(), r)
+			err := test.h.Handle(context.Background(), r)
 			if err != nil {
 				t.Fatal(err)
 			}
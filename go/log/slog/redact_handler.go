@@ -0,0 +1,460 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactMode controls how sensitive data is replaced.
+type RedactMode int
+
+const (
+	// RedactMask replaces sensitive data with the Mask.
+	RedactMask RedactMode = iota
+	// RedactHash replaces sensitive data with a keyed hash of it, such as "sha256:0123456789abcdef",
+	// so that records of the same value can be correlated without revealing it.
+	RedactHash
+	// RedactDrop removes attrs and struct fields of sensitive data.
+	// Substrings matched by patterns in the message are masked instead.
+	RedactDrop
+)
+
+func (m RedactMode) String() string {
+	switch m {
+	case RedactMask:
+		return "mask"
+	case RedactHash:
+		return "hash"
+	case RedactDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+// parseRedactMode parses a RedactMode by its String.
+func parseRedactMode(s string) (RedactMode, bool) {
+	switch s {
+	case "mask":
+		return RedactMask, true
+	case "hash":
+		return RedactHash, true
+	case "drop":
+		return RedactDrop, true
+	default:
+		return 0, false
+	}
+}
+
+// RedactTagKey is the key of struct tags marking fields of sensitive data, in the form of
+// `log:"redact"`, or `log:"redact,hash"` with the RedactMode of the field.
+const RedactTagKey = "log"
+
+// DefaultRedactMask is the Mask replacing sensitive data by default.
+const DefaultRedactMask = "[REDACTED]"
+
+// DefaultRedactKeys are globs of keys of credentials, matched case-insensitively.
+var DefaultRedactKeys = []string{
+	"*password*", "passwd", "*secret*", "*token", "*api_key", "*apikey", "*credential*",
+	"authorization", "proxy-authorization", "*cookie", "private_key", "privatekey",
+}
+
+var (
+	// RedactPatternBearer matches bearer tokens, such as in an Authorization header.
+	RedactPatternBearer = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`)
+	// RedactPatternJWT matches JSON Web Tokens.
+	RedactPatternJWT = regexp.MustCompile(`\beyJ[a-zA-Z0-9_-]*\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]*`)
+	// RedactPatternEmail matches email addresses.
+	RedactPatternEmail = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}`)
+	// RedactPatternCardNumber matches payment card numbers of 13 to 19 digits, optionally
+	// separated by spaces or dashes.
+	RedactPatternCardNumber = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// DefaultRedactPatterns are patterns of credentials in values.
+var DefaultRedactPatterns = []*regexp.Regexp{RedactPatternBearer, RedactPatternJWT}
+
+// maxRedactDepth bounds the depth of values walked, against cyclic pointers.
+const maxRedactDepth = 32
+
+//go:generate go-option -type "redact"
+type redact struct {
+	// Keys are globs of keys of attrs, struct fields and map entries of sensitive data,
+	// see path.Match, matched case-insensitively.
+	// A glob matches the key, or any group enclosing the key, such as "credentials" matches
+	// all attrs in the group "credentials"; a glob containing "." matches the dotted path of
+	// groups and the key, such as "req.header.x-*".
+	// DefaultRedactKeys by default, extended by WithRedactKeys and replaced by WithRedactKeysReplace.
+	Keys []string
+	// Patterns are regexps of sensitive data in string values and the message,
+	// the substrings matched are redacted.
+	// DefaultRedactPatterns by default, extended by WithRedactPatterns and replaced by
+	// WithRedactPatternsReplace.
+	Patterns []*regexp.Regexp
+	// Mode is how sensitive data is replaced, a field tagged by `log:"redact,mode"` overrides it.
+	Mode RedactMode
+	// Mask replaces sensitive data by RedactMask, DefaultRedactMask if empty.
+	Mask string
+	// HashKey is the key of HMAC-SHA256 hashing sensitive data by RedactHash, against
+	// guessing values by dictionaries. SHA256 is used if empty.
+	HashKey []byte
+}
+
+// redactor redacts attrs, shared by a redactHandler and the handlers derived by WithAttrs and WithGroup.
+type redactor struct {
+	opts redact
+	keys []string // lower case
+}
+
+func newRedactor(opts ...RedactOption) *redactor {
+	r := &redactor{}
+	r.opts.Keys = slices.Clone(DefaultRedactKeys)
+	r.opts.Patterns = slices.Clone(DefaultRedactPatterns)
+	r.opts.ApplyOptions(opts...)
+	if r.opts.Mask == "" {
+		r.opts.Mask = DefaultRedactMask
+	}
+	for _, key := range r.opts.Keys {
+		r.keys = append(r.keys, strings.ToLower(key))
+	}
+	return r
+}
+
+type redactHandler struct {
+	handler slog.Handler
+	r       *redactor
+	groups  []string // groups opened by WithGroup
+}
+
+var _ slog.Handler = (*redactHandler)(nil)
+
+// RedactHandler creates a slog.Handler that redacts sensitive data before handing records over
+// to handler, so that no sink behind it writes the data:
+// attrs whose keys or groups match Keys, string values and substrings of the message
+// matched by Patterns, and struct fields tagged by `log:"redact"`.
+//
+// LogValuers are resolved before redacted. Struct, map and slice values are walked by reflection
+// and replaced by maps and slices of the redacted values, only if there is data redacted in them.
+func RedactHandler(handler slog.Handler, opts ...RedactOption) slog.Handler {
+	return redactHandler{handler: handler, r: newRedactor(opts...)}
+}
+
+// RedactReplaceAttr returns a ReplaceAttr of slog.HandlerOptions redacting sensitive data as
+// RedactHandler does, except the message, which can be composed by MultiReplaceAttr.
+func RedactReplaceAttr(opts ...RedactOption) func(groups []string, a slog.Attr) slog.Attr {
+	r := newRedactor(opts...)
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a, _ = r.redactAttr(groups, a, false)
+		return a
+	}
+}
+
+func (h redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	msg, _ := h.r.redactString(record.Message, RedactMask)
+	r := slog.NewRecord(record.Time, record.Level, msg, record.PC)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(a slog.Attr) bool {
+		if a, keep := h.r.redactAttr(h.groups, a, false); keep {
+			attrs = append(attrs, a)
+		}
+		return true
+	})
+	r.AddAttrs(attrs...)
+	return h.handler.Handle(ctx, r)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, keep := h.r.redactAttr(h.groups, a, false); keep {
+			redacted = append(redacted, a)
+		}
+	}
+	return redactHandler{handler: h.handler.WithAttrs(redacted), r: h.r, groups: h.groups}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return redactHandler{
+		handler: h.handler.WithGroup(name),
+		r:       h.r,
+		groups:  append(h.groups[:len(h.groups):len(h.groups)], name),
+	}
+}
+
+// matchKey reports whether key in groups matches any of Keys.
+func (r *redactor) matchKey(groups []string, key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+	key = strings.ToLower(key)
+	var dotted string
+	for _, glob := range r.keys {
+		if strings.Contains(glob, ".") {
+			if dotted == "" {
+				dotted = strings.ToLower(strings.Join(append(groups[:len(groups):len(groups)], key), "."))
+			}
+			if ok, _ := path.Match(glob, dotted); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(glob, key); ok {
+			return true
+		}
+		for _, g := range groups {
+			if ok, _ := path.Match(glob, strings.ToLower(g)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactAttr returns a redacted, and whether a is kept, that is not dropped.
+// matched reports whether a is in a group of sensitive data.
+func (r *redactor) redactAttr(groups []string, a slog.Attr, matched bool) (slog.Attr, bool) {
+	a.Value = a.Value.Resolve()
+	matched = matched || (a.Key != "" && r.matchKey(groups, a.Key))
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		if a.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			if ga, keep := r.redactAttr(groups, ga, matched); keep {
+				attrs = append(attrs, ga)
+			}
+		}
+		if len(attrs) == 0 && len(group) > 0 {
+			return slog.Attr{}, false
+		}
+		a.Value = slog.GroupValue(attrs...)
+		return a, true
+	case slog.KindString:
+		if matched {
+			return r.replaceAttr(a, r.opts.Mode)
+		}
+		s, redacted := r.redactString(a.Value.String(), r.opts.Mode)
+		if !redacted {
+			return a, true
+		}
+		if r.opts.Mode == RedactDrop {
+			return slog.Attr{}, false
+		}
+		return slog.String(a.Key, s), true
+	case slog.KindAny:
+		if matched {
+			return r.replaceAttr(a, r.opts.Mode)
+		}
+		v, redacted, keep := r.redactValue(groups, a.Key, reflect.ValueOf(a.Value.Any()), r.opts.Mode, 0)
+		if !keep {
+			return slog.Attr{}, false
+		}
+		if redacted {
+			a.Value = slog.AnyValue(v)
+		}
+		return a, true
+	default:
+		if matched {
+			return r.replaceAttr(a, r.opts.Mode)
+		}
+		return a, true
+	}
+}
+
+// replaceAttr replaces the value of a by mode.
+func (r *redactor) replaceAttr(a slog.Attr, mode RedactMode) (slog.Attr, bool) {
+	if mode == RedactDrop {
+		return slog.Attr{}, false
+	}
+	if a.Value.Kind() == slog.KindAny {
+		return slog.String(a.Key, r.replace(sprintValue(reflect.ValueOf(a.Value.Any())), mode)), true
+	}
+	return slog.String(a.Key, r.replace(a.Value.String(), mode)), true
+}
+
+// sprintValue formats the value v points to, rather than the address, to be hashed.
+func sprintValue(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// replace returns the replacement of s by mode, RedactDrop is taken as RedactMask.
+func (r *redactor) replace(s string, mode RedactMode) string {
+	if mode != RedactHash {
+		return r.opts.Mask
+	}
+	var sum []byte
+	if len(r.opts.HashKey) > 0 {
+		m := hmac.New(sha256.New, r.opts.HashKey)
+		m.Write([]byte(s))
+		sum = m.Sum(nil)
+	} else {
+		s := sha256.Sum256([]byte(s))
+		sum = s[:]
+	}
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// redactString replaces substrings of s matched by Patterns, and reports whether s is redacted.
+func (r *redactor) redactString(s string, mode RedactMode) (string, bool) {
+	var redacted bool
+	for _, p := range r.opts.Patterns {
+		if !p.MatchString(s) {
+			continue
+		}
+		redacted = true
+		s = p.ReplaceAllStringFunc(s, func(m string) string { return r.replace(m, mode) })
+	}
+	return s, redacted
+}
+
+// redactValue redacts v, the value of key in groups, in which struct fields, map entries and
+// elements of slices are walked.
+// It returns maps and slices of the values redacted if redacted is true, or v as it is,
+// and reports whether v is kept, that is not dropped.
+func (r *redactor) redactValue(groups []string, key string, v reflect.Value, mode RedactMode, depth int) (_ any, redacted, keep bool) {
+	if !v.IsValid() {
+		return nil, false, true
+	}
+	if !v.CanInterface() || depth > maxRedactDepth {
+		return nil, false, true
+	}
+	orig := v.Interface()
+	// values of attrs are resolved already
+	if _, ok := orig.(slog.LogValuer); ok && depth > 0 {
+		return r.redactValue(groups, key, reflect.ValueOf(slog.AnyValue(orig).Resolve().Any()), mode, depth+1)
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return orig, false, true
+		}
+		return r.redactValue(groups, key, v.Elem(), mode, depth+1)
+	case reflect.String:
+		s, redacted := r.redactString(v.String(), mode)
+		if !redacted {
+			return orig, false, true
+		}
+		return s, true, mode != RedactDrop
+	case reflect.Struct:
+		if key != "" {
+			groups = append(groups[:len(groups):len(groups)], key)
+		}
+		t := v.Type()
+		fields := make(map[string]any, t.NumField())
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			fieldMode := mode
+			tagged := false
+			if tag := sf.Tag.Get(RedactTagKey); tag == "redact" || strings.HasPrefix(tag, "redact,") {
+				tagged = true
+				if _, m, ok := strings.Cut(tag, ","); ok {
+					if m, ok := parseRedactMode(m); ok {
+						fieldMode = m
+					}
+				}
+			}
+			fv := v.Field(i)
+			if tagged || r.matchKey(groups, name) {
+				redacted = true
+				if fieldMode != RedactDrop {
+					fields[name] = r.replace(sprintValue(fv), fieldMode)
+				}
+				continue
+			}
+			fr, fredacted, fkeep := r.redactValue(groups, name, fv, fieldMode, depth+1)
+			redacted = redacted || fredacted || !fkeep
+			if fkeep {
+				fields[name] = fr
+			}
+		}
+		if !redacted {
+			return orig, false, true
+		}
+		return fields, true, true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return orig, false, true
+		}
+		if key != "" {
+			groups = append(groups[:len(groups):len(groups)], key)
+		}
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			name := iter.Key().String()
+			if r.matchKey(groups, name) {
+				redacted = true
+				if mode != RedactDrop {
+					entries[name] = r.replace(sprintValue(iter.Value()), mode)
+				}
+				continue
+			}
+			er, eredacted, ekeep := r.redactValue(groups, name, iter.Value(), mode, depth+1)
+			redacted = redacted || eredacted || !ekeep
+			if ekeep {
+				entries[name] = er
+			}
+		}
+		if !redacted {
+			return orig, false, true
+		}
+		return entries, true, true
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 { // bytes
+			return orig, false, true
+		}
+		elems := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			er, eredacted, ekeep := r.redactValue(groups, key, v.Index(i), mode, depth+1)
+			redacted = redacted || eredacted || !ekeep
+			if ekeep {
+				elems = append(elems, er)
+			}
+		}
+		if !redacted {
+			return orig, false, true
+		}
+		return elems, true, true
+	default:
+		return orig, false, true
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+type loginRequest struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Card     string `json:"card" log:"redact,hash"`
+	Note     string `json:"note,omitempty" log:"redact,drop"`
+	Profile  *profile
+}
+
+type profile struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+type loginValuer struct{ req loginRequest }
+
+func (v loginValuer) LogValue() slog.Value { return slog.AnyValue(v.req) }
+
+func TestRedactHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog_.RedactHandler(
+		slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		slog_.WithRedactPatterns(slog_.RedactPatternEmail),
+		slog_.WithRedactKeys("req.header.x-*"))).With("api_key", "k1")
+
+	logger.Info("login by bob@example.com",
+		"user", "bob",
+		slog.Group("credentials", "user", "bob", "pin", 1234),
+		slog.Group("req", slog.Group("header", "X-Auth", "v", "Accept", "*/*")),
+		"auth", "Bearer abc.def",
+		"req", loginValuer{loginRequest{
+			User: "bob", Password: "p", Card: "4111", Note: "n",
+			Profile: &profile{Email: "bob@example.com", Age: 7}}},
+		"clean", profile{Age: 1})
+
+	got := buf.String()
+	for _, want := range []string{
+		`"msg":"login by [REDACTED]"`,
+		`"api_key":"[REDACTED]"`,
+		`"user":"bob"`,
+		`"credentials":{"user":"[REDACTED]","pin":"[REDACTED]"}`,
+		`"req":{"header":{"X-Auth":"[REDACTED]","Accept":"*/*"}}`,
+		`"auth":"[REDACTED]"`,
+		`"password":"[REDACTED]"`,
+		`"card":"sha256:`,
+		`"Profile":{"age":7,"email":"[REDACTED]"}`,
+		`"clean":{"email":"","age":1}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %s in %s", want, got)
+		}
+	}
+	for _, secret := range []string{"k1", "bob@example.com", "1234", `"v"`, "abc.def", `"p"`, "4111", `"note"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("leaked %s in %s", secret, got)
+		}
+	}
+}
+
+func TestRedactHandlerMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode slog_.RedactMode
+		want string
+	}{
+		{slog_.RedactMask, `level=INFO msg=m token=*** user=bob note="call ***"` + "\n"},
+		{slog_.RedactDrop, `level=INFO msg=m user=bob` + "\n"},
+	} {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog_.RedactHandler(
+				slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+				slog_.WithRedactMode(tt.mode),
+				slog_.WithRedactMask("***"),
+				slog_.WithRedactPatterns(regexp.MustCompile(`\d{3}-\d{4}`))))
+			logger.Info("m", "token", "t", "user", "bob", "note", "call 555-0100")
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	var a, b bytes.Buffer
+	for _, buf := range []*bytes.Buffer{&a, &b} {
+		slog.New(slog_.RedactHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+			slog_.WithRedactMode(slog_.RedactHash),
+			slog_.WithRedactHashKey([]byte("k")...))).Info("m", "token", "t")
+	}
+	if a.String() != b.String() || !strings.Contains(a.String(), "token=sha256:") {
+		t.Fatalf("hashes of the same value: %q, %q", a.String(), b.String())
+	}
+}
+
+func TestRedactReplaceAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: slog_.MultiReplaceAttr(removeTime, slog_.RedactReplaceAttr())}))
+	logger.WithGroup("db").Info("connect", "password", "p", "host", "h")
+
+	if got, want := buf.String(), "level=INFO msg=connect db.password=[REDACTED] db.host=h\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -6,6 +6,8 @@ package slog
 
 import (
 	"time"
+
+	os_ "github.com/searKing/golang/go/os"
 )
 
 //go:generate go-option -type "rotate"
@@ -34,6 +36,15 @@ type rotate struct {
 	// take effects if only MaxCount is bigger than 0.
 	MaxCount int
 
+	// max total size of rotate files, including the file being written.
+	// The oldest rotate files are removed until the total size is not bigger than MaxTotalSize.
+	// take effects if only MaxTotalSize is bigger than 0.
+	MaxTotalSize int64
+
+	// Compressor compresses rotated files on a separate goroutine, such as os_.GzipCompressor.
+	// take effects if only Compressor is not nil.
+	Compressor os_.Compressor
+
 	// Force File Rotate when start up
 	ForceNewFileOnStartup bool
 }
@@ -44,6 +44,8 @@ func NewRotateHandler(h NewHandler, path string, opts *slog.HandlerOptions, opti
 	file.RotateSize = opt.RotateSize
 	file.MaxAge = opt.MaxAge
 	file.MaxCount = opt.MaxCount
+	file.MaxTotalSize = opt.MaxTotalSize
+	file.Compressor = opt.Compressor
 	file.ForceNewFileOnStartup = opt.ForceNewFileOnStartup
 	file.PostRotateHandler = GlogRotateHeader
 	return h(file, opts), nil
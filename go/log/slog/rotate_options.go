@@ -3,7 +3,11 @@
 
 package slog
 
-import "time"
+import (
+	"time"
+
+	os_ "github.com/searKing/golang/go/os"
+)
 
 // A RotateOption sets options.
 type RotateOption interface {
@@ -98,6 +102,25 @@ func WithRotateMaxCount(v int) RotateOption {
 	})
 }
 
+// WithRotateMaxTotalSize sets MaxTotalSize in rotate.
+// max total size of rotate files, including the file being written.
+// The oldest rotate files are removed until the total size is not bigger than MaxTotalSize.
+// take effects if only MaxTotalSize is bigger than 0.
+func WithRotateMaxTotalSize(v int64) RotateOption {
+	return RotateOptionFunc(func(o *rotate) {
+		o.MaxTotalSize = v
+	})
+}
+
+// WithRotateCompressor sets Compressor in rotate.
+// Compressor compresses rotated files on a separate goroutine, such as os_.GzipCompressor.
+// take effects if only Compressor is not nil.
+func WithRotateCompressor(v os_.Compressor) RotateOption {
+	return RotateOptionFunc(func(o *rotate) {
+		o.Compressor = v
+	})
+}
+
 // WithRotateForceNewFileOnStartup sets ForceNewFileOnStartup in rotate.
 // Force File Rotate when start up
 func WithRotateForceNewFileOnStartup(v bool) RotateOption {
@@ -0,0 +1,271 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+	"github.com/searKing/golang/go/time/rate"
+)
+
+//go:generate go-option -type "sampling"
+type sampling struct {
+	// Interval is the period the counters of records are reset, 1s if not positive.
+	Interval time.Duration
+	// First is the number of records logged per level and message per Interval,
+	// before the sampling by Thereafter, 100 if 0, none if negative.
+	First int
+	// Thereafter logs every Thereafter-th record after the First ones per level and
+	// message per Interval, 100 if 0, all of them are suppressed if negative.
+	Thereafter int
+
+	// Key returns the key records are rate limited by, such as an attr or a value of ctx,
+	// take effects if only Key is not nil and Limit is bigger than 0.
+	Key func(ctx context.Context, r slog.Record) string
+	// Limit is the rate of records logged per key, with bursts of at most Burst records.
+	Limit rate.Limit
+	// Burst is the bucket size of records logged per key, 1 if not positive.
+	Burst int
+	// MaxKeys is the maximum number of keys tracked, the least recently used key is
+	// forgotten if exceeded, 10000 if not positive.
+	// It bounds the levels and messages reported by summaries as well, the records suppressed
+	// beyond are counted in the total only.
+	MaxKeys int
+
+	// SummaryInterval is the minimum period between summary records, which report the
+	// number of records suppressed per level and message since the last summary.
+	// A summary is logged with the next record handled after SummaryInterval elapses,
+	// or by a timer if no record is handled, so that a burst which ends is reported still.
+	// take effects if only SummaryInterval is bigger than 0.
+	SummaryInterval time.Duration
+	// SummaryLevel is the level of summary records, slog.LevelWarn if nil.
+	SummaryLevel slog.Leveler
+
+	// Clock tells the time to reset counters and to refill tokens, time_.RealClock if nil.
+	Clock time_.Clock
+}
+
+var _ slog.Handler = (*samplingHandler)(nil)
+
+type samplingHandler struct {
+	handler slog.Handler
+	s       *sampler
+}
+
+// sampler is shared by a samplingHandler and the handlers derived by WithAttrs and WithGroup.
+type sampler struct {
+	opts    sampling
+	handler slog.Handler // the handler wrapped at first, to log summaries
+	limiter *rate.KeyedLimiter[string]
+
+	mu           sync.Mutex
+	windowEnd    time.Time // counters are reset at windowEnd
+	counters     map[samplingKey]int
+	suppressed   map[samplingKey]int // records suppressed since lastSummary, at most MaxKeys keys
+	untracked    int                 // records suppressed since lastSummary, beyond MaxKeys keys
+	lastSummary  time.Time
+	summaryArmed bool // a timer to log the summary is pending
+}
+
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+// suppressedRecords counts records suppressed of a level and message, reported by summaries.
+type suppressedRecords struct {
+	Level      string `json:"level"`
+	Message    string `json:"msg"`
+	Suppressed int    `json:"suppressed"`
+}
+
+// SamplingHandler creates a slog.Handler that samples records before handing them over to handler.
+// The First records of a level and message are logged per Interval, and then every Thereafter-th.
+// Records passing the sampling may be rate limited by a token bucket per Key, and records
+// suppressed are reported by summary records every SummaryInterval.
+//
+// Only records enabled by handler are counted, so SamplingHandler composes with
+// DynamicLevelHandler and MultiHandler in any order.
+func SamplingHandler(handler slog.Handler, opts ...SamplingOption) slog.Handler {
+	s := &sampler{
+		handler:    handler,
+		counters:   make(map[samplingKey]int),
+		suppressed: make(map[samplingKey]int),
+	}
+	s.opts.ApplyOptions(opts...)
+	if s.opts.Interval <= 0 {
+		s.opts.Interval = time.Second
+	}
+	if s.opts.First == 0 {
+		s.opts.First = 100
+	}
+	if s.opts.Thereafter == 0 {
+		s.opts.Thereafter = 100
+	}
+	if s.opts.Burst <= 0 {
+		s.opts.Burst = 1
+	}
+	if s.opts.MaxKeys <= 0 {
+		s.opts.MaxKeys = 10000
+	}
+	if s.opts.SummaryLevel == nil {
+		s.opts.SummaryLevel = slog.LevelWarn
+	}
+	s.opts.Clock = time_.ClockOrDefault(s.opts.Clock)
+	if s.opts.Key != nil && s.opts.Limit > 0 {
+		s.limiter = rate.NewKeyedLimiter(func(string) rate.Limiter {
+			return rate.NewTokenBucketLimiter(s.opts.Limit, s.opts.Burst)
+		}, s.opts.MaxKeys, 0)
+	}
+	s.lastSummary = s.opts.Clock.Now()
+	return samplingHandler{handler: handler, s: s}
+}
+
+func (t samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return t.handler.Enabled(ctx, level)
+}
+
+func (t samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !t.handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+	now := t.s.opts.Clock.Now()
+	allowed := t.s.allow(ctx, record, now)
+	if summary, ok := t.s.summary(now); ok {
+		if t.s.handler.Enabled(ctx, summary.Level) {
+			if err := t.s.handler.Handle(ctx, summary); err != nil {
+				return err
+			}
+		}
+	}
+	if !allowed {
+		return nil
+	}
+	return t.handler.Handle(ctx, record)
+}
+
+func (t samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return samplingHandler{handler: t.handler.WithAttrs(attrs), s: t.s}
+}
+
+func (t samplingHandler) WithGroup(name string) slog.Handler {
+	return samplingHandler{handler: t.handler.WithGroup(name), s: t.s}
+}
+
+// allow reports whether r passes the sampling and the rate limit, and counts it as
+// suppressed if not.
+func (s *sampler) allow(ctx context.Context, r slog.Record, now time.Time) bool {
+	key := samplingKey{level: r.Level, message: r.Message}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !now.Before(s.windowEnd) {
+		clear(s.counters)
+		s.windowEnd = now.Add(s.opts.Interval)
+	}
+	s.counters[key]++
+	n := s.counters[key]
+	first := max(s.opts.First, 0)
+	sampled := n <= first ||
+		(s.opts.Thereafter > 0 && (n-first)%s.opts.Thereafter == 0)
+	if sampled && s.limiter != nil {
+		sampled = s.limiter.AllowN(s.opts.Key(ctx, r), now, 1)
+	}
+	if !sampled && s.opts.SummaryInterval > 0 {
+		if _, ok := s.suppressed[key]; ok || len(s.suppressed) < s.opts.MaxKeys {
+			s.suppressed[key]++
+		} else {
+			s.untracked++
+		}
+		s.armSummaryLocked(now)
+	}
+	return sampled
+}
+
+// armSummaryLocked starts a timer to log the summary when SummaryInterval elapses,
+// if any record is suppressed and no timer is pending.
+func (s *sampler) armSummaryLocked(now time.Time) {
+	if s.summaryArmed || (len(s.suppressed) == 0 && s.untracked == 0) {
+		return
+	}
+	s.summaryArmed = true
+	s.opts.Clock.AfterFunc(s.lastSummary.Add(s.opts.SummaryInterval).Sub(now), s.logSummary)
+}
+
+// logSummary logs the summary by the timer, if not logged with a record handled meanwhile.
+// The summary is handled with mu held, so that it is not reordered after the records
+// handled meanwhile.
+func (s *sampler) logSummary() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summaryArmed = false
+	now := s.opts.Clock.Now()
+	r, ok := s.summaryLocked(now)
+	if !ok {
+		// suppressed after the summary logged with a record, wait for the next one
+		s.armSummaryLocked(now)
+		return
+	}
+	ctx := context.Background()
+	if s.handler.Enabled(ctx, r.Level) {
+		_ = s.handler.Handle(ctx, r)
+	}
+}
+
+// summary returns a record reporting the records suppressed since the last summary,
+// if SummaryInterval elapsed and any record is suppressed.
+func (s *sampler) summary(now time.Time) (slog.Record, bool) {
+	if s.opts.SummaryInterval <= 0 {
+		return slog.Record{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.summaryLocked(now)
+}
+
+func (s *sampler) summaryLocked(now time.Time) (slog.Record, bool) {
+	if (len(s.suppressed) == 0 && s.untracked == 0) || now.Sub(s.lastSummary) < s.opts.SummaryInterval {
+		return slog.Record{}, false
+	}
+
+	keys := make([]samplingKey, 0, len(s.suppressed))
+	total := s.untracked
+	for key, n := range s.suppressed {
+		keys = append(keys, key)
+		total += n
+	}
+	// most suppressed first
+	slices.SortFunc(keys, func(a, b samplingKey) int {
+		if c := cmp.Compare(s.suppressed[b], s.suppressed[a]); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.level, b.level); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.message, b.message)
+	})
+	records := make([]suppressedRecords, 0, len(keys))
+	for _, key := range keys {
+		records = append(records, suppressedRecords{
+			Level:      key.level.String(),
+			Message:    key.message,
+			Suppressed: s.suppressed[key],
+		})
+	}
+
+	r := slog.NewRecord(now, s.opts.SummaryLevel.Level(), "slog: records suppressed by sampling", 0)
+	r.AddAttrs(slog.Int("suppressed", total),
+		slog.Duration("since", now.Sub(s.lastSummary)),
+		slog.Any("records", records))
+	clear(s.suppressed)
+	s.untracked = 0
+	s.lastSummary = now
+	return r, true
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+	time_ "github.com/searKing/golang/go/time"
+	"github.com/searKing/golang/go/time/rate"
+)
+
+func removeTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestSamplingHandler(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	var buf bytes.Buffer
+	logger := slog.New(slog_.SamplingHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		slog_.WithSamplingInterval(time.Second),
+		slog_.WithSamplingFirst(2),
+		slog_.WithSamplingThereafter(3),
+		slog_.WithSamplingClock(clock))).With("svc", "api")
+
+	for i := range 8 {
+		logger.Info("hot", "i", i)
+	}
+	logger.Warn("hot", "i", 0) // counted per level
+	clock.Step(time.Second)
+	logger.Info("hot", "i", 8) // counters are reset every interval
+
+	want := `level=INFO msg=hot svc=api i=0
+level=INFO msg=hot svc=api i=1
+level=INFO msg=hot svc=api i=4
+level=INFO msg=hot svc=api i=7
+level=WARN msg=hot svc=api i=0
+level=INFO msg=hot svc=api i=8
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSamplingHandlerSummary(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	var buf bytes.Buffer
+	logger := slog.New(slog_.SamplingHandler(
+		slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		slog_.WithSamplingInterval(time.Minute),
+		slog_.WithSamplingFirst(1),
+		slog_.WithSamplingThereafter(-1),
+		slog_.WithSamplingSummaryInterval(10*time.Second),
+		slog_.WithSamplingClock(clock))).WithGroup("req")
+
+	for range 3 {
+		logger.Info("a")
+	}
+	for range 4 {
+		logger.Error("b")
+	}
+	clock.Step(10 * time.Second)
+	logger.Info("c")
+	logger.Info("c") // no summary until SummaryInterval elapses again
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), buf.String())
+	}
+	var summary struct {
+		Level      string
+		Msg        string
+		Suppressed int
+		Since      time.Duration
+		Records    []struct {
+			Level      string
+			Msg        string
+			Suppressed int
+		}
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &summary); err != nil {
+		t.Fatalf("unmarshal summary %q: %v", lines[2], err)
+	}
+	if summary.Level != "WARN" || summary.Msg != "slog: records suppressed by sampling" ||
+		summary.Suppressed != 5 || summary.Since != 10*time.Second {
+		t.Fatalf("unexpected summary %q", lines[2])
+	}
+	if len(summary.Records) != 2 ||
+		summary.Records[0].Level != "ERROR" || summary.Records[0].Msg != "b" || summary.Records[0].Suppressed != 3 ||
+		summary.Records[1].Level != "INFO" || summary.Records[1].Msg != "a" || summary.Records[1].Suppressed != 2 {
+		t.Fatalf("unexpected suppressed records %q", lines[2])
+	}
+	if !strings.Contains(lines[3], `"msg":"c"`) {
+		t.Fatalf("summary must precede the record triggering it, got %q", lines[3])
+	}
+}
+
+func TestSamplingHandlerSummaryTimer(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	var buf lockedBuffer
+	logger := slog.New(slog_.SamplingHandler(
+		slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		slog_.WithSamplingInterval(time.Minute),
+		slog_.WithSamplingFirst(1),
+		slog_.WithSamplingThereafter(-1),
+		slog_.WithSamplingMaxKeys(1),
+		slog_.WithSamplingSummaryInterval(10*time.Second),
+		slog_.WithSamplingClock(clock)))
+
+	for range 3 {
+		logger.Info("a")
+		logger.Info("b")
+	}
+	// the burst ends, the summary is logged without any record handled after
+	clock.Step(10 * time.Second)
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(buf.String(), "\n") < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary logged after the burst ended:\n%s", buf.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var summary struct {
+		Suppressed int
+		Records    []struct {
+			Msg        string
+			Suppressed int
+		}
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &summary); err != nil {
+		t.Fatalf("unmarshal summary %q: %v", lines[2], err)
+	}
+	// only MaxKeys messages are reported, the others are counted in the total
+	if summary.Suppressed != 4 || len(summary.Records) != 1 ||
+		summary.Records[0].Msg != "a" || summary.Records[0].Suppressed != 2 {
+		t.Fatalf("unexpected summary %q", lines[2])
+	}
+}
+
+func TestSamplingHandlerKeyLimit(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	var buf bytes.Buffer
+	logger := slog.New(slog_.SamplingHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		slog_.WithSamplingKey(func(ctx context.Context, r slog.Record) string {
+			var tenant string
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == "tenant" {
+					tenant = a.Value.String()
+					return false
+				}
+				return true
+			})
+			return tenant
+		}),
+		slog_.WithSamplingLimit(rate.Every(time.Second)),
+		slog_.WithSamplingBurst(2),
+		slog_.WithSamplingClock(clock)))
+
+	for i := range 3 {
+		logger.Info("req", "tenant", "a", "i", i)
+		logger.Info("req", "tenant", "b", "i", i)
+	}
+	clock.Step(time.Second)
+	logger.Info("req", "tenant", "a", "i", 3)
+
+	want := `level=INFO msg=req tenant=a i=0
+level=INFO msg=req tenant=b i=0
+level=INFO msg=req tenant=a i=1
+level=INFO msg=req tenant=b i=1
+level=INFO msg=req tenant=a i=3
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSamplingHandlerCompose(t *testing.T) {
+	clock := time_.NewFakeClock(time.Unix(0, 0))
+	level := slog.LevelWarn
+	var all, errs bytes.Buffer
+	logger := slog.New(slog_.SamplingHandler(
+		slog_.MultiHandler(
+			slog_.DynamicLevelHandler(func(ctx context.Context) slog.Level { return level },
+				slog.NewTextHandler(&all, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: removeTime})),
+			slog.NewTextHandler(&errs, &slog.HandlerOptions{Level: slog.LevelError, ReplaceAttr: removeTime})),
+		slog_.WithSamplingFirst(1),
+		slog_.WithSamplingThereafter(-1),
+		slog_.WithSamplingClock(clock)))
+
+	logger.Info("x") // disabled, not counted
+	level = slog.LevelInfo
+	logger.Info("x")
+	logger.Info("x")
+	logger.Error("y")
+	logger.Error("y")
+
+	if got, want := all.String(), "level=INFO msg=x\nlevel=ERROR msg=y\n"; got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got, want := errs.String(), "level=ERROR msg=y\n"; got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+// syslogFraming is the framing of messages over a connection.
+type syslogFraming int
+
+const (
+	syslogFramingDatagram       syslogFraming = iota // one message per datagram
+	syslogFramingOctetCounting                       // MSG-LEN SP SYSLOG-MSG, as RFC 6587 3.4.1
+	syslogFramingNonTransparent                      // SYSLOG-MSG LF, as RFC 6587 3.4.2, expected by local syslog daemons
+)
+
+// syslogRecord is a message buffered while disconnected.
+type syslogRecord struct {
+	msg    []byte
+	framed bool // msg is framed already, such as the unwritten tail of a partial write
+}
+
+// syslogConn is a connection to a syslog server, reconnecting by a BackOff in background,
+// and buffering messages while disconnected.
+type syslogConn struct {
+	network    string
+	addr       string
+	tlsConfig  *tls.Config
+	timeout    time.Duration
+	backoff    time_.BackOff // used by the reconnecting goroutine only
+	bufferSize int
+	// dropped formats the message reporting the number of messages dropped while disconnected.
+	dropped func(n int) []byte
+
+	mu           sync.Mutex
+	conn         net.Conn
+	framing      syslogFraming
+	pending      []syslogRecord // messages buffered while disconnected, oldest first
+	droppedN     int            // number of messages dropped while disconnected
+	reconnecting bool
+	closed       bool
+	done         chan struct{}
+}
+
+func newSyslogConn(opt *syslog, dropped func(n int) []byte) (*syslogConn, error) {
+	switch opt.Network {
+	case "":
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "tcp+tls", "unixgram", "unix":
+		if opt.Addr == "" {
+			return nil, fmt.Errorf("slog: missing syslog address of network %q", opt.Network)
+		}
+	default:
+		return nil, fmt.Errorf("slog: unknown syslog network %q", opt.Network)
+	}
+	c := &syslogConn{
+		network:    opt.Network,
+		addr:       opt.Addr,
+		tlsConfig:  opt.TLSConfig,
+		timeout:    opt.Timeout,
+		backoff:    opt.BackOff,
+		bufferSize: opt.BufferSize,
+		dropped:    dropped,
+		done:       make(chan struct{}),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, framing, err := c.dial(); err == nil {
+		c.conn, c.framing = conn, framing
+	} else {
+		c.reconnectLocked()
+	}
+	return c, nil
+}
+
+// write sends msg, or buffers it if disconnected.
+func (c *syslogConn) write(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	rec := syslogRecord{msg: msg}
+	if c.conn != nil {
+		tail, err := c.writeLocked(rec)
+		if err == nil {
+			return nil
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+		rec = tail
+	}
+	c.bufferLocked(rec)
+	c.reconnectLocked()
+	return nil
+}
+
+func (c *syslogConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	if c.conn == nil {
+		return nil
+	}
+	return errors.Join(c.flushLocked(), c.conn.Close())
+}
+
+// writeLocked sends rec, and returns the record to be buffered on error, which is
+// the unwritten tail only if the frame has been written partially.
+func (c *syslogConn) writeLocked(rec syslogRecord) (tail syslogRecord, err error) {
+	if c.timeout > 0 {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	}
+	frame := rec.msg
+	if !rec.framed {
+		frame = c.frame(rec.msg)
+	}
+	n, err := c.conn.Write(frame)
+	if err == nil {
+		return syslogRecord{}, nil
+	}
+	if n <= 0 {
+		return rec, err
+	}
+	return syslogRecord{msg: frame[n:], framed: true}, err
+}
+
+func (c *syslogConn) frame(msg []byte) []byte {
+	switch c.framing {
+	case syslogFramingOctetCounting:
+		frame := strconv.AppendInt(nil, int64(len(msg)), 10)
+		frame = append(frame, ' ')
+		return append(frame, msg...)
+	case syslogFramingNonTransparent:
+		if len(msg) > 0 && msg[len(msg)-1] == '\n' {
+			return msg
+		}
+		return append(msg[:len(msg):len(msg)], '\n')
+	default:
+		return msg
+	}
+}
+
+func (c *syslogConn) bufferLocked(rec syslogRecord) {
+	if c.bufferSize < 0 {
+		c.droppedN++
+		return
+	}
+	if len(c.pending) >= c.bufferSize {
+		n := len(c.pending) - c.bufferSize + 1
+		c.pending = c.pending[n:]
+		c.droppedN += n
+	}
+	c.pending = append(c.pending, rec)
+}
+
+// flushLocked sends the messages buffered and the report of dropped messages, the messages not sent
+// are kept on error.
+func (c *syslogConn) flushLocked() error {
+	// the unwritten tail of a partial write goes first, completing its frame
+	if len(c.pending) > 0 && c.pending[0].framed {
+		tail, err := c.writeLocked(c.pending[0])
+		if err != nil {
+			c.pending[0] = tail
+			return err
+		}
+		c.pending[0] = syslogRecord{}
+		c.pending = c.pending[1:]
+	}
+	if c.droppedN > 0 {
+		tail, err := c.writeLocked(syslogRecord{msg: c.dropped(c.droppedN)})
+		if err != nil {
+			if tail.framed {
+				c.droppedN = 0
+				c.pending = append([]syslogRecord{tail}, c.pending...)
+			}
+			return err
+		}
+		c.droppedN = 0
+	}
+	for len(c.pending) > 0 {
+		tail, err := c.writeLocked(c.pending[0])
+		if err != nil {
+			c.pending[0] = tail
+			return err
+		}
+		c.pending[0] = syslogRecord{}
+		c.pending = c.pending[1:]
+	}
+	return nil
+}
+
+// reconnectLocked starts reconnecting in background, if not yet.
+func (c *syslogConn) reconnectLocked() {
+	if c.reconnecting || c.closed {
+		return
+	}
+	c.reconnecting = true
+	go c.reconnect()
+}
+
+func (c *syslogConn) reconnect() {
+	c.backoff.Reset()
+	for {
+		d, ok := c.backoff.NextBackOff()
+		if !ok {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.reconnecting = false
+			return
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-c.done:
+			timer.Stop()
+			return
+		}
+		conn, framing, err := c.dial()
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		c.conn, c.framing = conn, framing
+		if err := c.flushLocked(); err != nil {
+			_ = c.conn.Close()
+			c.conn = nil
+			c.mu.Unlock()
+			continue
+		}
+		c.reconnecting = false
+		c.mu.Unlock()
+		return
+	}
+}
+
+// dial connects to the syslog server, and returns the framing of messages over the connection.
+func (c *syslogConn) dial() (conn net.Conn, framing syslogFraming, err error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	switch c.network {
+	case "":
+		// local syslog server
+		for _, network := range []string{"unixgram", "unix"} {
+			for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+				conn, err = dialer.Dial(network, path)
+				if err == nil {
+					if network == "unix" {
+						framing = syslogFramingNonTransparent
+					}
+					return conn, framing, nil
+				}
+			}
+		}
+		return nil, framing, errors.New("slog: unix syslog delivery error")
+	case "tcp+tls":
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.addr, c.tlsConfig)
+		return conn, syslogFramingOctetCounting, err
+	default:
+		conn, err = dialer.Dial(c.network, c.addr)
+		switch c.network {
+		case "tcp", "tcp4", "tcp6":
+			framing = syslogFramingOctetCounting
+		case "unix":
+			framing = syslogFramingNonTransparent
+		}
+		return conn, framing, err
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+// shortConn accepts at most limit bytes, and fails the write beyond it.
+type shortConn struct {
+	net.Conn
+	buf   bytes.Buffer
+	limit int
+}
+
+func (c *shortConn) Write(b []byte) (int, error) {
+	if c.buf.Len()+len(b) <= c.limit {
+		return c.buf.Write(b)
+	}
+	n := c.limit - c.buf.Len()
+	c.buf.Write(b[:n])
+	return n, errors.New("short write")
+}
+
+func (c *shortConn) Close() error { return nil }
+
+func TestSyslogConnPartialWrite(t *testing.T) {
+	c := &syslogConn{bufferSize: 8, framing: syslogFramingOctetCounting, reconnecting: true}
+	c.conn = &shortConn{limit: 5}
+	if err := c.write([]byte("hello world")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if c.conn != nil {
+		t.Fatalf("conn: want closed after a short write")
+	}
+
+	conn := &shortConn{limit: 1 << 10}
+	c.mu.Lock()
+	c.conn = conn
+	err := c.flushLocked()
+	c.mu.Unlock()
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	// "11 hello world" has been written up to "11 he"
+	if got, want := conn.buf.String(), "llo world"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSyslogConnFrame(t *testing.T) {
+	for _, tt := range []struct {
+		framing syslogFraming
+		msg     string
+		want    string
+	}{
+		{syslogFramingDatagram, "msg", "msg"},
+		{syslogFramingOctetCounting, "msg", "3 msg"},
+		{syslogFramingNonTransparent, "msg", "msg\n"},
+		{syslogFramingNonTransparent, "msg\n", "msg\n"},
+	} {
+		c := &syslogConn{framing: tt.framing}
+		if got := string(c.frame([]byte(tt.msg))); got != tt.want {
+			t.Errorf("frame(%d, %q): got %q, want %q", tt.framing, tt.msg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+// SyslogFormat is the format of syslog messages.
+type SyslogFormat int
+
+const (
+	// SyslogFormatRFC5424 formats messages as RFC 5424, with attrs as structured data.
+	SyslogFormatRFC5424 SyslogFormat = iota
+	// SyslogFormatRFC3164 formats messages as RFC 3164, the BSD syslog, with attrs as key=value
+	// appended to the message.
+	SyslogFormatRFC3164
+)
+
+// SyslogFacility is the facility of syslog messages, see RFC 5424 section 6.2.1.
+type SyslogFacility int
+
+const (
+	SyslogFacilityKern SyslogFacility = iota
+	SyslogFacilityUser
+	SyslogFacilityMail
+	SyslogFacilityDaemon
+	SyslogFacilityAuth
+	SyslogFacilitySyslog
+	SyslogFacilityLPR
+	SyslogFacilityNews
+	SyslogFacilityUUCP
+	SyslogFacilityCron
+	SyslogFacilityAuthPriv
+	SyslogFacilityFTP
+)
+
+const (
+	SyslogFacilityLocal0 SyslogFacility = iota + 16
+	SyslogFacilityLocal1
+	SyslogFacilityLocal2
+	SyslogFacilityLocal3
+	SyslogFacilityLocal4
+	SyslogFacilityLocal5
+	SyslogFacilityLocal6
+	SyslogFacilityLocal7
+)
+
+// DefaultSyslogStructuredDataID is the SD-ID of the structured data of attrs in RFC 5424 messages,
+// 32473 is the private enterprise number reserved for documentation by RFC 5612.
+const DefaultSyslogStructuredDataID = "slog@32473"
+
+//go:generate go-option -type "syslog"
+type syslog struct {
+	// Network is the network of the syslog server, "udp", "tcp", "tcp+tls", "unixgram" or "unix",
+	// or the local syslog server, by "unixgram" or "unix" on /dev/log, /var/run/syslog or /var/run/log, if empty.
+	// Messages are framed by octet counting over "tcp" and "tcp+tls", as RFC 5425 and RFC 6587,
+	// terminated by a newline over "unix", as local syslog daemons expect,
+	// and sent one per datagram over datagram networks.
+	Network string
+	// Addr is the address of the syslog server, ignored if Network is empty.
+	Addr string
+	// TLSConfig is the TLS configuration of "tcp+tls".
+	TLSConfig *tls.Config
+	// Timeout is the timeout of dialing and writing, no timeout if not positive.
+	Timeout time.Duration
+
+	// Format is the format of messages, SyslogFormatRFC5424 by default.
+	Format SyslogFormat
+	// Facility is the facility of messages, SyslogFacilityUser by default.
+	Facility SyslogFacility
+	// Hostname is the HOSTNAME of messages, os.Hostname() if empty.
+	Hostname string
+	// AppName is the APP-NAME of RFC 5424 messages or the TAG of RFC 3164 messages,
+	// the base name of os.Args[0] if empty.
+	AppName string
+	// StructuredDataID is the SD-ID of attrs in RFC 5424 messages, DefaultSyslogStructuredDataID if empty.
+	StructuredDataID string
+
+	// BackOff tells the time to wait before reconnecting, time_.NewExponentialBackOff() if nil.
+	// Reconnecting stops when BackOff stops, until the next record is handled.
+	BackOff time_.BackOff
+	// BufferSize is the maximum number of messages buffered while disconnected, the oldest message
+	// is dropped if exceeded, 1000 if 0, no buffering if negative.
+	// The number of messages dropped is reported by a warning record once reconnected.
+	BufferSize int
+}
+
+// SyslogHandler is a slog.Handler that sends records as syslog messages of RFC 5424 or RFC 3164,
+// over UDP, TCP, TCP+TLS or unix sockets.
+//
+// Levels are mapped to severities as: DEBUG to Debug, INFO to Informational, INFO+2 to Notice,
+// WARN to Warning, ERROR to Error, ERROR+4 to Critical and ERROR+8 to Alert.
+type SyslogHandler struct {
+	s      *syslogState
+	params []syslogParam // attrs added by WithAttrs, flattened
+	groups []string      // groups added by WithGroup
+}
+
+var _ slog.Handler = (*SyslogHandler)(nil)
+
+// syslogState is shared by a SyslogHandler and the handlers derived by WithAttrs and WithGroup.
+type syslogState struct {
+	opts      slog.HandlerOptions
+	format    SyslogFormat
+	facility  SyslogFacility
+	hostname  string
+	appName   string
+	sdID      string
+	procID    string
+	localHost bool // omits HOSTNAME of RFC 3164 messages, as the local syslog server adds it
+
+	conn *syslogConn
+}
+
+// syslogParam is a flattened attr, with the name qualified by groups.
+type syslogParam struct {
+	name  string
+	value string
+}
+
+// NewSyslogHandler creates a SyslogHandler that sends records to a syslog server,
+// using the given options.
+// If opts is nil, the default options are used.
+// The syslog server is dialed at once, records are buffered until connected if the dial fails.
+func NewSyslogHandler(opts *slog.HandlerOptions, options ...SyslogOption) (*SyslogHandler, error) {
+	var opt syslog
+	opt.Facility = SyslogFacilityUser
+	opt.ApplyOptions(options...)
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	if opt.Facility < SyslogFacilityKern || opt.Facility > SyslogFacilityLocal7 {
+		return nil, fmt.Errorf("slog: invalid syslog facility %d", opt.Facility)
+	}
+	if opt.Hostname == "" {
+		opt.Hostname, _ = os.Hostname()
+	}
+	if opt.AppName == "" {
+		opt.AppName = filepath.Base(os.Args[0])
+	}
+	if opt.StructuredDataID == "" {
+		opt.StructuredDataID = DefaultSyslogStructuredDataID
+	}
+	if opt.BackOff == nil {
+		opt.BackOff = time_.NewExponentialBackOff()
+	}
+	if opt.BufferSize == 0 {
+		opt.BufferSize = 1000
+	}
+	s := &syslogState{
+		opts:      *opts,
+		format:    opt.Format,
+		facility:  opt.Facility,
+		hostname:  syslogHeaderField(opt.Hostname, 255),
+		appName:   syslogHeaderField(opt.AppName, 48),
+		sdID:      syslogName(opt.StructuredDataID),
+		procID:    strconv.Itoa(os.Getpid()),
+		localHost: opt.Network == "",
+	}
+	conn, err := newSyslogConn(&opt, s.droppedMessage)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	return &SyslogHandler{s: s}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// The handler ignores records whose level is lower.
+func (h *SyslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.s.opts.Level != nil {
+		minLevel = h.s.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle sends r as a syslog message, or buffers it until connected.
+func (h *SyslogHandler) Handle(_ context.Context, r slog.Record) error {
+	params := slices.Clip(h.params)
+	if h.s.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		params = append(params, syslogParam{name: slog.SourceKey, value: frame.File + ":" + strconv.Itoa(frame.Line)})
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		params = h.s.appendParams(params, h.groups, a)
+		return true
+	})
+	return h.s.conn.write(h.s.marshal(r, params))
+}
+
+// WithAttrs returns a new SyslogHandler whose attributes consists of h's attributes followed by attrs.
+func (h *SyslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.params = slices.Clip(h.params)
+	for _, a := range attrs {
+		h2.params = h.s.appendParams(h2.params, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup returns a new SyslogHandler whose attrs are qualified by name.
+func (h *SyslogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// Close sends the messages buffered if connected, and closes the connection to the syslog server.
+// Records handled after Close are discarded with an error.
+func (h *SyslogHandler) Close() error {
+	return h.s.conn.close()
+}
+
+func (s *syslogState) appendParams(params []syslogParam, groups []string, a slog.Attr) []syslogParam {
+	if rep := s.opts.ReplaceAttr; rep != nil && a.Value.Kind() != slog.KindGroup {
+		a.Value = a.Value.Resolve()
+		a = rep(groups, a)
+	}
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return params
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if a.Key != "" {
+			groups = append(slices.Clip(groups), a.Key)
+		}
+		for _, ga := range attrs {
+			params = s.appendParams(params, groups, ga)
+		}
+		return params
+	}
+	name := a.Key
+	if len(groups) > 0 {
+		name = strings.Join(groups, ".") + "." + a.Key
+	}
+	return append(params, syslogParam{name: name, value: syslogValue(a.Value)})
+}
+
+// marshal formats r and params as a syslog message.
+func (s *syslogState) marshal(r slog.Record, params []syslogParam) []byte {
+	pri := int(s.facility)*8 + syslogSeverity(r.Level)
+	var buf []byte
+	buf = append(buf, '<')
+	buf = strconv.AppendInt(buf, int64(pri), 10)
+	buf = append(buf, '>')
+
+	if s.format == SyslogFormatRFC3164 {
+		// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+		t := r.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+		buf = t.Local().AppendFormat(buf, time.Stamp)
+		buf = append(buf, ' ')
+		if !s.localHost {
+			buf = append(buf, s.hostname...)
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, s.appName...)
+		buf = append(buf, '[')
+		buf = append(buf, s.procID...)
+		buf = append(buf, "]: "...)
+		buf = append(buf, r.Message...)
+		for _, p := range params {
+			buf = append(buf, ' ')
+			buf = append(buf, p.name...)
+			buf = append(buf, '=')
+			if needsQuoting(p.value, false) {
+				buf = strconv.AppendQuote(buf, p.value)
+			} else {
+				buf = append(buf, p.value...)
+			}
+		}
+		return buf
+	}
+
+	// <PRI>VERSION SP TIMESTAMP SP HOSTNAME SP APP-NAME SP PROCID SP MSGID SP STRUCTURED-DATA [SP MSG]
+	buf = append(buf, "1 "...)
+	if r.Time.IsZero() {
+		buf = append(buf, '-')
+	} else {
+		buf = r.Time.AppendFormat(buf, "2006-01-02T15:04:05.000000Z07:00")
+	}
+	buf = append(buf, ' ')
+	buf = append(buf, s.hostname...)
+	buf = append(buf, ' ')
+	buf = append(buf, s.appName...)
+	buf = append(buf, ' ')
+	buf = append(buf, s.procID...)
+	buf = append(buf, " - "...)
+	if len(params) == 0 {
+		buf = append(buf, '-')
+	} else {
+		buf = append(buf, '[')
+		buf = append(buf, s.sdID...)
+		for _, p := range params {
+			buf = append(buf, ' ')
+			buf = append(buf, syslogName(p.name)...)
+			buf = append(buf, `="`...)
+			for i := 0; i < len(p.value); i++ {
+				if c := p.value[i]; c == '"' || c == '\\' || c == ']' {
+					buf = append(buf, '\\')
+				}
+				buf = append(buf, p.value[i])
+			}
+			buf = append(buf, '"')
+		}
+		buf = append(buf, ']')
+	}
+	if r.Message != "" {
+		buf = append(buf, ' ')
+		if !isASCII(r.Message) {
+			buf = append(buf, "\ufeff"...) // BOM of UTF-8 MSG
+		}
+		buf = append(buf, r.Message...)
+	}
+	return buf
+}
+
+// droppedMessage formats the warning message of dropped messages.
+func (s *syslogState) droppedMessage(dropped int) []byte {
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "slog: syslog messages dropped while disconnected", 0)
+	return s.marshal(r, []syslogParam{{name: "dropped", value: strconv.Itoa(dropped)}})
+}
+
+// syslogSeverity maps level to the severity of syslog.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError+8:
+		return 1 // Alert
+	case level >= slog.LevelError+4:
+		return 2 // Critical
+	case level >= slog.LevelError:
+		return 3 // Error
+	case level >= slog.LevelWarn:
+		return 4 // Warning
+	case level >= slog.LevelInfo+2:
+		return 5 // Notice
+	case level >= slog.LevelInfo:
+		return 6 // Informational
+	default:
+		return 7 // Debug
+	}
+}
+
+func syslogValue(v slog.Value) string {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		if b, ok := byteSlice(v.Any()); ok {
+			return string(b)
+		}
+	}
+	return v.String()
+}
+
+// syslogHeaderField returns s as a header field of at most n printable US-ASCII characters, "-" if empty.
+func syslogHeaderField(s string, n int) string {
+	if s == "" {
+		return "-"
+	}
+	b := []byte(s[:min(len(s), n)])
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+// syslogName returns s as an SD-NAME of RFC 5424, at most 32 printable US-ASCII characters
+// except '=', ' ', ']' and '"'.
+func syslogName(s string) string {
+	b := []byte(syslogHeaderField(s, 32))
+	for i, c := range b {
+		if c == '=' || c == ']' || c == '"' {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
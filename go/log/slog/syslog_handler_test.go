@@ -0,0 +1,221 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func readSyslogPacket(t *testing.T, pc net.PacketConn) string {
+	t.Helper()
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read syslog message: %v", err)
+	}
+	return string(buf[:n])
+}
+
+func TestSyslogHandlerRFC5424(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	h, err := slog_.NewSyslogHandler(&slog.HandlerOptions{Level: slog.LevelDebug},
+		slog_.WithSyslogNetwork("udp"),
+		slog_.WithSyslogAddr(pc.LocalAddr().String()),
+		slog_.WithSyslogFacility(slog_.SyslogFacilityLocal0),
+		slog_.WithSyslogHostname("host"),
+		slog_.WithSyslogAppName("app"))
+	if err != nil {
+		t.Fatalf("NewSyslogHandler: %v", err)
+	}
+	defer h.Close()
+	logger := slog.New(h).With("svc", "api").WithGroup("req")
+
+	logger.Warn("hello", "path", `/a]"\b`, slog.Group("user", "id", 7), "err", errors.New("boom"))
+	want := fmt.Sprintf(`^<132>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}\S+ host app %d - `+
+		`\[slog@32473 svc="api" req\.path="/a\\\]\\"\\\\b" req\.user\.id="7" req\.err="boom"\] hello$`, os.Getpid())
+	if got := readSyslogPacket(t, pc); !regexp.MustCompile(want).MatchString(got) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	slog.New(h).Debug("héllo")
+	want = fmt.Sprintf(`^<135>1 \S+ host app %d - - \x{feff}héllo$`, os.Getpid())
+	if got := readSyslogPacket(t, pc); !regexp.MustCompile(want).MatchString(got) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSyslogHandlerRFC3164(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	h, err := slog_.NewSyslogHandler(nil,
+		slog_.WithSyslogNetwork("udp"),
+		slog_.WithSyslogAddr(pc.LocalAddr().String()),
+		slog_.WithSyslogFormat(slog_.SyslogFormatRFC3164),
+		slog_.WithSyslogHostname("host"),
+		slog_.WithSyslogAppName("app"))
+	if err != nil {
+		t.Fatalf("NewSyslogHandler: %v", err)
+	}
+	defer h.Close()
+
+	slog.New(h).WithGroup("g").Error("failed", "k", "v w")
+	want := fmt.Sprintf(`^<11>[A-Z][a-z]{2} [ \d]\d \d\d:\d\d:\d\d host app\[%d\]: failed g\.k="v w"$`, os.Getpid())
+	if got := readSyslogPacket(t, pc); !regexp.MustCompile(want).MatchString(got) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSyslogHandlerReconnect(t *testing.T) {
+	// reserve an address, which is not listened on until messages are buffered
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	h, err := slog_.NewSyslogHandler(nil,
+		slog_.WithSyslogNetwork("tcp"),
+		slog_.WithSyslogAddr(addr),
+		slog_.WithSyslogHostname("host"),
+		slog_.WithSyslogAppName("app"),
+		slog_.WithSyslogBackOff(time_.FullJitterBackOff(time.Millisecond, 10*time.Millisecond, 2)),
+		slog_.WithSyslogBufferSize(2))
+	if err != nil {
+		t.Fatalf("NewSyslogHandler: %v", err)
+	}
+	defer h.Close()
+	logger := slog.New(h)
+	for i := range 3 {
+		logger.Info("m" + strconv.Itoa(i)) // m0 is dropped
+	}
+
+	l, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("listen %s again: %v", addr, err)
+	}
+	defer l.Close()
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+	readFrame := func() string {
+		t.Helper()
+		size, err := r.ReadString(' ')
+		if err != nil {
+			t.Fatalf("read frame: %v", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(size, " "))
+		if err != nil {
+			t.Fatalf("malformed frame length %q: %v", size, err)
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			t.Fatalf("read frame: %v", err)
+		}
+		return string(msg)
+	}
+
+	for _, want := range []string{
+		`^<12>1 .* \[slog@32473 dropped="1"\] slog: syslog messages dropped while disconnected$`,
+		`^<14>1 .* - m1$`,
+		`^<14>1 .* - m2$`,
+	} {
+		if got := readFrame(); !regexp.MustCompile(want).MatchString(got) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+	logger.Info("m3")
+	if got, want := readFrame(), `^<14>1 .* - m3$`; !regexp.MustCompile(want).MatchString(got) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)); !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("Handle after Close: got %v, want %v", err, net.ErrClosed)
+	}
+}
+
+func TestSyslogHandlerUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("listen unix: %v", err)
+	}
+	defer l.Close()
+
+	h, err := slog_.NewSyslogHandler(nil,
+		slog_.WithSyslogNetwork("unix"),
+		slog_.WithSyslogAddr(path),
+		slog_.WithSyslogHostname("host"),
+		slog_.WithSyslogAppName("app"))
+	if err != nil {
+		t.Fatalf("NewSyslogHandler: %v", err)
+	}
+	defer h.Close()
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+
+	logger := slog.New(h)
+	// messages are terminated by a newline, not octet counted
+	for _, msg := range []string{"m0", "m1"} {
+		logger.Info(msg)
+		got, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read message: %v", err)
+		}
+		if want := `^<14>1 .* - ` + msg + `\n$`; !regexp.MustCompile(want).MatchString(got) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNewSyslogHandlerError(t *testing.T) {
+	for _, opts := range [][]slog_.SyslogOption{
+		{slog_.WithSyslogNetwork("sctp"), slog_.WithSyslogAddr("localhost:514")},
+		{slog_.WithSyslogNetwork("udp")},
+		{slog_.WithSyslogNetwork("udp"), slog_.WithSyslogAddr("localhost:514"), slog_.WithSyslogFacility(24)},
+	} {
+		if _, err := slog_.NewSyslogHandler(nil, opts...); err == nil {
+			t.Errorf("NewSyslogHandler: want error")
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// VModule is a set of level overrides per package or file, resolved from the source of records,
+// as the -vmodule flag of glog does. VModule is safe for concurrent use, and can be changed at
+// runtime by Set, as a flag.Value, or by HTTP requests, as an http.Handler.
+//
+// A spec of VModule is a comma-separated list of pattern=level, such as "net/mux=debug,webserver*=warn":
+//
+//	pattern  A glob, see path.Match, matched against the trailing path elements of the package
+//	         path and the file path without ".go" of the source of a record, such that
+//	         "net/mux" matches the package "github.com/searKing/golang/go/net/mux",
+//	         and "webserver*" matches the package "webserver" and the file "webserver.factory.go".
+//	level    A slog.Level, such as "debug" or "warn+2", or a verbosity of glog, such as "2",
+//	         which is slog.LevelInfo-2.
+//
+// The first pattern matched wins. Levels are cached per source PC, until the spec is changed.
+type VModule struct {
+	state atomic.Pointer[vmoduleState]
+}
+
+var (
+	_ fmt.Stringer = (*VModule)(nil)
+	_ http.Handler = (*VModule)(nil)
+)
+
+type vmoduleState struct {
+	rules    []vmoduleRule
+	minLevel slog.Level // the lowest level of rules
+	levels   sync.Map   // of source PC to vmoduleLevel, cached
+}
+
+type vmoduleRule struct {
+	pattern  string
+	elements int // number of path elements in pattern
+	level    slog.Level
+}
+
+type vmoduleLevel struct {
+	level slog.Level
+	ok    bool
+}
+
+// NewVModule returns a VModule of spec.
+func NewVModule(spec string) (*VModule, error) {
+	v := &VModule{}
+	if err := v.Set(spec); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Set replaces the level overrides by spec, an empty spec removes all of them.
+func (v *VModule) Set(spec string) error {
+	st := &vmoduleState{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		pattern, levelText, ok := strings.Cut(item, "=")
+		pattern, levelText = strings.TrimSpace(pattern), strings.TrimSpace(levelText)
+		if !ok || pattern == "" {
+			return fmt.Errorf("slog: invalid vmodule %q: want pattern=level", item)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("slog: invalid vmodule %q: %w", item, err)
+		}
+		level, err := parseVModuleLevel(levelText)
+		if err != nil {
+			return fmt.Errorf("slog: invalid vmodule %q: %w", item, err)
+		}
+		if len(st.rules) == 0 || level < st.minLevel {
+			st.minLevel = level
+		}
+		st.rules = append(st.rules, vmoduleRule{
+			pattern:  pattern,
+			elements: strings.Count(pattern, "/") + 1,
+			level:    level,
+		})
+	}
+	v.state.Store(st)
+	return nil
+}
+
+// String returns the spec of v, with levels formatted by slog.Level.String.
+func (v *VModule) String() string {
+	if v == nil {
+		return ""
+	}
+	st := v.state.Load()
+	if st == nil {
+		return ""
+	}
+	items := make([]string, 0, len(st.rules))
+	for _, rule := range st.rules {
+		items = append(items, rule.pattern+"="+rule.level.String())
+	}
+	return strings.Join(items, ",")
+}
+
+// Level returns the level overriding the level of records logged at pc, the source of records,
+// and whether there is any.
+func (v *VModule) Level(pc uintptr) (slog.Level, bool) {
+	st := v.state.Load()
+	if st == nil || len(st.rules) == 0 || pc == 0 {
+		return 0, false
+	}
+	if l, ok := st.levels.Load(pc); ok {
+		return l.(vmoduleLevel).level, l.(vmoduleLevel).ok
+	}
+	l := st.resolve(pc)
+	st.levels.Store(pc, l)
+	return l.level, l.ok
+}
+
+// minLevel returns the lowest level of overrides, and whether there is any.
+func (v *VModule) minLevel() (slog.Level, bool) {
+	st := v.state.Load()
+	if st == nil || len(st.rules) == 0 {
+		return 0, false
+	}
+	return st.minLevel, true
+}
+
+// ServeHTTP serves the spec of v by GET, and replaces it by the body of PUT or POST.
+func (v *VModule) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := v.Set(string(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, v.String()+"\n")
+}
+
+func (st *vmoduleState) resolve(pc uintptr) vmoduleLevel {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	pkg := packagePath(frame.Function)
+	file := strings.TrimSuffix(frame.File, ".go")
+	for _, rule := range st.rules {
+		if matchPathSuffix(rule.pattern, rule.elements, pkg) || matchPathSuffix(rule.pattern, rule.elements, file) {
+			return vmoduleLevel{level: rule.level, ok: true}
+		}
+	}
+	return vmoduleLevel{}
+}
+
+// packagePath returns the package path of a function name, such as "github.com/a/b" of
+// "github.com/a/b.(*T).M".
+func packagePath(funcName string) string {
+	if i := strings.IndexByte(funcName, '['); i >= 0 { // type parameters
+		funcName = funcName[:i]
+	}
+	slash := strings.LastIndexByte(funcName, '/')
+	if dot := strings.IndexByte(funcName[slash+1:], '.'); dot >= 0 {
+		return funcName[:slash+1+dot]
+	}
+	return funcName
+}
+
+// matchPathSuffix reports whether pattern of n path elements matches the last n path elements of p.
+func matchPathSuffix(pattern string, n int, p string) bool {
+	if p == "" {
+		return false
+	}
+	i := len(p)
+	for ; n > 0 && i >= 0; n-- {
+		i = strings.LastIndexByte(p[:i], '/')
+	}
+	if n > 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern, p[i+1:])
+	return ok
+}
+
+// parseVModuleLevel parses a slog.Level, or a verbosity of glog.
+func parseVModuleLevel(s string) (slog.Level, error) {
+	if v, err := strconv.Atoi(s); err == nil {
+		if v < 0 {
+			return 0, fmt.Errorf("negative verbosity %d", v)
+		}
+		return slog.LevelInfo - slog.Level(v), nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return 0, err
+	}
+	return l, nil
+}
+
+var _ slog.Handler = (*vmoduleHandler)(nil)
+
+type vmoduleHandler struct {
+	v       *VModule
+	handler slog.Handler
+}
+
+func (t vmoduleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if t.handler.Enabled(ctx, level) {
+		return true
+	}
+	// the source is unknown until the record is handled
+	minLevel, ok := t.v.minLevel()
+	return ok && level >= minLevel
+}
+
+func (t vmoduleHandler) Handle(ctx context.Context, record slog.Record) error {
+	if level, ok := t.v.Level(record.PC); ok {
+		if record.Level < level {
+			return nil
+		}
+	} else if !t.handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return t.handler.Handle(ctx, record)
+}
+
+func (t vmoduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return VModuleHandler(t.v, t.handler.WithAttrs(attrs))
+}
+
+func (t vmoduleHandler) WithGroup(name string) slog.Handler {
+	return VModuleHandler(t.v, t.handler.WithGroup(name))
+}
+
+// VModuleHandler creates a slog.Handler that overrides the level of handler per package or file
+// of the source of records by v, see VModule.
+// Records enabled by v are handed over to handler even if handler is disabled at their level,
+// such as debug records of a GlogHandler at slog.LevelInfo.
+func VModuleHandler(v *VModule, handler slog.Handler) slog.Handler {
+	return vmoduleHandler{v: v, handler: handler}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+func TestVModuleHandler(t *testing.T) {
+	v, err := slog_.NewVModule("net/mux=error, vmodule_test=debug")
+	if err != nil {
+		t.Fatalf("NewVModule: %v", err)
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog_.VModuleHandler(v,
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: removeTime}))).With("k", "v")
+
+	logger.Debug("debug by file")
+	if got, want := buf.String(), "level=DEBUG msg=\"debug by file\" k=v\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := v.Set("go/log/slog*=warn"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn by package")
+	if got, want := buf.String(), "level=WARN msg=\"warn by package\" k=v\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := v.Set(""); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if h := logger.Handler(); h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("debug records should be disabled without overrides")
+	}
+	logger.Debug("debug")
+	logger.Info("info")
+	if got, want := buf.String(), "level=INFO msg=info k=v\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestVModuleSet(t *testing.T) {
+	v, err := slog_.NewVModule("a=debug,b/c*=warn+1,d=2")
+	if err != nil {
+		t.Fatalf("NewVModule: %v", err)
+	}
+	if got, want := v.String(), "a=DEBUG,b/c*=WARN+1,d=DEBUG+2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, spec := range []string{"a", "=debug", "a=verbose", "a=-1", "[=debug"} {
+		if err := v.Set(spec); err == nil {
+			t.Errorf("Set(%q): want error", spec)
+		}
+	}
+	if got, want := v.String(), "a=DEBUG,b/c*=WARN+1,d=DEBUG+2"; got != want {
+		t.Errorf("invalid spec changed %q to %q", want, got)
+	}
+}
+
+func TestVModuleServeHTTP(t *testing.T) {
+	v, err := slog_.NewVModule("a=debug")
+	if err != nil {
+		t.Fatalf("NewVModule: %v", err)
+	}
+	for _, tt := range []struct {
+		method string
+		body   string
+		code   int
+		want   string
+	}{
+		{http.MethodGet, "", http.StatusOK, "a=DEBUG\n"},
+		{http.MethodPut, "webserver*=warn", http.StatusOK, "webserver*=WARN\n"},
+		{http.MethodPut, "webserver*", http.StatusBadRequest, ""},
+		{http.MethodDelete, "", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "", http.StatusOK, "webserver*=WARN\n"},
+	} {
+		w := httptest.NewRecorder()
+		v.ServeHTTP(w, httptest.NewRequest(tt.method, "/debug/vmodule", strings.NewReader(tt.body)))
+		if w.Code != tt.code {
+			t.Errorf("%s %q: got code %d, want %d", tt.method, tt.body, w.Code, tt.code)
+		}
+		if tt.want != "" && w.Body.String() != tt.want {
+			t.Errorf("%s %q: got %q, want %q", tt.method, tt.body, w.Body.String(), tt.want)
+		}
+	}
+}
@@ -66,6 +66,9 @@ func DoWithBackoff(httpReq *http.Request, opts ...DoWithBackoffOption) (resp *ht
 				return opt.clientInterceptor(req, retry, do, opts...)
 			}
 		}
+		if retries == 0 && opt.RetryBudget != nil {
+			opt.RetryBudget.Deposit()
+		}
 		resp, err = httpDo(httpReq, retries)
 		errs = append(errs, err)
 
@@ -99,6 +102,14 @@ func DoWithBackoff(httpReq *http.Request, opts ...DoWithBackoffOption) (resp *ht
 			}
 		}
 
+		if opt.RetryBudget != nil && !opt.RetryBudget.TryWithdraw() {
+			if err != nil {
+				return nil, fmt.Errorf("http do reach retry budget after retries %d: %w", retries, errors.Join(errs...))
+			} else {
+				return resp, nil
+			}
+		}
+
 		timer := time.NewTimer(wait)
 		select {
 		case <-timer.C:
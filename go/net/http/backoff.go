@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -15,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/searKing/golang/go/sync/circuitbreaker"
 	time_ "github.com/searKing/golang/go/time"
 )
 
@@ -60,6 +62,11 @@ func RetryAfter(resp *http.Response, err error, defaultBackoff time.Duration) (b
 	}
 
 	if err != nil {
+		// Don't retry if the upstream is cut off by a circuit breaker.
+		if errors.Is(err, circuitbreaker.ErrOpenState) || errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+			return backoff, false
+		}
+
 		if v, ok := err.(*url.Error); ok {
 			// Don't retry if the error was due to too many redirects.
 			if redirectsErrorRe.MatchString(v.Error()) {
@@ -205,6 +212,8 @@ type doWithBackoff struct {
 	ChainClientInterceptors  []ClientInterceptor
 	RetryAfter               RetryAfterHandler
 	ExponentialBackOffOption []time_.ExponentialBackOffOption
+	// RetryBudget limits retries to a percentage of recent requests, no limit if nil.
+	RetryBudget *time_.RetryBudget
 }
 
 func (o *doWithBackoff) SetDefault() {
@@ -49,6 +49,9 @@ func RoundTripperWithBackoff(rt http.RoundTripper, opts ...DoWithBackoffOption)
 					return opt.clientInterceptor(req, retry, do, opts...)
 				}
 			}
+			if retries == 0 && opt.RetryBudget != nil {
+				opt.RetryBudget.Deposit()
+			}
 			resp, err := httpDo(req, retries)
 
 			wait, ok := backoff.NextBackOff()
@@ -77,6 +80,14 @@ func RoundTripperWithBackoff(rt http.RoundTripper, opts ...DoWithBackoffOption)
 				}
 			}
 
+			if opt.RetryBudget != nil && !opt.RetryBudget.TryWithdraw() {
+				if err != nil {
+					return nil, fmt.Errorf("http do reach retry budget after retries %d: %w", retries, err)
+				} else {
+					return resp, nil
+				}
+			}
+
 			timer := time.NewTimer(wait)
 			select {
 			case <-timer.C:
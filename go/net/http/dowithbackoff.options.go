@@ -86,3 +86,12 @@ func WithDoWithBackoffOptionProxy(proxyUrl string, proxyTarget string) DoWithBac
 			return cli.Do(req)
 		})
 }
+
+// WithDoWithBackoffOptionRetryBudget returns a DoWithBackoffOption that limits retries by budget,
+// shared by all requests sent with the same budget, such as time_.DefaultRetryBudget.
+// Each request deposits to the budget, and each retry withdraws from it.
+func WithDoWithBackoffOptionRetryBudget(budget *time_.RetryBudget) DoWithBackoffOption {
+	return DoWithBackoffOptionFunc(func(o *doWithBackoff) {
+		o.RetryBudget = budget
+	})
+}
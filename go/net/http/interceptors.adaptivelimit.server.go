@@ -0,0 +1,35 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/searKing/golang/go/sync/adaptive"
+)
+
+// AdaptiveLimitServerInterceptor returns a new server interceptor that performs adaptive concurrency limiting.
+// Requests over the limit estimated by limiter are rejected with 503 Service Unavailable,
+// responses of 429, 503 and 504 from next are sampled as drops to back off the limit.
+func AdaptiveLimitServerInterceptor(next http.Handler, limiter *adaptive.Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listener, ok := limiter.Acquire()
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable)+
+				": rejected by adaptive concurrency limit, please retry later", http.StatusServiceUnavailable)
+			return
+		}
+		// no-op if completed, release without sampling if next panics
+		defer listener.OnIgnore()
+		rw := NewResponseWriterDelegator(w)
+		next.ServeHTTP(rw, r)
+		switch rw.Status() {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			listener.OnDropped()
+		default:
+			listener.OnSuccess()
+		}
+	})
+}
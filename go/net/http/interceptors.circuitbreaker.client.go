@@ -0,0 +1,63 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/searKing/golang/go/sync/circuitbreaker"
+)
+
+// CircuitBreakerKeyByHost returns the host of req as the name of its circuit breaker.
+func CircuitBreakerKeyByHost(req *http.Request) string {
+	return req.URL.Host
+}
+
+// CircuitBreakerClientInterceptor returns a new client interceptor that rejects requests
+// with circuitbreaker.ErrOpenState or circuitbreaker.ErrTooManyRequests while the circuit breaker is not closed.
+// A request is a failure if it fails or responds 5xx.
+// key names the circuit breaker of a request in group, CircuitBreakerKeyByHost if nil.
+// DoWithBackoff does not retry a request rejected by the circuit breaker, see RetryAfter.
+func CircuitBreakerClientInterceptor(group *circuitbreaker.Group, key func(req *http.Request) string) ClientInterceptor {
+	if key == nil {
+		key = CircuitBreakerKeyByHost
+	}
+	return func(req *http.Request, retry int, invoker ClientInvoker, opts ...DoWithBackoffOption) (resp *http.Response, err error) {
+		return doWithCircuitBreaker(group.Get(key(req)), req, func(req *http.Request) (*http.Response, error) {
+			return invoker(req, retry)
+		})
+	}
+}
+
+// RoundTripperWithCircuitBreaker wraps http.RoundTripper with circuit breakers.
+// A request is a failure if it fails or responds 5xx.
+// key names the circuit breaker of a request in group, CircuitBreakerKeyByHost if nil.
+func RoundTripperWithCircuitBreaker(rt http.RoundTripper, group *circuitbreaker.Group, key func(req *http.Request) string) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if key == nil {
+		key = CircuitBreakerKeyByHost
+	}
+	return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return doWithCircuitBreaker(group.Get(key(req)), req, rt.RoundTrip)
+	})
+}
+
+func doWithCircuitBreaker(cb *circuitbreaker.CircuitBreaker, req *http.Request,
+	do func(req *http.Request) (*http.Response, error)) (*http.Response, error) {
+	done, err := cb.Allow()
+	if err != nil {
+		return nil, fmt.Errorf("http: %s %s: %w", req.Method, cb.Name(), err)
+	}
+	resp, err := do(req)
+	if err == nil && resp != nil && resp.StatusCode >= http.StatusInternalServerError {
+		done(fmt.Errorf("http: %s", resp.Status))
+	} else {
+		done(err)
+	}
+	return resp, err
+}
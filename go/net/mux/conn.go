@@ -98,6 +98,7 @@ func (c *conn) serve(ctx context.Context) {
 	defer cancelCtx()
 
 	c.setState(c.muc, ConnStateActive)
+	c.muc.startIdleTimer(c.server.timingWheel, c.server.idleTimeout)
 
 	rwc, err := c.hijackLocked() // so the conn is taken over
 	if err != nil {
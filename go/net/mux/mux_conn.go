@@ -7,14 +7,25 @@ package mux
 import (
 	"io"
 	"net"
+	"sync/atomic"
+	"time"
 
 	io_ "github.com/searKing/golang/go/io"
+	time_ "github.com/searKing/golang/go/time"
 )
 
 // sniffConn wraps a net.Conn and provides transparent sniffing of connection data.
 type sniffConn struct {
 	net.Conn
 	sniffer io_.ReadSniffer
+
+	// idleTimer closes the connection if idle for idleTimeout, nil if not enabled.
+	// Reads and writes only record the time they are active, which is checked when idleTimer
+	// fires, so that they do not contend on the lock of the timing wheel.
+	idleTimer   atomic.Pointer[time_.WheelTimer]
+	idleTimeout time.Duration
+	lastActive  atomic.Int64 // unix nano of the last data read or written
+	closed      atomic.Bool
 }
 
 func newMuxConn(c net.Conn) *sniffConn {
@@ -35,7 +46,56 @@ func newMuxConn(c net.Conn) *sniffConn {
 // return either err == EOF or err == nil.  The next Read should
 // return 0, EOF.
 func (m *sniffConn) Read(p []byte) (int, error) {
-	return m.sniffer.Read(p)
+	n, err := m.sniffer.Read(p)
+	if n > 0 {
+		m.touchIdleTimer()
+	}
+	return n, err
+}
+
+func (m *sniffConn) Write(p []byte) (int, error) {
+	n, err := m.Conn.Write(p)
+	if n > 0 {
+		m.touchIdleTimer()
+	}
+	return n, err
+}
+
+func (m *sniffConn) Close() error {
+	m.closed.Store(true)
+	if t := m.idleTimer.Load(); t != nil {
+		t.Stop()
+	}
+	return m.Conn.Close()
+}
+
+// startIdleTimer closes the connection if no data is read or written for d,
+// by a timer on tw.
+func (m *sniffConn) startIdleTimer(tw *time_.TimingWheel, d time.Duration) {
+	if tw == nil || d <= 0 {
+		return
+	}
+	m.idleTimeout = d
+	m.touchIdleTimer()
+	m.idleTimer.Store(tw.AfterFunc(d, m.onIdleTimer))
+}
+
+// touchIdleTimer records the connection is active now.
+func (m *sniffConn) touchIdleTimer() {
+	m.lastActive.Store(time.Now().UnixNano())
+}
+
+// onIdleTimer closes the connection if idle for idleTimeout, or waits for the rest of it.
+func (m *sniffConn) onIdleTimer() {
+	if m.closed.Load() {
+		return
+	}
+	idle := time.Since(time.Unix(0, m.lastActive.Load()))
+	if t := m.idleTimer.Load(); t != nil && idle < m.idleTimeout {
+		t.Reset(m.idleTimeout - idle)
+		return
+	}
+	_ = m.Conn.Close()
 }
 
 func (m *sniffConn) startSniffing() io.Reader {
@@ -17,6 +17,7 @@ import (
 	http_ "github.com/searKing/golang/go/net/http"
 	"github.com/searKing/golang/go/strings"
 	"github.com/searKing/golang/go/sync/atomic"
+	time_ "github.com/searKing/golang/go/time"
 )
 
 // for readability of sniffTimeout
@@ -48,6 +49,12 @@ type Server struct {
 	maxIdleConns int
 	errHandler   ErrorHandler
 
+	// idleTimeout closes connections without reads or writes for idleTimeout,
+	// by a timer on timingWheel per connection.
+	// take effects if only idleTimeout is bigger than 0 and timingWheel is not nil.
+	idleTimeout time.Duration
+	timingWheel *time_.TimingWheel
+
 	// ConnStateHook specifies an optional callback function that is
 	// called when a client connection changes state. See the
 	// ConnStateHook type and associated constants for details.
@@ -4,7 +4,12 @@
 
 package mux
 
-import "log"
+import (
+	"log"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
 
 // WithMaxIdleConns controls the maximum number of idle (keep-alive)
 // connections across all hosts. Zero means no limit.
@@ -20,3 +25,13 @@ func WithErrorLog(errorLog *log.Logger) ServerOption {
 		c.errorLog = errorLog
 	})
 }
+
+// WithIdleTimeout closes connections without reads or writes for idleTimeout,
+// by a timer on tw per connection, which is cheaper than a runtime timer
+// with a large number of connections.
+func WithIdleTimeout(tw *time_.TimingWheel, idleTimeout time.Duration) ServerOption {
+	return ServerOptionFunc(func(c *Server) {
+		c.timingWheel = tw
+		c.idleTimeout = idleTimeout
+	})
+}
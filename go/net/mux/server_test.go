@@ -17,6 +17,7 @@ import (
 
 	"github.com/searKing/golang/go/net/mux"
 	"github.com/searKing/golang/go/testing/leakcheck"
+	time_ "github.com/searKing/golang/go/time"
 
 	"golang.org/x/net/http2"
 )
@@ -537,3 +538,55 @@ func TestClose(t *testing.T) {
 		}
 	}
 }
+
+func TestIdleTimeout(t *testing.T) {
+	defer leakcheck.Check(t)
+	loopbackLis := testListener(t)
+	defer loopbackLis.Close()
+	tw := time_.NewTimingWheel(time_.WithTimingWheelTick(5 * time.Millisecond))
+	defer tw.Stop()
+	muxer := mux.NewServeMux()
+	anyListener := muxer.HandleListener(mux.Any())
+	defer anyListener.Close()
+
+	srv := mux.NewServer().ApplyOptions(mux.WithIdleTimeout(tw, 100*time.Millisecond))
+	defer srv.Close()
+	srv.Handler = muxer
+	go func() {
+		_ = srv.Serve(loopbackLis)
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		conn, err := anyListener.Accept()
+		if err != nil {
+			close(closed)
+			return
+		}
+		_, _ = io.Copy(io.Discard, conn)
+		_ = conn.Close()
+		close(closed)
+	}()
+
+	conn, err := net.Dial("tcp", loopbackLis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// active for longer than the idle timeout
+	for range 15 {
+		if _, err := conn.Write([]byte("ping")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		select {
+		case <-closed:
+			t.Fatal("active connection is closed by the idle timeout")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle connection is not closed")
+	}
+}
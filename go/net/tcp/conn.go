@@ -13,6 +13,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	time_ "github.com/searKing/golang/go/time"
 	"github.com/searKing/golang/go/x/dispatch"
 )
 
@@ -56,6 +57,14 @@ type conn struct {
 	w *checkConnErrorWriter
 
 	curState struct{ atomic uint64 } // packed (unixtime<<8|uint8(ConnState))
+
+	// idleTimer closes the connection if idle for Server.IdleTimeout,
+	// nil if Server.TimingWheel is nil.
+	// Reads and writes only record the time they are active, which is checked when idleTimer
+	// fires, so that they do not contend on the lock of the timing wheel.
+	idleTimer   atomic.Pointer[time_.WheelTimer]
+	lastActive  atomic.Int64 // unix nano of the last data read or written
+	idleStopped atomic.Bool
 }
 
 func (c *conn) finalFlush() {
@@ -121,6 +130,42 @@ func (c *conn) getState() (state ConnState, unixSec int64) {
 	return ConnState(packedState & 0xff), int64(packedState >> 8)
 }
 
+// startIdleTimer arms idleTimer, if the connection is managed by Server.TimingWheel.
+func (c *conn) startIdleTimer() {
+	d := c.server.idleTimeout()
+	if d <= 0 || c.server.TimingWheel == nil {
+		return
+	}
+	c.touchIdleTimer()
+	c.idleTimer.Store(c.server.TimingWheel.AfterFunc(d, c.onIdleTimer))
+}
+
+// touchIdleTimer records the connection is active now, which pushes idleTimer back.
+func (c *conn) touchIdleTimer() {
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// onIdleTimer closes the connection if idle for Server.IdleTimeout, or waits for the rest of it.
+func (c *conn) onIdleTimer() {
+	if c.idleStopped.Load() {
+		return
+	}
+	d := c.server.idleTimeout()
+	idle := time.Since(time.Unix(0, c.lastActive.Load()))
+	if t := c.idleTimer.Load(); t != nil && idle < d {
+		t.Reset(d - idle)
+		return
+	}
+	_ = c.rwc.Close()
+}
+
+func (c *conn) stopIdleTimer() {
+	c.idleStopped.Store(true)
+	if t := c.idleTimer.Load(); t != nil {
+		t.Stop()
+	}
+}
+
 // ErrAbortHandler is a sentinel panic value to abort a handler.
 // While any panic from OnHandshake aborts the response to the client,
 // panicking with ErrAbortHandler also suppresses logging of a stack
@@ -210,6 +255,8 @@ func (c *conn) serve(ctx context.Context) {
 	// wrap original conn itself with buffer
 	c.r = &connReader{conn: c}
 	c.w = &checkConnErrorWriter{c: c}
+	c.startIdleTimer()
+	defer c.stopIdleTimer()
 
 	// read and handle the msg
 	dispatch.NewDispatch(dispatch.ReaderFunc(func(ctx context.Context) (any, error) {
@@ -243,6 +290,9 @@ type checkConnErrorWriter struct {
 
 func (w checkConnErrorWriter) Write(p []byte) (n int, err error) {
 	n, err = w.c.rwc.Write(p)
+	if n > 0 {
+		w.c.touchIdleTimer()
+	}
 	if err != nil && w.c.werr == nil {
 		w.c.werr = err
 		w.c.cancelCtx()
@@ -150,6 +150,9 @@ func (cr *connReader) Read(p []byte) (n int, err error) {
 	cr.inRead = true
 	cr.unlock()
 	n, err = cr.conn.rwc.Read(p)
+	if n > 0 {
+		cr.conn.touchIdleTimer()
+	}
 
 	cr.lock()
 	cr.inRead = false
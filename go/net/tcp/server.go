@@ -57,6 +57,11 @@ type Server struct {
 	IdleTimeout  time.Duration
 	MaxBytes     int
 
+	// TimingWheel, if not nil, closes connections without reads or writes for IdleTimeout,
+	// by a timer on the TimingWheel per connection, which is cheaper than a runtime timer
+	// with a large number of connections.
+	TimingWheel *time_.TimingWheel
+
 	ErrorLog *log.Logger
 
 	mu         sync.Mutex
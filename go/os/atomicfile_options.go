@@ -0,0 +1,52 @@
+// Code generated by "go-option -type atomicFile"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package os
+
+// A AtomicFileOption sets options.
+type AtomicFileOption interface {
+	apply(*atomicFile)
+}
+
+// EmptyAtomicFileOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyAtomicFileOption struct{}
+
+func (EmptyAtomicFileOption) apply(*atomicFile) {}
+
+// AtomicFileOptionFunc wraps a function that modifies atomicFile into an
+// implementation of the AtomicFileOption interface.
+type AtomicFileOptionFunc func(*atomicFile)
+
+func (f AtomicFileOptionFunc) apply(do *atomicFile) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *atomicFile) ApplyOptions(options ...AtomicFileOption) *atomicFile {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withAtomicFile sets atomicFile.
+func withAtomicFile(v atomicFile) AtomicFileOption {
+	return AtomicFileOptionFunc(func(o *atomicFile) {
+		*o = v
+	})
+}
+
+// WithAtomicFileBackupExt sets BackupExt in atomicFile.
+// BackupExt keeps the previous version of the file as name+BackupExt, replaced on each commit.
+// take effects if only BackupExt is not empty.
+func WithAtomicFileBackupExt(v string) AtomicFileOption {
+	return AtomicFileOptionFunc(func(o *atomicFile) {
+		o.BackupExt = v
+	})
+}
@@ -72,9 +72,30 @@ func WithCacheFileCacheMetaExt(v string) CacheFileOption {
 }
 
 // WithCacheFileCacheExpiredAfter sets CacheExpiredAfter in CacheFile.
-// Cache file expiration time, lazy expire cache files base on cache URL modification time
+// Cache file expiration time, lazy expire cache files base on cache URL modification time,
+// that is the time the cache file is put or hit last.
+// take effects if only CacheExpiredAfter is bigger than 0.
 func WithCacheFileCacheExpiredAfter(v time.Duration) CacheFileOption {
 	return CacheFileOptionFunc(func(o *CacheFile) {
 		o.CacheExpiredAfter = v
 	})
 }
+
+// WithCacheFileMaxTotalSize sets MaxTotalSize in CacheFile.
+// max total size of cache files, cache meta files excluded.
+// Cache files are evicted by EvictPolicy once a cache file is put, or by Scrub,
+// until the total size is not bigger than MaxTotalSize.
+// take effects if only MaxTotalSize is bigger than 0.
+func WithCacheFileMaxTotalSize(v int64) CacheFileOption {
+	return CacheFileOptionFunc(func(o *CacheFile) {
+		o.MaxTotalSize = v
+	})
+}
+
+// WithCacheFileEvictPolicy sets EvictPolicy in CacheFile.
+// EvictPolicy chooses cache files to evict by MaxTotalSize, CacheEvictLRU if not set.
+func WithCacheFileEvictPolicy(v CacheEvictPolicy) CacheFileOption {
+	return CacheFileOptionFunc(func(o *CacheFile) {
+		o.EvictPolicy = v
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// AppendOnlyFile is a file appended only, synced to stable storage by group commit:
+// Append calls waiting for a sync are committed together by one fsync, instead of one fsync each.
+//
+// Once a sync fails, the data not synced may be lost, so the error is returned by all the calls after.
+type AppendOnlyFile struct {
+	file *os.File
+
+	mu      sync.Mutex // guards writes
+	written int64      // bytes written since opened
+	err     error      // sticky error of sync
+
+	syncMu sync.Mutex // serializes syncs
+	synced int64      // bytes synced since opened, guarded by syncMu
+}
+
+// OpenAppendOnlyFile opens the file named for appending, created with perm (before umask) if not exist,
+// and the directory of which is synced then.
+// If the dir does not exist, OpenAppendOnlyFile creates it with 0755 (before umask).
+func OpenAppendOnlyFile(name string, perm os.FileMode) (*AppendOnlyFile, error) {
+	_, statErr := os.Stat(name)
+	file, err := OpenFileAll(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, DefaultPermissionDirectory, perm)
+	if err != nil {
+		return nil, err
+	}
+	if os.IsNotExist(statErr) {
+		if err := SyncDir(filepath.Dir(name)); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+	return &AppendOnlyFile{file: file}, nil
+}
+
+// Name returns the name of the file as presented to OpenAppendOnlyFile.
+func (f *AppendOnlyFile) Name() string {
+	return f.file.Name()
+}
+
+// Write appends p to the file, without syncing it, see Sync.
+func (f *AppendOnlyFile) Write(p []byte) (n int, err error) {
+	_, err = f.write(p)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Append appends p to the file, and returns once p is synced to stable storage,
+// by a sync shared with the other Append calls meanwhile.
+func (f *AppendOnlyFile) Append(p []byte) error {
+	end, err := f.write(p)
+	if err != nil {
+		return err
+	}
+	return f.syncTo(end)
+}
+
+// Sync commits the contents written to stable storage.
+func (f *AppendOnlyFile) Sync() error {
+	f.mu.Lock()
+	end, err := f.written, f.err
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return f.syncTo(end)
+}
+
+// Close syncs and closes the file.
+func (f *AppendOnlyFile) Close() error {
+	err := f.Sync()
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// write appends p, and returns the bytes written since opened, p included.
+func (f *AppendOnlyFile) write(p []byte) (end int64, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return 0, f.err
+	}
+	n, err := f.file.Write(p)
+	f.written += int64(n)
+	return f.written, err
+}
+
+// syncTo returns once bytes written until end are synced, syncing all bytes written so far if not yet.
+func (f *AppendOnlyFile) syncTo(end int64) error {
+	f.syncMu.Lock()
+	defer f.syncMu.Unlock()
+	f.mu.Lock()
+	written, err := f.written, f.err
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// synced by the group committed meanwhile
+	if f.synced >= end {
+		return nil
+	}
+
+	if err := f.file.Sync(); err != nil {
+		f.mu.Lock()
+		f.err = err
+		f.mu.Unlock()
+		return err
+	}
+	f.synced = written
+	return nil
+}
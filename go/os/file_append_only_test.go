@@ -0,0 +1,49 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	os_ "github.com/searKing/golang/go/os"
+)
+
+func TestAppendOnlyFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "sub", "wal.log")
+	f, err := os_.OpenAppendOnlyFile(name, 0o644)
+	if err != nil {
+		t.Fatalf("OpenAppendOnlyFile: %v", err)
+	}
+	const n = 64
+	record := []byte("0123456789abcdef\n")
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f.Append(record); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := f.Write(record); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := bytes.Repeat(record, n+1); !bytes.Equal(got, want) {
+		t.Errorf("ReadFile = %d bytes, want %d bytes", len(got), len(want))
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+//go:generate go-option -type "atomicFile"
+type atomicFile struct {
+	// BackupExt keeps the previous version of the file as name+BackupExt, replaced on each commit.
+	// take effects if only BackupExt is not empty.
+	BackupExt string
+}
+
+// AtomicFile is a file written to a temporary file in the same directory, and renamed to the file named
+// by Commit, so that readers see either the previous content or the new content in whole, even after
+// a crash or power loss.
+//
+// The embedded File is the temporary file, Close without Commit discards it.
+type AtomicFile struct {
+	*os.File
+
+	name   string
+	perm   os.FileMode
+	opts   atomicFile
+	closed bool
+}
+
+// NewAtomicFile creates a temporary file in the directory of name, to be renamed to name by Commit.
+// The permission of the file is that of the previous file if exists, or perm, not masked by umask.
+// If the dir does not exist, NewAtomicFile creates it with 0755 (before umask).
+func NewAtomicFile(name string, perm os.FileMode, opts ...AtomicFileOption) (*AtomicFile, error) {
+	dir, file := filepath.Split(name)
+	if dir == "" {
+		dir = "."
+	}
+	f := &AtomicFile{name: name, perm: perm}
+	f.opts.ApplyOptions(opts...)
+	tempFile, err := TempAll(dir, fmt.Sprintf(".%s.*.rename", file))
+	if err != nil {
+		return nil, err
+	}
+	f.File = tempFile
+	return f, nil
+}
+
+// Commit syncs the temporary file to stable storage, backs up the previous file if BackupExt is set,
+// renames the temporary file to the file named, and syncs the directory.
+// The AtomicFile is closed after Commit, successfully or not.
+func (f *AtomicFile) Commit() (err error) {
+	if f.closed {
+		return os.ErrClosed
+	}
+	f.closed = true
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.File.Name())
+		}
+	}()
+
+	perm := f.perm
+	if fi, err := os.Stat(f.name); err == nil {
+		perm = fi.Mode().Perm()
+	}
+	if err := f.File.Chmod(perm); err != nil && runtime.GOOS != "windows" {
+		_ = f.File.Close()
+		return err
+	}
+	if err := f.File.Sync(); err != nil {
+		_ = f.File.Close()
+		return err
+	}
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+	if f.opts.BackupExt != "" {
+		if err := backupFile(f.name, f.name+f.opts.BackupExt, perm); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", f.name, err)
+		}
+	}
+	if err := os.Rename(f.File.Name(), f.name); err != nil {
+		return err
+	}
+	return SyncDir(filepath.Dir(f.name))
+}
+
+// Close discards the temporary file if not committed, it's a nop after Commit.
+func (f *AtomicFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	return errors.Join(f.File.Close(), os.Remove(f.File.Name()))
+}
+
+// WriteFileAtomic writes data to the file named atomically, see AtomicFile.
+// The permission of the file is that of the previous file if exists, or perm, not masked by umask.
+// If the dir does not exist, WriteFileAtomic creates it with 0755 (before umask).
+func WriteFileAtomic(name string, data []byte, perm os.FileMode, opts ...AtomicFileOption) error {
+	f, err := NewAtomicFile(name, perm, opts...)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	return f.Commit()
+}
+
+// SyncDir commits the entries of dir, such as files created, renamed or removed, to stable storage.
+// It's a nop on windows, which does not support syncing directories.
+func SyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// backupFile makes backup the same file as name by a hard link, or a copy of it if links are not supported.
+func backupFile(name, backup string, perm os.FileMode) error {
+	if _, err := os.Stat(name); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(name, backup); err == nil {
+		return nil
+	}
+	return CopyFile(backup, name, DefaultFlagCreateTruncate, perm)
+}
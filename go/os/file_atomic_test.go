@@ -0,0 +1,75 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	os_ "github.com/searKing/golang/go/os"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "sub", "file.txt")
+	if err := os_.WriteFileAtomic(name, []byte("v1"), 0o600); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(name, 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os_.WriteFileAtomic(name, []byte("v2"), 0o600, os_.WithAtomicFileBackupExt(".bak")); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	for file, want := range map[string]string{name: "v2", name + ".bak": "v1"} {
+		if got, err := os.ReadFile(file); err != nil || string(got) != want {
+			t.Errorf("ReadFile(%s) = %q, %v; want %q", file, got, err, want)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		// the permission of the previous file is kept
+		if fi, err := os.Stat(name); err != nil || fi.Mode().Perm() != 0o640 {
+			t.Errorf("Stat(%s) = %v, %v; want perm %v", name, fi.Mode().Perm(), err, os.FileMode(0o640))
+		}
+	}
+	entries, err := os.ReadDir(filepath.Dir(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("temp files left: %v", entries)
+	}
+}
+
+func TestAtomicFileAbort(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(name, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os_.NewAtomicFile(name, 0o644)
+	if err != nil {
+		t.Fatalf("NewAtomicFile: %v", err)
+	}
+	if _, err := f.WriteString("v2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := f.Commit(); err == nil {
+		t.Errorf("Commit after Close: want error")
+	}
+	if got, err := os.ReadFile(name); err != nil || string(got) != "v1" {
+		t.Errorf("ReadFile = %q, %v; want %q", got, err, "v1")
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("ReadDir = %v, %v; want the file only", entries, err)
+	}
+}
@@ -5,15 +5,42 @@
 package os
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/searKing/golang/go/crypto/md5"
 	filepath_ "github.com/searKing/golang/go/path/filepath"
+	"github.com/searKing/golang/go/sync/filelock"
+	time_ "github.com/searKing/golang/go/time"
+)
+
+// CacheEvictPolicy represents a way to choose cache files to evict, see CacheFile.MaxTotalSize.
+type CacheEvictPolicy int
+
+const (
+	// CacheEvictLRU evicts the least recently used cache files first.
+	CacheEvictLRU CacheEvictPolicy = iota
+	// CacheEvictLFU evicts the least frequently used cache files first, the least recently used
+	// first if used as frequently.
+	CacheEvictLFU
+)
+
+const (
+	// cacheLockName is the name of the lock file under BucketRootDir, guarding cache files across processes.
+	cacheLockName = ".lock"
+	// cacheIdleTimeout is the time after which temporary files, and cache meta files without cache files
+	// being written, are supposed to be left by a crash, and removed by Scrub.
+	cacheIdleTimeout = time.Hour
 )
 
 // CacheFile is a package cache(Eventual consistency), backed by a file system directory tree.
@@ -37,8 +64,47 @@ type CacheFile struct {
 	// see: https://github.com/golang/go/issues/13516
 	BucketKeyFunc func(key string) string
 
-	CacheMetaExt      string        // the file name extension used by path. ".cache" if empty
-	CacheExpiredAfter time.Duration // Cache file expiration time, lazy expire cache files base on cache URL modification time
+	CacheMetaExt string // the file name extension used by path. ".cache" if empty
+	// Cache file expiration time, lazy expire cache files base on cache URL modification time,
+	// that is the time the cache file is put or hit last.
+	// take effects if only CacheExpiredAfter is bigger than 0.
+	CacheExpiredAfter time.Duration
+
+	// max total size of cache files, cache meta files excluded.
+	// Cache files are evicted by EvictPolicy once a cache file is put, or by Scrub,
+	// until the total size is not bigger than MaxTotalSize.
+	// take effects if only MaxTotalSize is bigger than 0.
+	MaxTotalSize int64
+	// EvictPolicy chooses cache files to evict by MaxTotalSize, CacheEvictLRU if not set.
+	EvictPolicy CacheEvictPolicy
+
+	// counters of this CacheFile, see Stats
+	hits        int64 `option:"-"`
+	misses      int64 `option:"-"`
+	evictions   int64 `option:"-"`
+	expirations int64 `option:"-"`
+	orphans     int64 `option:"-"`
+}
+
+// CacheStats is the statistics of a CacheFile.
+type CacheStats struct {
+	Entries   int   // number of cache files put
+	TotalSize int64 // total size of cache files, cache meta files excluded
+
+	// counters of the CacheFile since created, those of other processes excluded
+	Hits        int64 // Get of cache files put
+	Misses      int64 // Get of cache files not put, or expired
+	Evictions   int64 // cache files evicted by MaxTotalSize
+	Expirations int64 // cache files removed as expired
+	Orphans     int64 // cache files or cache meta files removed by Scrub, as the other of the pair is missing
+}
+
+// cacheMeta is the content of a cache meta file, in JSON.
+// A cache meta file of the key only is recognized too, as written by former versions.
+type cacheMeta struct {
+	Key      string    `json:"key"`
+	Hits     int64     `json:"hits,omitempty"`      // times the cache file is hit
+	ExpireAt time.Time `json:"expire_at,omitempty"` // expiration time of the cache file, put with a TTL
 }
 
 func NewCacheFile(opts ...CacheFileOption) *CacheFile {
@@ -69,10 +135,13 @@ func (f *CacheFile) BucketKey(name string) string {
 // Get looks up the file in the cache and returns
 // the cache name of the corresponding data file.
 func (f *CacheFile) Get(name string) (cacheFilePath, cacheMetaPath string, hit bool, err error) {
-	cacheFilePath = filepath.Join(f.BucketRootDir, f.BucketKey(name))
-	cacheMetaPath = cacheFilePath + f.CacheMetaExt
+	unlock, err := f.lock()
+	if err != nil {
+		return "", "", false, err
+	}
+	defer unlock()
 
-	cacheFilePath, cacheMetaPath, err = f.createCacheMetaIfNotExist(name, cacheFilePath+".*"+f.CacheMetaExt)
+	cacheFilePath, cacheMetaPath, meta, err := f.createCacheMetaIfNotExist(name, f.cacheMetaPathPattern(name))
 	if err != nil {
 		return "", "", false, err
 	}
@@ -85,24 +154,33 @@ func (f *CacheFile) Get(name string) (cacheFilePath, cacheMetaPath string, hit b
 	{
 		// violate cache file if it's empty
 		info, err_ := os.Stat(cacheFilePath)
-		if err_ != nil {
-			hit = false
-			return
-		}
-		if info.Size() == 0 {
-			hit = false
-			return
+		if err_ != nil || info.Size() == 0 {
+			atomic.AddInt64(&f.misses, 1)
+			return cacheFilePath, cacheMetaPath, false, nil
 		}
 	}
 
 	// STEP3 cache url not conflict, refresh ModTime of cache file and cache file's metadata
 	hit = true
-	_ = ChtimesNow(cacheMetaPath)
+	atomic.AddInt64(&f.hits, 1)
+	meta.Hits++
+	if err := writeCacheMeta(cacheMetaPath, meta, false); err != nil {
+		_ = ChtimesNow(cacheMetaPath)
+	}
 	_ = ChtimesNow(cacheFilePath)
 	return
 }
 
+// Put stores the content read from r as the cache file of name, if not hit,
+// and returns the cache name of the corresponding data file.
 func (f *CacheFile) Put(name string, r io.Reader) (cacheFilePath string, refreshed bool, err error) {
+	return f.PutWithTTL(name, r, 0)
+}
+
+// PutWithTTL is like Put, but the cache file put expires once ttl elapsed, whether hit or not,
+// besides CacheExpiredAfter.
+// take effects if only ttl is bigger than 0.
+func (f *CacheFile) PutWithTTL(name string, r io.Reader, ttl time.Duration) (cacheFilePath string, refreshed bool, err error) {
 	cacheFilePath, _, hit, err := f.Get(name)
 	if err != nil {
 		return "", false, err
@@ -111,14 +189,267 @@ func (f *CacheFile) Put(name string, r io.Reader) (cacheFilePath string, refresh
 		return cacheFilePath, false, nil
 	}
 
-	err = WriteRenameAllFrom(cacheFilePath, r)
+	// write to a temp file without the lock, as reading r may take long
+	dir, file := filepath.Split(cacheFilePath)
+	if dir == "" {
+		dir = "."
+	}
+	tempFile, err := TempAll(dir, fmt.Sprintf(".%s.*.rename", file))
 	if err != nil {
 		return "", false, fmt.Errorf("failed to create cache file: %w", err)
 	}
+	defer os.Remove(tempFile.Name()) // remove if rename failed
+	_, err = tempFile.ReadFrom(r)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create cache file: %w", err)
+	}
+
+	unlock, err := f.lock()
+	if err != nil {
+		return "", false, err
+	}
+	defer unlock()
+	// the cache meta file may be expired or evicted meanwhile
+	cacheFilePath, cacheMetaPath, meta, err := f.createCacheMetaIfNotExist(name, f.cacheMetaPathPattern(name))
+	if err != nil {
+		return "", false, err
+	}
+	if err := os.Rename(tempFile.Name(), cacheFilePath); err != nil {
+		return "", false, fmt.Errorf("failed to create cache file: %w", err)
+	}
+	if err := SyncDir(filepath.Dir(cacheFilePath)); err != nil {
+		return "", false, fmt.Errorf("failed to create cache file: %w", err)
+	}
+	meta.ExpireAt = time.Time{}
+	if ttl > 0 {
+		meta.ExpireAt = time.Now().Add(ttl)
+	}
+	if err := writeCacheMeta(cacheMetaPath, meta, true); err != nil {
+		return "", false, fmt.Errorf("failed to write cache meta: %w", err)
+	}
+	if err := f.evictLocked(cacheMetaPath); err != nil {
+		return cacheFilePath, true, err
+	}
 	return cacheFilePath, true, nil
 }
 
-func (f *CacheFile) createCacheMetaIfNotExist(key, cacheMetaPathPattern string) (cacheFilePath, cacheMetaPath string, err error) {
+// Scrub removes expired cache files, temporary files and cache meta files left by a crash, and
+// cache files or cache meta files orphaned as the other of the pair is missing, then evicts cache
+// files by MaxTotalSize.
+func (f *CacheFile) Scrub() error {
+	unlock, err := f.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(f.rootDir())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var errs []error
+	remove := func(name string) {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == cacheLockName {
+			continue
+		}
+		name := filepath.Join(f.rootDir(), e.Name())
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(e.Name(), ".") && strings.HasSuffix(e.Name(), ".rename"):
+			// temporary files of Put or of cache meta files
+			if now.Sub(info.ModTime()) > cacheIdleTimeout {
+				remove(name)
+			}
+		case strings.HasSuffix(e.Name(), f.CacheMetaExt):
+			cacheFilePath := strings.TrimSuffix(name, f.CacheMetaExt)
+			if meta, err := readCacheMeta(name); err == nil && f.expired(meta, info, now) {
+				if f.removeCache(cacheFilePath, name) == nil {
+					atomic.AddInt64(&f.expirations, 1)
+				}
+				continue
+			}
+			// cache meta files of cache files being written are created first
+			if _, err := os.Stat(cacheFilePath); os.IsNotExist(err) && now.Sub(info.ModTime()) > cacheIdleTimeout {
+				remove(name)
+				atomic.AddInt64(&f.orphans, 1)
+			}
+		case cacheFileRe.MatchString(e.Name()):
+			if _, err := os.Stat(name + f.CacheMetaExt); os.IsNotExist(err) {
+				remove(name)
+				atomic.AddInt64(&f.orphans, 1)
+			}
+		}
+	}
+	errs = append(errs, f.evictLocked(""))
+	return errors.Join(errs...)
+}
+
+// ScrubUntil calls Scrub every period until ctx is done, see Scrub.
+func (f *CacheFile) ScrubUntil(ctx context.Context, period time.Duration) {
+	time_.NonSlidingUntil(ctx, func(ctx context.Context) { _ = f.Scrub() }, period)
+}
+
+// Stats returns the statistics of f, cache files put by other processes included.
+func (f *CacheFile) Stats() (CacheStats, error) {
+	stats := CacheStats{
+		Hits:        atomic.LoadInt64(&f.hits),
+		Misses:      atomic.LoadInt64(&f.misses),
+		Evictions:   atomic.LoadInt64(&f.evictions),
+		Expirations: atomic.LoadInt64(&f.expirations),
+		Orphans:     atomic.LoadInt64(&f.orphans),
+	}
+	entries, err := f.listCaches()
+	if err != nil {
+		return stats, err
+	}
+	stats.Entries = len(entries)
+	for _, e := range entries {
+		stats.TotalSize += e.size
+	}
+	return stats, nil
+}
+
+// cacheFileRe matches names of cache files, as bucket key + "." + seq.
+var cacheFileRe = regexp.MustCompile(`\.\d+$`)
+
+// cacheEntry is a pair of cache file and cache meta file, with the cache file put.
+type cacheEntry struct {
+	cacheFilePath string
+	cacheMetaPath string
+	size          int64
+	accessTime    time.Time // modification time of the cache meta file, refreshed on hit
+	hits          int64
+}
+
+// listCaches returns pairs of cache files and cache meta files, with cache files put.
+func (f *CacheFile) listCaches() ([]cacheEntry, error) {
+	metas, err := filepath.Glob(filepath.Join(f.rootDir(), "*"+f.CacheMetaExt))
+	if err != nil {
+		return nil, err
+	}
+	var entries []cacheEntry
+	for _, cacheMetaPath := range metas {
+		cacheFilePath := strings.TrimSuffix(cacheMetaPath, f.CacheMetaExt)
+		metaInfo, err := os.Stat(cacheMetaPath)
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(cacheFilePath)
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		e := cacheEntry{cacheFilePath: cacheFilePath, cacheMetaPath: cacheMetaPath, size: info.Size(), accessTime: metaInfo.ModTime()}
+		if meta, err := readCacheMeta(cacheMetaPath); err == nil {
+			e.hits = meta.Hits
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// evictLocked evicts cache files by EvictPolicy until the total size is not bigger than MaxTotalSize,
+// the cache file of keepMetaPath is evicted last, but never.
+func (f *CacheFile) evictLocked(keepMetaPath string) error {
+	if f.MaxTotalSize <= 0 {
+		return nil
+	}
+	entries, err := f.listCaches()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	if total <= f.MaxTotalSize {
+		return nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if f.EvictPolicy == CacheEvictLFU && entries[i].hits != entries[j].hits {
+			return entries[i].hits < entries[j].hits
+		}
+		return entries[i].accessTime.Before(entries[j].accessTime)
+	})
+	var errs []error
+	for _, e := range entries {
+		if total <= f.MaxTotalSize {
+			break
+		}
+		if e.cacheMetaPath == keepMetaPath {
+			continue
+		}
+		if err := f.removeCache(e.cacheFilePath, e.cacheMetaPath); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= e.size
+		atomic.AddInt64(&f.evictions, 1)
+	}
+	return errors.Join(errs...)
+}
+
+// removeCache removes the cache file and the cache meta file.
+func (f *CacheFile) removeCache(cacheFilePath, cacheMetaPath string) error {
+	_ = os.Truncate(cacheMetaPath, 0) // make cache meta file not available
+	// clear cache file if exists, atomic operation as cache meta file is locked.
+	if err := os.Remove(cacheFilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// invalidate cache meta file
+	if err := os.Remove(cacheMetaPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (f *CacheFile) expired(meta cacheMeta, metaInfo os.FileInfo, now time.Time) bool {
+	if f.CacheExpiredAfter > 0 && now.Sub(metaInfo.ModTime()) > f.CacheExpiredAfter {
+		return true
+	}
+	return !meta.ExpireAt.IsZero() && now.After(meta.ExpireAt)
+}
+
+// cacheMetaPathPattern returns the pattern of cache meta files of name, as bucket key + ".*" + CacheMetaExt.
+func (f *CacheFile) cacheMetaPathPattern(name string) string {
+	return filepath.Join(f.BucketRootDir, f.BucketKey(name)) + ".*" + f.CacheMetaExt
+}
+
+func (f *CacheFile) rootDir() string {
+	if f.BucketRootDir == "" {
+		return "."
+	}
+	return f.BucketRootDir
+}
+
+// lock locks the cache across processes, by the lock file under BucketRootDir.
+func (f *CacheFile) lock() (unlock func(), err error) {
+	if err := MakeAll(f.rootDir()); err != nil {
+		return nil, err
+	}
+	unlock, err = filelock.MutexAt(filepath.Join(f.rootDir(), cacheLockName)).Lock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock cache: %w", err)
+	}
+	return unlock, nil
+}
+
+func (f *CacheFile) createCacheMetaIfNotExist(key, cacheMetaPathPattern string) (cacheFilePath, cacheMetaPath string, meta cacheMeta, err error) {
 	var hitMeta bool
 
 	now := time.Now()
@@ -127,14 +458,14 @@ func (f *CacheFile) createCacheMetaIfNotExist(key, cacheMetaPathPattern string)
 		cacheMetaPath = path
 		cacheFilePath = strings.TrimSuffix(cacheMetaPath, f.CacheMetaExt)
 		info, err := os.Stat(cacheMetaPath)
-		if err == nil {
-			// violate cache file if cache expired
-			expired := now.Sub(info.ModTime()) > f.CacheExpiredAfter
-			if expired {
-				_ = os.Truncate(cacheMetaPath, 0) // make cache meta file not available
-				_ = os.Remove(cacheFilePath)      // clear cache file if exists, atomic operation as cache meta file is locked.
-				_ = os.Remove(cacheMetaPath)      // invalidate cache meta file
-				return nil
+		if err != nil {
+			return nil
+		}
+		m, err := readCacheMeta(cacheMetaPath)
+		// violate cache file if cache expired
+		if err == nil && f.expired(m, info, now) {
+			if f.removeCache(cacheFilePath, cacheMetaPath) == nil {
+				atomic.AddInt64(&f.expirations, 1)
 			}
 		}
 		return nil
@@ -145,9 +476,10 @@ func (f *CacheFile) createCacheMetaIfNotExist(key, cacheMetaPathPattern string)
 		cacheMetaPath = path
 		cacheFilePath = strings.TrimSuffix(cacheMetaPath, f.CacheMetaExt)
 		// verify whether if cache key in cache file is match
-		keyInCache, _ := os.ReadFile(cacheMetaPath)
-		if string(keyInCache) == key {
+		m, err := readCacheMeta(cacheMetaPath)
+		if err == nil && m.Key == key {
 			hitMeta = true
+			meta = m
 			return filepath.SkipAll
 		}
 		// cache key conflict, continue search cache file list
@@ -161,20 +493,52 @@ func (f *CacheFile) createCacheMetaIfNotExist(key, cacheMetaPathPattern string)
 	// foo.txt.* -> foo.txt.[0,1,2,...], which exists and seq is max
 	nextFile, _, err := NextFile(cacheMetaPathPattern, 0)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to open next cache meta: %w", err)
+		return "", "", cacheMeta{}, fmt.Errorf("failed to open next cache meta: %w", err)
 	}
 	defer nextFile.Close()
 	// STEP3 cache url not conflict, refresh ModTime of cache file and cache file's metadata
 	cacheMetaPath = nextFile.Name()
 	cacheFilePath = strings.TrimSuffix(cacheMetaPath, f.CacheMetaExt)
-	_ = os.Remove(cacheFilePath)       // clear cache file if exists, atomic operation as cache meta file is locked.
-	_, err = nextFile.WriteString(key) // make cache meta file available
+	_ = os.Remove(cacheFilePath) // clear cache file if exists, atomic operation as cache meta file is locked.
+	meta = cacheMeta{Key: key}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return "", "", cacheMeta{}, err
+	}
+	_, err = nextFile.Write(data) // make cache meta file available
 	if err != nil {
-		return "", "", fmt.Errorf("failed to write next cache meta: %w", err)
+		return "", "", cacheMeta{}, fmt.Errorf("failed to write next cache meta: %w", err)
 	}
 	return
 }
 
+// readCacheMeta reads the cache meta file, which may be of the key only, as written by former versions.
+func readCacheMeta(name string) (cacheMeta, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return cacheMeta{}, err
+	}
+	var meta cacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil || meta.Key == "" {
+		return cacheMeta{Key: string(data)}, nil
+	}
+	return meta, nil
+}
+
+// writeCacheMeta replaces the meta file by rename, synced to stable storage if durable.
+// Metas updated by hits are not synced, as losing a hit count costs nothing but the fsyncs
+// of every Get under the lock.
+func writeCacheMeta(name string, meta cacheMeta, durable bool) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if !durable {
+		return WriteRenameAll(name, data)
+	}
+	return WriteFileAtomic(name, data, DefaultPermissionFile)
+}
+
 func MD5CacheKey(s string) string {
 	// Special CASE 1: filename-as-part-of-a-query-string
 	// http://foo.com?url=http://bar.com/kitty.jpg&filename=kitty.jpg
@@ -0,0 +1,147 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	os_ "github.com/searKing/golang/go/os"
+)
+
+func TestCacheFileEvict(t *testing.T) {
+	for _, tt := range []struct {
+		policy  os_.CacheEvictPolicy
+		evicted string
+	}{
+		{os_.CacheEvictLRU, "a"}, // a is hit less recently
+		{os_.CacheEvictLFU, "b"}, // b is hit less frequently
+	} {
+		f := os_.NewCacheFile(os_.WithCacheFileBucketRootDir(t.TempDir()),
+			os_.WithCacheFileMaxTotalSize(10),
+			os_.WithCacheFileEvictPolicy(tt.policy))
+		put := func(name string) {
+			t.Helper()
+			if _, refreshed, err := f.Put(name, strings.NewReader("1234")); err != nil || !refreshed {
+				t.Fatalf("Put(%q) = %t, %v", name, refreshed, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		get := func(name string) bool {
+			t.Helper()
+			_, _, hit, err := f.Get(name)
+			if err != nil {
+				t.Fatalf("Get(%q): %v", name, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+			return hit
+		}
+
+		put("a")
+		put("b")
+		get("a")
+		get("a")
+		get("b")
+		put("c")
+		for _, name := range []string{"a", "b", "c"} {
+			if hit := get(name); hit == (name == tt.evicted) {
+				t.Errorf("policy %d: Get(%q) hit = %t", tt.policy, name, hit)
+			}
+		}
+		stats, err := f.Stats()
+		if err != nil {
+			t.Fatalf("Stats: %v", err)
+		}
+		if stats.Entries != 2 || stats.TotalSize != 8 || stats.Evictions != 1 {
+			t.Errorf("policy %d: Stats = %+v", tt.policy, stats)
+		}
+	}
+}
+
+func TestCacheFileTTL(t *testing.T) {
+	f := os_.NewCacheFile(os_.WithCacheFileBucketRootDir(t.TempDir()))
+	if _, _, err := f.PutWithTTL("a", strings.NewReader("a"), 50*time.Millisecond); err != nil {
+		t.Fatalf("PutWithTTL: %v", err)
+	}
+	if _, _, hit, err := f.Get("a"); err != nil || !hit {
+		t.Fatalf("Get = %t, %v; want hit", hit, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	// expires whether hit or not
+	if _, _, hit, err := f.Get("a"); err != nil || hit {
+		t.Fatalf("Get = %t, %v; want expired", hit, err)
+	}
+	stats, err := f.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Expirations != 1 || stats.Entries != 0 {
+		t.Errorf("Stats = %+v", stats)
+	}
+}
+
+func TestCacheFileScrub(t *testing.T) {
+	dir := t.TempDir()
+	f := os_.NewCacheFile(os_.WithCacheFileBucketRootDir(dir), os_.WithCacheFileBucketKeyFunc(func(key string) string {
+		return key
+	}))
+	if _, _, err := f.Put("kept", strings.NewReader("kept")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	files := map[string]bool{ // removed or not
+		"orphan.0":              true,  // cache file without cache meta file
+		"orphan.1.cache":        true,  // cache meta file without cache file, left by a crash
+		"writing.0.cache":       false, // cache meta file without cache file, being written
+		".writing.0.123.rename": false, // temp file being written
+		".crashed.0.123.rename": true,  // temp file left by a crash
+		"legacy.0.cache":        false, // cache meta file of the key only
+		"legacy.0":              false,
+		"unknown.txt":           false,
+		"kept.0.cache":          false,
+		"kept.0":                false,
+	}
+	for name := range files {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		data := name
+		if name == "legacy.0.cache" {
+			data = "legacy"
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if name == "orphan.1.cache" || name == ".crashed.0.123.rename" {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := f.Scrub(); err != nil {
+		t.Fatalf("Scrub: %v", err)
+	}
+	for name, removed := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) != removed {
+			t.Errorf("%s removed = %t, want %t", name, !removed, removed)
+		}
+	}
+	if _, _, hit, err := f.Get("legacy"); err != nil || !hit {
+		t.Errorf("Get(legacy) = %t, %v; want hit", hit, err)
+	}
+	stats, err := f.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Orphans != 2 || stats.Entries != 2 {
+		t.Errorf("Stats = %+v", stats)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Compressor compresses files, such as files rotated by RotateFile.
+// Compressors of other formats, such as zstd by github.com/klauspost/compress/zstd,
+// can be plugged in by implementing Compressor.
+type Compressor interface {
+	// Ext returns the extension appended to the name of a file compressed, such as ".gz".
+	Ext() string
+	// NewWriter returns a WriteCloser compressing data written to it into w.
+	// Close flushes data compressed, but does not close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+// GzipCompressor is a Compressor in gzip format.
+type GzipCompressor struct {
+	// Level is the compression level, gzip.DefaultCompression if 0.
+	Level int
+}
+
+var _ Compressor = GzipCompressor{}
+
+func (c GzipCompressor) Ext() string { return ".gz" }
+
+func (c GzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	level := c.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+// compressTempExt is appended to the name of a file being compressed, until it is complete.
+const compressTempExt = ".tmp"
+
+// CompressFile compresses file name into name+c.Ext(), and removes file name then.
+// The modification time of name is kept by the file compressed.
+//
+// CompressFile is crash-safe: data is compressed into a temporary file name+c.Ext()+".tmp",
+// which is renamed to name+c.Ext() once synced, before file name is removed.
+// If name+c.Ext() exists already, which is left by a crash before removing file name,
+// file name is removed only.
+func CompressFile(c Compressor, name string) (err error) {
+	dst := name + c.Ext()
+	if _, err := os.Stat(dst); err == nil {
+		return os.Remove(name)
+	}
+
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	fi, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp := dst + compressTempExt
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(tmp)
+		}
+	}()
+	w, err := c.NewWriter(out)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, src); err != nil {
+		return errors.Join(err, w.Close())
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	if err = out.Sync(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	if err = os.Chtimes(tmp, fi.ModTime(), fi.ModTime()); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, dst); err != nil {
+		return err
+	}
+	// errors are ignored as some platforms do not support syncing directories.
+	_ = SyncDir(filepath.Dir(dst))
+	return os.Remove(name)
+}
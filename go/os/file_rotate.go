@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -73,13 +75,27 @@ type RotateFile struct {
 	// max age of a log file before it gets purged from the file system.
 	// Remove rotated logs older than duration. The age is only checked if the file is
 	// to be rotated.
+	// The age is counted from the end of the rotate interval, recovered from the time
+	// formatted in the file name, or from the modification time if the name can't be parsed.
 	// take effects if only MaxAge is bigger than 0.
 	MaxAge time.Duration
 
-	// Rotate files are rotated MaxCount times before being removed
+	// Rotate files are rotated MaxCount times before being removed, that is the max number
+	// of backups kept, besides the file being written.
 	// take effects if only MaxCount is bigger than 0.
 	MaxCount int
 
+	// max total size of files matched by RotateFileGlob, including the file being written.
+	// The oldest rotated files are removed until the total size is not bigger than MaxTotalSize.
+	// take effects if only MaxTotalSize is bigger than 0.
+	MaxTotalSize int64
+
+	// Compressor compresses rotated files into name+Compressor.Ext() on a separate goroutine,
+	// see CompressFile. Files compressed are recognized when sequence numbers are recovered,
+	// and are counted by MaxAge, MaxCount and MaxTotalSize as rotated files.
+	// take effects if only Compressor is not nil.
+	Compressor Compressor
+
 	// Force File Rotate when start up
 	ForceNewFileOnStartup bool
 
@@ -91,11 +107,31 @@ type RotateFile struct {
 	// name means file path rotated
 	PostRotateHandler func(name string)
 
+	// RotatedHandler called after each rotation, done by RotateFile, or done externally and detected,
+	// see ExternalRotate.
+	// oldName means file path written before rotation, or the copy of it in RotateModeCopyTruncate;
+	// newName means file path written after rotation, the same as oldName if rotated externally.
+	RotatedHandler func(oldName, newName string)
+
+	// ExternalRotate leaves rotation to an external tool, such as logrotate, so that RotateFile never
+	// rotates the file by RotateInterval or RotateSize itself.
+	// Rotations done externally are detected on each write, or by Reopen, in any case:
+	// the file renamed or removed, as by logrotate's create, is reopened by its name;
+	// the file truncated, as by logrotate's copytruncate, is written from its new end.
+	ExternalRotate bool
+
+	// Clock tells the time to name rotate files and to expire them by MaxAge.
+	// time_.RealClock if nil.
+	Clock time_.Clock
+
 	cleaning               atomic.Bool
+	cleanPending           atomic.Bool            // clean requested while cleaning
+	protectedPath          atomic.Pointer[string] // file being written, never cleaned
 	mu                     sync.Mutex
 	writingSeq             int // file rotated by size limit meet
 	writingFilePath        string
 	writingFile            *os.File
+	writingSize            int64  // size of writingFile known, to detect truncation
 	writingFilePathRotated string // rotated file path, for copytruncate
 }
 
@@ -136,7 +172,9 @@ func (f *RotateFile) Write(b []byte) (n int, err error) {
 		return 0, fmt.Errorf("acquite rotated file :%w", err)
 	}
 
-	return out.Write(b)
+	n, err = out.Write(b)
+	f.writingSize += int64(n)
+	return n, err
 }
 
 // WriteString is like Write, but writes the contents of string s rather than
@@ -184,7 +222,7 @@ func (f *RotateFile) Close() error {
 	if f.writingFile == nil { // maybe file is closed or not open
 		return nil
 	}
-	defer f.serializedClean("")
+	defer f.serializedClean()
 
 	defer func() { f.writingFile = nil }()
 	return f.writingFile.Close()
@@ -208,29 +246,75 @@ func (f *RotateFile) Rotate(forceRotate bool) error {
 	return nil
 }
 
+// Reopen closes the file being written, and opens it by its name again, created if removed.
+// It's a nop if no file is opened yet.
+//
+// This method can be used in conjunction with a signal handler, such as a SIGHUP sent by
+// the postrotate script of logrotate, see OnSignal.
+func (f *RotateFile) Reopen() error {
+	if err := f.checkValid("reopen"); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.writingFile == nil {
+		return nil
+	}
+
+	prev, prevErr := f.writingFile.Stat()
+	prevSize := f.writingSize
+	_ = f.writingFile.Close()
+	f.writingFile = nil
+	if err := f.makeUsingFileReadyLocked(); err != nil {
+		return err
+	}
+	// makeUsingFileReadyLocked has no file to compare with
+	if cur, err := f.writingFile.Stat(); prevErr == nil && err == nil {
+		if !os.SameFile(prev, cur) || cur.Size() < prevSize {
+			f.rotatedLocked(f.writingFilePath, f.writingFilePath)
+		}
+	}
+	return nil
+}
+
+// OnSignal reopens the file, see Reopen, so that RotateFile is an OnSignalHandler of
+// package github.com/searKing/golang/go/os/signal, to reopen the file on SIGHUP for example:
+//
+//	stop := signal_.RegisterOnSignal(f, syscall.SIGHUP)
+//	defer stop()
+func (f *RotateFile) OnSignal(os.Signal) {
+	_ = f.Reopen()
+}
+
 func (f *RotateFile) filePathByRotateTime() string {
 	// create a new file name using the regular time layout
-	return f.FilePathPrefix + time_.TruncateByLocation(time.Now(), f.RotateInterval).Format(f.FilePathRotateLayout)
+	return f.FilePathPrefix + time_.TruncateByLocation(time_.ClockOrDefault(f.Clock).Now(), f.RotateInterval).Format(f.FilePathRotateLayout)
 }
 
 func (f *RotateFile) filePathByRotateSize() (name string, seq int) {
 	// instead of just using the regular time layout,
 	// we create a new file name using names such as "foo.1", "foo.2", "foo.3", etc
-	return nextSeqFileName(f.filePathByRotateTime(), f.writingSeq)
+	return f.nextSeqFileName(f.filePathByRotateTime(), f.writingSeq)
 }
 
 func (f *RotateFile) filePathByRotate(forceRotate bool) (name string, seq int, byTime, bySize bool) {
 	// name using the regular time layout, without seq
 	name = f.filePathByRotateTime()
+	if f.ExternalRotate && f.writingFilePath != "" {
+		return f.writingFilePath, f.writingSeq, false, false
+	}
 	// startup
 	if f.writingFilePath == "" {
+		if f.ExternalRotate {
+			return name, 0, true, false
+		}
 		if f.ForceNewFileOnStartup {
 			// instead of just using the regular time layout,
 			// we create a new file name using names such as "foo", "foo.1", "foo.2", "foo.3", etc
-			name, seq = nextSeqFileName(name, f.writingSeq)
+			name, seq = f.nextSeqFileName(name, f.writingSeq)
 			return name, seq, false, true
 		}
-		name, seq = maxSeqFileName(name)
+		name, seq = f.maxSeqFileName(name)
 		return name, seq, true, false
 	}
 
@@ -240,10 +324,10 @@ func (f *RotateFile) filePathByRotate(forceRotate bool) (name string, seq int, b
 		if forceRotate {
 			// instead of just using the regular time layout,
 			// we create a new file name using names such as "foo", "foo.1", "foo.2", "foo.3", etc
-			name, seq = nextSeqFileName(name, 0)
+			name, seq = f.nextSeqFileName(name, 0)
 			return name, seq, true, false
 		}
-		name, seq = maxSeqFileName(name)
+		name, seq = f.maxSeqFileName(name)
 		return name, seq, true, false
 	}
 
@@ -262,7 +346,7 @@ func (f *RotateFile) filePathByRotate(forceRotate bool) (name string, seq int, b
 	if forceRotate || (err == nil && (f.RotateSize > 0 && usingFileInfo.Size() > f.RotateSize)) {
 		// instead of just using the regular time layout,
 		// we create a new file name using names such as "foo", "foo.1", "foo.2", "foo.3", etc
-		name, seq = nextSeqFileName(name, f.writingSeq)
+		name, seq = f.nextSeqFileName(name, f.writingSeq)
 		return name, seq, false, true
 	}
 	name = f.writingFilePath
@@ -272,12 +356,18 @@ func (f *RotateFile) filePathByRotate(forceRotate bool) (name string, seq int, b
 
 func (f *RotateFile) makeUsingFileReadyLocked() (err error) {
 	// using file exist, close this file if not ready to use
+	var rotated bool
 	if f.writingFile != nil {
 		diskFileInfo, err := os.Stat(f.writingFile.Name())
 		if err == nil {
 			usingFileInfo, statErr := f.writingFile.Stat()
 			if statErr == nil {
 				if os.SameFile(diskFileInfo, usingFileInfo) {
+					// truncated externally, written from the new end as opened with O_APPEND
+					if diskFileInfo.Size() < f.writingSize {
+						f.writingSize = diskFileInfo.Size()
+						f.rotatedLocked(f.writingFilePath, f.writingFilePath)
+					}
 					return nil
 				}
 			}
@@ -285,6 +375,7 @@ func (f *RotateFile) makeUsingFileReadyLocked() (err error) {
 		// file not exist or not the same file, recreate the file and file link
 		_ = f.writingFile.Close()
 		f.writingFile = nil
+		rotated = true
 	}
 
 	// using file not exist, recreate the file and file link
@@ -305,8 +396,11 @@ func (f *RotateFile) makeUsingFileReadyLocked() (err error) {
 		}
 	}
 	f.writingFile = file
+	f.writingSize = fileSize(file)
+	if rotated {
+		f.rotatedLocked(f.writingFilePath, f.writingFilePath)
+	}
 	return nil
-
 }
 
 func (f *RotateFile) getWriterLocked(bailOnRotateFail, forceRotate bool) (out io.Writer, err error) {
@@ -345,17 +439,39 @@ func (f *RotateFile) getWriterLocked(bailOnRotateFail, forceRotate bool) (out io
 		_ = f.writingFile.Close()
 		f.writingFile = nil
 	}
+	oldName := f.writingFilePath
+	if f.RotateMode == RotateModeCopyTruncate && oldName != "" {
+		oldName = newName
+	}
 	f.writingFilePathRotated = newName
 	f.writingFile = newFile
+	f.writingSize = fileSize(newFile)
 	f.writingFilePath = newFile.Name()
 	f.writingSeq = newSeq
 	if f.PostRotateHandler != nil {
 		f.PostRotateHandler(f.writingFilePath)
 	}
+	if oldName != "" {
+		f.rotatedLocked(oldName, f.writingFilePath)
+	}
 
 	return f.writingFile, nil
 }
 
+func (f *RotateFile) rotatedLocked(oldName, newName string) {
+	if f.RotatedHandler != nil {
+		f.RotatedHandler(oldName, newName)
+	}
+}
+
+func fileSize(file *os.File) int64 {
+	fi, err := file.Stat()
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
+}
+
 // file may not be nil if err is nil
 func (f *RotateFile) rotateLocked(newName string) (_ *os.File, err error) {
 	// if we got here, then we need to create a file
@@ -369,6 +485,8 @@ func (f *RotateFile) rotateLocked(newName string) (_ *os.File, err error) {
 		writeName = newName
 		needRotate = newName != f.writingFilePath
 	}
+	// protect the file to write before it is created
+	f.protectedPath.Store(&writeName)
 	if needRotate {
 		var err error
 		var mode string
@@ -403,6 +521,11 @@ func (f *RotateFile) rotateLocked(newName string) (_ *os.File, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if needRotate {
+		// persist the files created or renamed by rotation, errors are ignored as some platforms
+		// do not support syncing directories.
+		_ = SyncDir(filepath.Dir(writeName))
+	}
 	defer func() {
 		if err != nil {
 			_ = file.Close()
@@ -416,31 +539,50 @@ func (f *RotateFile) rotateLocked(newName string) (_ *os.File, err error) {
 		}
 	}
 	// unlink files on a separate goroutine
-	go f.serializedClean(writeName)
+	go f.serializedClean()
 
 	return file, nil
 }
 
 // unlink files
 // expect run on a separate goroutine
-func (f *RotateFile) serializedClean(protectedPath string) error {
-	// running already, ignore duplicate clean
-	if !f.cleaning.CompareAndSwap(false, true) {
-		return nil
+func (f *RotateFile) serializedClean() error {
+	// a clean requested while cleaning is run again by the goroutine cleaning once it is done
+	f.cleanPending.Store(true)
+	var errs []error
+	for f.cleanPending.Load() && f.cleaning.CompareAndSwap(false, true) {
+		f.cleanPending.Store(false)
+		errs = append(errs, f.clean())
+		f.cleaning.Store(false)
+	}
+	return errors.Join(errs...)
+}
+
+// protected reports whether name is the file being written, which is never cleaned.
+// The file being written may change while cleaning, so check it just before cleaning name.
+func (f *RotateFile) protected(name string) bool {
+	p := f.protectedPath.Load()
+	return p != nil && *p == name
+}
+
+// clean compresses rotated files, and removes files by MaxAge, MaxCount and MaxTotalSize,
+// except the file being written.
+func (f *RotateFile) clean() error {
+	var errs []error
+	if f.Compressor != nil {
+		errs = append(errs, f.compressRotated())
 	}
-	defer f.cleaning.Store(false)
 
-	now := time.Now()
+	now := time_.ClockOrDefault(f.Clock).Now()
 
 	// find old files
 	var filesNotExpired []string
 	filesExpired, err := filepath_.GlobFunc(f.FilePathPrefix+f.RotateFileGlob, func(name string) bool {
-		if protectedPath != "" && name == protectedPath {
+		if f.protected(name) {
 			return false
 		}
-
-		fi, err := os.Stat(name)
-		if err != nil {
+		// being compressed
+		if strings.HasSuffix(name, compressTempExt) {
 			return false
 		}
 
@@ -448,50 +590,177 @@ func (f *RotateFile) serializedClean(protectedPath string) error {
 		if err != nil {
 			return false
 		}
-		if f.MaxAge <= 0 {
-			filesNotExpired = append(filesNotExpired, name)
+		if fl.Mode()&os.ModeSymlink == os.ModeSymlink {
 			return false
 		}
-
-		if now.Sub(fi.ModTime()) < f.MaxAge {
+		if f.MaxAge <= 0 {
 			filesNotExpired = append(filesNotExpired, name)
 			return false
 		}
 
-		if fl.Mode()&os.ModeSymlink == os.ModeSymlink {
+		if now.Sub(f.rotatedTime(name, fl)) < f.MaxAge {
+			filesNotExpired = append(filesNotExpired, name)
 			return false
 		}
 		return true
 	})
 	if err != nil {
-		return err
+		return errors.Join(append(errs, err)...)
 	}
 
 	var filesExceedMaxCount []string
+	if f.MaxCount > 0 || f.MaxTotalSize > 0 {
+		sort.Sort(rotateFileSlice(filesNotExpired))
+	}
 	if f.MaxCount > 0 && len(filesNotExpired) > 0 {
 		removeCount := len(filesNotExpired) - f.MaxCount
 		if removeCount < 0 {
 			removeCount = 0
 		}
-		sort.Sort(rotateFileSlice(filesNotExpired))
 		filesExceedMaxCount = filesNotExpired[:removeCount]
+		filesNotExpired = filesNotExpired[removeCount:]
 	}
-	var errs []error
-	for _, path := range filesExpired {
-		err = os.Remove(path)
-		if err != nil {
-			errs = append(errs, err)
+
+	var filesExceedMaxTotalSize []string
+	if f.MaxTotalSize > 0 {
+		var total int64
+		sizes := make([]int64, len(filesNotExpired))
+		for i, path := range filesNotExpired {
+			if fi, err := os.Stat(path); err == nil {
+				sizes[i] = fi.Size()
+				total += sizes[i]
+			}
+		}
+		if p := f.protectedPath.Load(); p != nil {
+			if fi, err := os.Stat(*p); err == nil {
+				total += fi.Size()
+			}
+		}
+		// remove the oldest first
+		for i, path := range filesNotExpired {
+			if total <= f.MaxTotalSize {
+				break
+			}
+			filesExceedMaxTotalSize = append(filesExceedMaxTotalSize, path)
+			total -= sizes[i]
 		}
 	}
-	for _, path := range filesExceedMaxCount {
-		err = os.Remove(path)
-		if err != nil {
+
+	for _, files := range [][]string{filesExpired, filesExceedMaxCount, filesExceedMaxTotalSize} {
+		for _, path := range files {
+			if f.protected(path) {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compressRotated compresses files rotated, except the file being written,
+// and removes temporary files left by a crash while compressing.
+func (f *RotateFile) compressRotated() error {
+	ext := f.Compressor.Ext()
+	names, err := filepath.Glob(f.FilePathPrefix + f.RotateFileGlob)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, name := range names {
+		if strings.HasSuffix(name, ext) {
+			continue
+		}
+		// cleans are serialized, so that a temporary file is left by a crash
+		if strings.HasSuffix(name, ext+compressTempExt) {
+			if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		fl, err := os.Lstat(name)
+		if err != nil || !fl.Mode().IsRegular() || f.protected(name) {
+			continue
+		}
+		if err := CompressFile(f.Compressor, name); err != nil && !os.IsNotExist(err) {
 			errs = append(errs, err)
 		}
 	}
 	return errors.Join(errs...)
 }
 
+// rotatedTime returns the time a rotated file is written until, that is the end of the rotate
+// interval formatted in the file name, or the modification time if the name can't be parsed.
+func (f *RotateFile) rotatedTime(name string, fi os.FileInfo) time.Time {
+	layout := time_.LayoutTimeToSimilarStrftime(f.FilePathRotateLayout)
+	value := strings.TrimPrefix(name, f.FilePathPrefix)
+	if f.Compressor != nil {
+		value = strings.TrimSuffix(value, f.Compressor.Ext())
+	}
+	for {
+		if t, err := time_.StrptimeInLocation(layout, value, time.Local); err == nil {
+			if f.RotateInterval > 0 {
+				t = t.Add(f.RotateInterval)
+			}
+			return t
+		}
+		// foo.txt.1 -> foo.txt, trim seq appended by rotation by size
+		i := strings.LastIndexByte(value, '.')
+		if i < 0 {
+			return fi.ModTime()
+		}
+		if _, err := strconv.Atoi(value[i+1:]); err != nil {
+			return fi.ModTime()
+		}
+		value = value[:i]
+	}
+}
+
+// compressedExists reports whether name is compressed already, as name+Compressor.Ext().
+func (f *RotateFile) compressedExists(name string) bool {
+	if f.Compressor == nil {
+		return false
+	}
+	_, err := os.Stat(name + f.Compressor.Ext())
+	return err == nil
+}
+
+// nextSeqFileName is like nextSeqFileName, but skips seqs taken by files compressed.
+func (f *RotateFile) nextSeqFileName(name string, seq int) (string, int) {
+	for {
+		next, nextSeq := nextSeqFileName(name, seq)
+		// no file name available
+		if next == name && nextSeq != 0 {
+			return next, nextSeq
+		}
+		if !f.compressedExists(next) {
+			return next, nextSeq
+		}
+		// remove the empty file created for the seq taken
+		_ = os.Remove(next)
+		seq = nextSeq + 1
+	}
+}
+
+// maxSeqFileName is like maxSeqFileName, but recognizes files compressed, so that
+// a file is never written again once compressed.
+func (f *RotateFile) maxSeqFileName(name string) (string, int) {
+	plainName, plainSeq := maxSeqFileName(name)
+	if f.Compressor == nil {
+		return plainName, plainSeq
+	}
+	// foo.txt.*.gz -> foo.txt.[1,2,...].gz, which exists and seq is max
+	_, compressedSeq, _ := MaxSeq(name + ".*" + f.Compressor.Ext())
+	if compressedSeq == 0 && !f.compressedExists(name) {
+		return plainName, plainSeq
+	}
+	if plainSeq > compressedSeq {
+		return plainName, plainSeq
+	}
+	return f.nextSeqFileName(name, compressedSeq+1)
+}
+
 // checkValid checks whether f is valid for use.
 // If not, it returns an appropriate error, perhaps incorporating the operation name op.
 func (f *RotateFile) checkValid(op string) error {
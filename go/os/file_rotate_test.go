@@ -0,0 +1,363 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	os_ "github.com/searKing/golang/go/os"
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestRotateFileMaxAgeByFileName(t *testing.T) {
+	dir := t.TempDir()
+	f := os_.NewRotateFile("2006-01-02.log")
+	f.FilePathPrefix = filepath.Join(dir, "app.")
+	f.MaxAge = 7 * 24 * time.Hour
+
+	// modification times are fresh, ages are recovered from the names
+	expired := []string{"app.2001-08-23.log", "app.2001-08-23.log.1"}
+	kept := []string{"app." + time.Now().AddDate(0, 0, -3).Format("2006-01-02") + ".log"}
+	for _, name := range append(expired, kept...) {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := f.WriteString("hello\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for _, name := range expired {
+		for {
+			if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expired file %s is not removed", name)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	for _, name := range kept {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("file %s is removed: %v", name, err)
+		}
+	}
+}
+
+func TestRotateFileWithClock(t *testing.T) {
+	dir := t.TempDir()
+	clock := time_.NewFakeClock(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local))
+	f := os_.NewRotateFile("2006-01-02T15.log")
+	f.FilePathPrefix = filepath.Join(dir, "app.")
+	f.RotateInterval = time.Hour
+	f.MaxAge = 90 * time.Minute
+	f.Clock = clock
+	defer f.Close()
+
+	for _, s := range []string{"10", "11", "13"} {
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatalf("WriteString: %v", err)
+		}
+		if s == "11" {
+			clock.Step(2 * time.Hour)
+		} else {
+			clock.Step(time.Hour)
+		}
+	}
+
+	for _, s := range []string{"11", "13"} {
+		data, err := os.ReadFile(filepath.Join(dir, "app.2026-03-01T"+s+".log"))
+		if err != nil || string(data) != s {
+			t.Fatalf("rotate file of hour %s = %q, %v; want %q", s, data, err, s)
+		}
+	}
+
+	// the file of hour 10 is 2h old at 13:00, the file of hour 11 is 1h old
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "app.2026-03-01T10.log")); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expired file of hour 10 is not removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// waitFor polls cond until it holds, or fails the test after a while.
+func waitFor(t *testing.T, msg string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+func readGzip(t *testing.T, name string) string {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip %s: %v", name, err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("gzip %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestRotateFileCompress(t *testing.T) {
+	dir := t.TempDir()
+	clock := time_.NewFakeClock(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local))
+	f := os_.NewRotateFile("2006-01-02T15.log")
+	f.FilePathPrefix = filepath.Join(dir, "app.")
+	f.RotateInterval = time.Hour
+	f.Compressor = os_.GzipCompressor{}
+	f.Clock = clock
+	defer f.Close()
+
+	for _, s := range []string{"10", "11", "12"} {
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatalf("WriteString: %v", err)
+		}
+		clock.Step(time.Hour)
+	}
+	if _, err := f.WriteString("13"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+
+	for _, s := range []string{"10", "11", "12"} {
+		name := filepath.Join(dir, "app.2026-03-01T"+s+".log")
+		waitFor(t, "rotated file "+name+" is not compressed", func() bool {
+			return exists(name+".gz") && !exists(name)
+		})
+		if got := readGzip(t, name+".gz"); got != s {
+			t.Fatalf("compressed file of hour %s = %q, want %q", s, got, s)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "app.2026-03-01T13.log")); err != nil || string(data) != "13" {
+		t.Fatalf("file being written = %q, %v; want %q", data, err, "13")
+	}
+}
+
+func TestRotateFileCompressRecovery(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+	writeGzip := func(name, data string) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, _ = w.Write([]byte(data))
+		_ = w.Close()
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// compressed by the last run, seqs 0 and 1 of hour 10 are taken
+	writeGzip("app.2026-03-01T10.log.gz", "a")
+	writeGzip("app.2026-03-01T10.log.1.gz", "b")
+	// crash after renaming before removing the file compressed
+	writeGzip("app.2026-03-01T09.log.gz", "c")
+	writeFile("app.2026-03-01T09.log", "c")
+	// crash while compressing
+	writeFile("app.2026-03-01T08.log", "d")
+	writeFile("app.2026-03-01T08.log.gz.tmp", "partial")
+
+	f := os_.NewRotateFile("2006-01-02T15.log")
+	f.FilePathPrefix = filepath.Join(dir, "app.")
+	f.RotateInterval = time.Hour
+	f.Compressor = os_.GzipCompressor{}
+	f.Clock = time_.NewFakeClock(now)
+	if _, err := f.WriteString("e"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "app.2026-03-01T10.log.2")); err != nil || string(data) != "e" {
+		t.Fatalf("file being written = %q, %v; want the next seq of files compressed", data, err)
+	}
+	waitFor(t, "files left by a crash are not recovered", func() bool {
+		return !exists(filepath.Join(dir, "app.2026-03-01T09.log")) &&
+			!exists(filepath.Join(dir, "app.2026-03-01T08.log")) &&
+			!exists(filepath.Join(dir, "app.2026-03-01T08.log.gz.tmp"))
+	})
+	for name, want := range map[string]string{
+		"app.2026-03-01T10.log.gz":   "a",
+		"app.2026-03-01T10.log.1.gz": "b",
+		"app.2026-03-01T09.log.gz":   "c",
+		"app.2026-03-01T08.log.gz":   "d",
+	} {
+		if got := readGzip(t, filepath.Join(dir, name)); got != want {
+			t.Fatalf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRotateFileMaxTotalSize(t *testing.T) {
+	dir := t.TempDir()
+	f := os_.NewRotateFile("2006-01-02.log")
+	f.FilePathPrefix = filepath.Join(dir, "app.")
+	f.MaxTotalSize = 25
+
+	// the oldest files are removed first
+	now := time.Now()
+	for i, name := range []string{"app.2001-08-21.log", "app.2001-08-22.log", "app.2001-08-23.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), bytes.Repeat([]byte{'x'}, 10), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(filepath.Join(dir, name), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := f.WriteString("hello\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// 6 bytes being written, 10 bytes of the newest, 16 bytes in total
+	waitFor(t, "files exceeding MaxTotalSize are not removed", func() bool {
+		return !exists(filepath.Join(dir, "app.2001-08-21.log")) && !exists(filepath.Join(dir, "app.2001-08-22.log"))
+	})
+	if !exists(filepath.Join(dir, "app.2001-08-23.log")) {
+		t.Fatal("the newest rotated file is removed")
+	}
+}
+
+func TestRotateFileExternalRotate(t *testing.T) {
+	dir := t.TempDir()
+	clock := time_.NewFakeClock(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local))
+	f := os_.NewRotateFile("")
+	f.FilePathPrefix = filepath.Join(dir, "app.log")
+	f.RotateInterval = time.Hour
+	f.RotateSize = 1
+	f.ExternalRotate = true
+	f.Clock = clock
+	var rotated []string
+	f.RotatedHandler = func(oldName, newName string) {
+		rotated = append(rotated, filepath.Base(oldName)+"->"+filepath.Base(newName))
+	}
+	defer f.Close()
+
+	write := func(s string) {
+		t.Helper()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatalf("WriteString: %v", err)
+		}
+	}
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// never rotated by time or size itself
+	write("a")
+	clock.Step(2 * time.Hour)
+	write("b")
+
+	// renamed, as logrotate's create, and reopened on the next write
+	if err := os.Rename(filepath.Join(dir, "app.log"), filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	write("c")
+
+	// truncated, as logrotate's copytruncate, and written from the new end
+	if err := os.Truncate(filepath.Join(dir, "app.log"), 0); err != nil {
+		t.Fatal(err)
+	}
+	write("d")
+
+	// renamed, and reopened by Reopen, as on SIGHUP
+	if err := os.Rename(filepath.Join(dir, "app.log"), filepath.Join(dir, "app.log.2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+	f.OnSignal(os.Interrupt) // reopens the same file
+	write("e")
+
+	for name, want := range map[string]string{"app.log.1": "ab", "app.log.2": "d", "app.log": "e"} {
+		if got := read(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if want := []string{"app.log->app.log", "app.log->app.log", "app.log->app.log"}; !slices.Equal(rotated, want) {
+		t.Errorf("rotated %q, want %q", rotated, want)
+	}
+}
+
+func TestRotateFileRotatedHandler(t *testing.T) {
+	for _, mode := range []os_.RotateMode{os_.RotateModeNew, os_.RotateModeCopyTruncate} {
+		dir := t.TempDir()
+		clock := time_.NewFakeClock(time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local))
+		f := os_.NewRotateFile("2006-01-02T15.log")
+		f.FilePathPrefix = filepath.Join(dir, "app.")
+		f.RotateInterval = time.Hour
+		f.RotateMode = mode
+		f.Clock = clock
+		var rotated []string
+		f.RotatedHandler = func(oldName, newName string) {
+			rotated = append(rotated, filepath.Base(oldName)+"->"+filepath.Base(newName))
+		}
+
+		for range 2 {
+			if _, err := f.WriteString("x"); err != nil {
+				t.Fatalf("WriteString: %v", err)
+			}
+			clock.Step(time.Hour)
+		}
+		_ = f.Close()
+
+		want := []string{"app.2026-03-01T10.log->app.2026-03-01T11.log"}
+		if mode == os_.RotateModeCopyTruncate {
+			// the copy is rotated, the file is written in place
+			want = []string{"app.2026-03-01T11.log->app.2026-03-01T10.log"}
+		}
+		if !slices.Equal(rotated, want) {
+			t.Errorf("mode %d: rotated %q, want %q", mode, rotated, want)
+		}
+	}
+}
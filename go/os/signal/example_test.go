@@ -82,3 +82,23 @@ func ExampleNotify() {
 	// Output:
 	// Got signal: interrupt
 }
+
+func ExampleRegisterOnSignal() {
+	received := make(chan os.Signal, 1)
+	stop := signal_.RegisterOnSignal(signal_.OnSignalHandlerFunc(func(signum os.Signal) {
+		// reopen log files here, as on SIGHUP sent by logrotate, see os.RotateFile.OnSignal
+		received <- signum
+	}), syscall.SIGHUP)
+	defer stop()
+
+	_ = syscall.Kill(syscall.Getpid(), syscall.SIGHUP)
+	select {
+	case s := <-received:
+		fmt.Printf("Got signal: %s\n", s)
+	case <-time.After(time.Minute):
+		fmt.Println("time overseed")
+	}
+
+	// Output:
+	// Got signal: hangup
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -56,6 +58,35 @@ func Notify(c chan<- os.Signal, sigs ...os.Signal) {
 	setSig(sigs...)
 }
 
+// RegisterOnSignal calls h.OnSignal on a separate goroutine for each of sigs received, until stop is called.
+// If no signals are provided, all incoming signals will be relayed to h, as signal.Notify.
+// Unlike Notify, no stacktrace is dumped on sigs, as they are expected, such as SIGHUP sent by logrotate.
+func RegisterOnSignal(h OnSignalHandler, sigs ...os.Signal) (stop func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-c:
+				h.OnSignal(s)
+				if runtime.GOOS == "windows" {
+					signal.Notify(c, s)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+	}
+}
+
 // DumpSignalTo redirects log to fd, -1 if not set; muted if < 0.
 func DumpSignalTo(fd int) {
 	dumpSignalTo(fd)
@@ -0,0 +1,270 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWatchOverflow is reported by Watcher.Events if events are dropped by the platform, as too many
+// events happened before read; the files watched need to be rescanned.
+var ErrWatchOverflow = errors.New("watch: event queue overflow")
+
+// ErrWatcherClosed is returned by a Watcher closed.
+var ErrWatcherClosed = errors.New("watch: watcher closed")
+
+// WatchOp describes a set of file operations.
+type WatchOp uint32
+
+const (
+	WatchCreate WatchOp = 1 << iota // a file is created, or moved into the tree watched
+	WatchWrite                      // a file is written
+	WatchRemove                     // a file is removed
+	WatchRename                     // a file is renamed, or moved out of the tree watched
+	WatchChmod                      // the attributes of a file are changed
+)
+
+func (op WatchOp) String() string {
+	var ops []string
+	for _, o := range []struct {
+		op   WatchOp
+		name string
+	}{
+		{WatchCreate, "CREATE"},
+		{WatchWrite, "WRITE"},
+		{WatchRemove, "REMOVE"},
+		{WatchRename, "RENAME"},
+		{WatchChmod, "CHMOD"},
+	} {
+		if op&o.op != 0 {
+			ops = append(ops, o.name)
+		}
+	}
+	if len(ops) == 0 {
+		return "[no events]"
+	}
+	return strings.Join(ops, "|")
+}
+
+// Has reports whether op contains any of h.
+func (op WatchOp) Has(h WatchOp) bool { return op&h != 0 }
+
+// WatchEvent represents a file operation, or operations coalesced, see Watcher.
+type WatchEvent struct {
+	Name    string  // path of the file, joined with the path watched
+	OldName string  // path of the file moved from, if the file is renamed within the tree watched
+	Op      WatchOp // operations happened
+}
+
+func (e WatchEvent) String() string {
+	if e.OldName != "" {
+		return e.Op.String() + " " + e.OldName + " -> " + e.Name
+	}
+	return e.Op.String() + " " + e.Name
+}
+
+//go:generate go-option -type "watcher"
+type watcher struct {
+	// Recursive watches the subdirectories of the directories added, including those created later.
+	Recursive bool
+
+	// Debounce coalesces the events of the same file, which are emitted once no more events of the
+	// file happen in Debounce, or 10 times of Debounce passed since the first one, whichever comes first.
+	// take effects if only Debounce is bigger than 0.
+	Debounce time.Duration
+
+	// PollInterval is the interval to scan the files watched if polling, 1s if not set.
+	PollInterval time.Duration
+
+	// ForcePolling watches by polling even if the platform supports notifications, such as inotify on linux,
+	// for file systems which don't, such as NFS, FUSE or /proc.
+	ForcePolling bool
+}
+
+// watchBackend watches files and sends events to Watcher.
+type watchBackend interface {
+	add(name string) error
+	remove(name string) error
+	close() error
+}
+
+type watchItem struct {
+	event WatchEvent
+	err   error
+}
+
+// Watcher watches files and directories for changes, by inotify on linux, and by polling on other platforms.
+//
+// A directory watched reports the changes of the files in it, and of the subdirectories too if Recursive.
+// Renames within the tree watched are reported as one event with OldName set.
+// To watch a file replaced by renames, such as by editors or WriteFileAtomic, watch the directory of it.
+type Watcher struct {
+	opts    watcher
+	backend watchBackend
+
+	items chan watchItem
+	done  chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewWatcher returns a Watcher watching nothing, call Add to watch files.
+func NewWatcher(opts ...WatcherOption) (*Watcher, error) {
+	w := &Watcher{
+		items: make(chan watchItem, 128),
+		done:  make(chan struct{}),
+	}
+	w.opts.ApplyOptions(opts...)
+	if w.opts.PollInterval <= 0 {
+		w.opts.PollInterval = time.Second
+	}
+	if !w.opts.ForcePolling {
+		backend, err := newNotifyWatchBackend(w)
+		if err == nil {
+			w.backend = backend
+			return w, nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return nil, err
+		}
+	}
+	w.backend = newPollWatchBackend(w)
+	return w, nil
+}
+
+// Add starts watching the file or directory named, which must exist.
+func (w *Watcher) Add(name string) error {
+	select {
+	case <-w.done:
+		return ErrWatcherClosed
+	default:
+	}
+	return w.backend.add(filepath.Clean(name))
+}
+
+// Remove stops watching the file or directory named, added by Add.
+func (w *Watcher) Remove(name string) error {
+	select {
+	case <-w.done:
+		return ErrWatcherClosed
+	default:
+	}
+	return w.backend.remove(filepath.Clean(name))
+}
+
+// Close stops watching, and ends Events.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.closeErr = w.backend.close()
+	})
+	return w.closeErr
+}
+
+// Events returns the events, coalesced if Debounce is set, and errors met, such as ErrWatchOverflow,
+// until ctx is done or the Watcher is closed.
+// Events are consumed by the iteration, so only one iteration is expected at a time.
+func (w *Watcher) Events(ctx context.Context) iter.Seq2[WatchEvent, error] {
+	return func(yield func(WatchEvent, error) bool) {
+		if w.opts.Debounce <= 0 {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-w.done:
+					return
+				case item := <-w.items:
+					if !yield(item.event, item.err) {
+						return
+					}
+				}
+			}
+		}
+
+		type pendingEvent struct {
+			event       WatchEvent
+			first, last time.Time
+		}
+		var pendings []*pendingEvent // in order of the first event
+		byName := make(map[string]*pendingEvent)
+		deadline := func(p *pendingEvent) time.Time {
+			if d := p.first.Add(10 * w.opts.Debounce); d.Before(p.last.Add(w.opts.Debounce)) {
+				return d
+			}
+			return p.last.Add(w.opts.Debounce)
+		}
+		timer := time.NewTimer(w.opts.Debounce)
+		defer timer.Stop()
+		for {
+			if len(pendings) > 0 {
+				next := deadline(pendings[0])
+				for _, p := range pendings[1:] {
+					if d := deadline(p); d.Before(next) {
+						next = d
+					}
+				}
+				timer.Reset(time.Until(next))
+			} else {
+				timer.Stop()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.done:
+				return
+			case item := <-w.items:
+				if item.err != nil {
+					if !yield(WatchEvent{}, item.err) {
+						return
+					}
+					continue
+				}
+				now := time.Now()
+				if p, ok := byName[item.event.Name]; ok {
+					p.event.Op |= item.event.Op
+					if item.event.OldName != "" {
+						p.event.OldName = item.event.OldName
+					}
+					p.last = now
+					continue
+				}
+				p := &pendingEvent{event: item.event, first: now, last: now}
+				pendings = append(pendings, p)
+				byName[item.event.Name] = p
+			case now := <-timer.C:
+				var left []*pendingEvent
+				for _, p := range pendings {
+					if deadline(p).After(now) {
+						left = append(left, p)
+						continue
+					}
+					delete(byName, p.event.Name)
+					if !yield(p.event, nil) {
+						return
+					}
+				}
+				pendings = left
+			}
+		}
+	}
+}
+
+// send delivers an event or an error to Events, it returns false if the Watcher is closed.
+func (w *Watcher) send(item watchItem) bool {
+	select {
+	case w.items <- item:
+		return true
+	case <-w.done:
+		return false
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package os
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyWatchMask = unix.IN_MODIFY | unix.IN_ATTRIB | unix.IN_CREATE | unix.IN_DELETE |
+	unix.IN_DELETE_SELF | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_MOVE_SELF
+
+// inotifyMovedTimeout is how long an IN_MOVED_FROM waits for its IN_MOVED_TO, which may be read
+// by the next read, before reported as moved out of the tree watched.
+const inotifyMovedTimeout = 10 * time.Millisecond
+
+// inotifyWatchBackend watches files by inotify, see https://man7.org/linux/man-pages/man7/inotify.7.html
+type inotifyWatchBackend struct {
+	w    *Watcher
+	fd   int
+	file *os.File // fd, read by the netpoller
+
+	mu    sync.Mutex
+	paths map[int]string // path by watch descriptor
+	wds   map[string]int // watch descriptor by path
+	roots map[string]bool
+
+	moved *inotifyMovedFrom // IN_MOVED_FROM waiting for its IN_MOVED_TO, accessed by run only
+
+	stopped chan struct{}
+}
+
+type inotifyMovedFrom struct {
+	path   string
+	cookie uint32
+	isDir  bool
+}
+
+func newNotifyWatchBackend(w *Watcher) (watchBackend, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	b := &inotifyWatchBackend{
+		w:       w,
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		paths:   make(map[int]string),
+		wds:     make(map[string]int),
+		roots:   make(map[string]bool),
+		stopped: make(chan struct{}),
+	}
+	go b.run()
+	return b, nil
+}
+
+func (b *inotifyWatchBackend) add(name string) error {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if fi.IsDir() && b.w.opts.Recursive {
+		_, err = b.addTreeLocked(name)
+	} else {
+		err = b.addWatchLocked(name)
+	}
+	if err != nil {
+		return err
+	}
+	b.roots[name] = true
+	return nil
+}
+
+func (b *inotifyWatchBackend) remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.roots[name] {
+		return os.ErrNotExist
+	}
+	delete(b.roots, name)
+	return b.removeTreeLocked(name)
+}
+
+func (b *inotifyWatchBackend) close() error {
+	// unblocks the Read in run
+	err := b.file.Close()
+	<-b.stopped
+	return err
+}
+
+func (b *inotifyWatchBackend) addWatchLocked(path string) error {
+	wd, err := unix.InotifyAddWatch(b.fd, path, inotifyWatchMask)
+	if err != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: path, Err: err}
+	}
+	b.paths[wd] = path
+	b.wds[path] = wd
+	return nil
+}
+
+// addTreeLocked watches the directory root and the subdirectories, and returns the paths found in it.
+func (b *inotifyWatchBackend) addTreeLocked(root string) (found []string, err error) {
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// removed meanwhile
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if path != root {
+			found = append(found, path)
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := b.addWatchLocked(path); err != nil && !errors.Is(err, unix.ENOENT) {
+			return err
+		}
+		return nil
+	})
+	return found, err
+}
+
+// removeTreeLocked stops watching root, and the subdirectories not watched as roots.
+func (b *inotifyWatchBackend) removeTreeLocked(root string) error {
+	var errs []error
+	for path, wd := range b.wds {
+		if path != root && (!strings.HasPrefix(path, root+string(filepath.Separator)) || b.roots[path]) {
+			continue
+		}
+		delete(b.wds, path)
+		delete(b.paths, wd)
+		// EINVAL if the watch is removed by the kernel already, as the file is removed
+		if _, err := unix.InotifyRmWatch(b.fd, uint32(wd)); err != nil && !errors.Is(err, unix.EINVAL) {
+			errs = append(errs, &os.PathError{Op: "inotify_rm_watch", Path: path, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// renameTreeLocked updates the paths of the directory renamed and the subdirectories watched.
+func (b *inotifyWatchBackend) renameTreeLocked(oldRoot, newRoot string) {
+	for path, wd := range b.wds {
+		if path != oldRoot && !strings.HasPrefix(path, oldRoot+string(filepath.Separator)) {
+			continue
+		}
+		newPath := newRoot + path[len(oldRoot):]
+		delete(b.wds, path)
+		b.wds[newPath] = wd
+		b.paths[wd] = newPath
+	}
+}
+
+func (b *inotifyWatchBackend) run() {
+	defer close(b.stopped)
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		// the IN_MOVED_TO of a IN_MOVED_FROM pending may be read by the next read, wait for it a moment
+		var deadline time.Time
+		if b.moved != nil {
+			deadline = time.Now().Add(inotifyMovedTimeout)
+		}
+		_ = b.file.SetReadDeadline(deadline)
+		n, err := b.file.Read(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			if !b.flushMoved() {
+				return
+			}
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				b.w.send(watchItem{err: err})
+			}
+			return
+		}
+		if !b.handle(buf[:n]) {
+			return
+		}
+	}
+}
+
+// flushMoved sends the IN_MOVED_FROM pending, if any, as a file moved out of the tree watched,
+// since no IN_MOVED_TO follows. It returns false if the Watcher is closed.
+func (b *inotifyWatchBackend) flushMoved() bool {
+	m := b.moved
+	if m == nil {
+		return true
+	}
+	b.moved = nil
+	if m.isDir {
+		b.mu.Lock()
+		_ = b.removeTreeLocked(m.path)
+		b.mu.Unlock()
+	}
+	return b.w.send(watchItem{event: WatchEvent{Name: m.path, Op: WatchRename}})
+}
+
+// handle sends the events read, it returns false if the Watcher is closed.
+// An IN_MOVED_FROM at the end of buf is left pending, as its IN_MOVED_TO may be read next.
+func (b *inotifyWatchBackend) handle(buf []byte) bool {
+	emit := func(path string, op WatchOp) bool {
+		return b.w.send(watchItem{event: WatchEvent{Name: path, Op: op}})
+	}
+
+	for len(buf) >= unix.SizeofInotifyEvent {
+		wd := int(int32(binary.NativeEndian.Uint32(buf[0:])))
+		mask := binary.NativeEndian.Uint32(buf[4:])
+		cookie := binary.NativeEndian.Uint32(buf[8:])
+		nameLen := int(binary.NativeEndian.Uint32(buf[12:]))
+		if len(buf) < unix.SizeofInotifyEvent+nameLen {
+			break
+		}
+		name := strings.TrimRight(string(buf[unix.SizeofInotifyEvent:unix.SizeofInotifyEvent+nameLen]), "\x00")
+		buf = buf[unix.SizeofInotifyEvent+nameLen:]
+
+		if mask&unix.IN_Q_OVERFLOW != 0 {
+			if !b.flushMoved() || !b.w.send(watchItem{err: ErrWatchOverflow}) {
+				return false
+			}
+			continue
+		}
+
+		b.mu.Lock()
+		dir, ok := b.paths[wd]
+		if ok && mask&unix.IN_IGNORED != 0 {
+			// removed by the kernel, as the file is removed, or the file system is unmounted
+			delete(b.paths, wd)
+			if b.wds[dir] == wd {
+				delete(b.wds, dir)
+			}
+		}
+		root := b.roots[dir]
+		b.mu.Unlock()
+		if !ok || mask&unix.IN_IGNORED != 0 {
+			continue
+		}
+		path := dir
+		if name != "" {
+			path = filepath.Join(dir, name)
+		}
+		isDir := mask&unix.IN_ISDIR != 0
+
+		if mask&unix.IN_MOVED_TO != 0 && b.moved != nil && b.moved.cookie == cookie {
+			oldPath := b.moved.path
+			b.moved = nil
+			if isDir {
+				b.mu.Lock()
+				b.renameTreeLocked(oldPath, path)
+				b.mu.Unlock()
+			}
+			if !b.w.send(watchItem{event: WatchEvent{Name: path, OldName: oldPath, Op: WatchRename}}) {
+				return false
+			}
+			continue
+		}
+		if !b.flushMoved() {
+			return false
+		}
+
+		sent := true
+		switch {
+		case mask&unix.IN_MOVED_FROM != 0:
+			b.moved = &inotifyMovedFrom{path: path, cookie: cookie, isDir: isDir}
+		case mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+			sent = emit(path, WatchCreate)
+			if sent && isDir && b.w.opts.Recursive {
+				// files may be created before the directory is watched
+				b.mu.Lock()
+				found, err := b.addTreeLocked(path)
+				b.mu.Unlock()
+				if err != nil {
+					sent = b.w.send(watchItem{err: fmt.Errorf("watch %s: %w", path, err)})
+				}
+				for _, p := range found {
+					if !sent {
+						break
+					}
+					sent = emit(p, WatchCreate)
+				}
+			}
+		case mask&unix.IN_DELETE != 0:
+			sent = emit(path, WatchRemove)
+		case mask&unix.IN_MODIFY != 0:
+			sent = emit(path, WatchWrite)
+		case mask&unix.IN_ATTRIB != 0:
+			sent = emit(path, WatchChmod)
+		case mask&unix.IN_DELETE_SELF != 0:
+			// reported by the parent directory too, if watched
+			if root {
+				sent = emit(path, WatchRemove)
+			}
+		case mask&unix.IN_MOVE_SELF != 0:
+			if root {
+				sent = emit(path, WatchRename)
+			}
+		}
+		if !sent {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package os
+
+import "errors"
+
+// newNotifyWatchBackend is not supported yet, Watcher watches by polling instead.
+func newNotifyWatchBackend(w *Watcher) (watchBackend, error) {
+	return nil, errors.ErrUnsupported
+}
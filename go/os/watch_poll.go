@@ -0,0 +1,203 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// pollWatchBackend watches files by scanning them every PollInterval, and diffing the snapshots.
+type pollWatchBackend struct {
+	w *Watcher
+
+	mu        sync.Mutex
+	snapshots map[string]map[string]os.FileInfo // snapshot of files by path, by root watched
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+func newPollWatchBackend(w *Watcher) *pollWatchBackend {
+	b := &pollWatchBackend{
+		w:         w,
+		snapshots: make(map[string]map[string]os.FileInfo),
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+func (b *pollWatchBackend) add(name string) error {
+	snapshot, err := b.scan(name)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.snapshots[name]; !ok {
+		b.snapshots[name] = snapshot
+	}
+	return nil
+}
+
+func (b *pollWatchBackend) remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.snapshots[name]; !ok {
+		return os.ErrNotExist
+	}
+	delete(b.snapshots, name)
+	return nil
+}
+
+func (b *pollWatchBackend) close() error {
+	b.stopOnce.Do(func() { close(b.stop) })
+	<-b.stopped
+	return nil
+}
+
+func (b *pollWatchBackend) run() {
+	defer close(b.stopped)
+	ticker := time.NewTicker(b.w.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		}
+		b.mu.Lock()
+		roots := make([]string, 0, len(b.snapshots))
+		for root := range b.snapshots {
+			roots = append(roots, root)
+		}
+		b.mu.Unlock()
+		sort.Strings(roots)
+
+		for _, root := range roots {
+			snapshot, err := b.scan(root)
+			if err != nil && !os.IsNotExist(err) {
+				if !b.w.send(watchItem{err: err}) {
+					return
+				}
+				continue
+			}
+			b.mu.Lock()
+			last, ok := b.snapshots[root]
+			if ok {
+				b.snapshots[root] = snapshot
+			}
+			b.mu.Unlock()
+			if !ok {
+				continue // removed meanwhile
+			}
+			for _, event := range diffWatchSnapshots(last, snapshot) {
+				if !b.w.send(watchItem{event: event}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// scan returns the snapshot of the file named, with the files in it if a directory, and in the subdirectories
+// too if Recursive.
+func (b *pollWatchBackend) scan(root string) (map[string]os.FileInfo, error) {
+	fi, err := os.Lstat(root)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := map[string]os.FileInfo{root: fi}
+	if !fi.IsDir() {
+		return snapshot, nil
+	}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// removed meanwhile
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		snapshot[path] = fi
+		if d.IsDir() && !b.w.opts.Recursive {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return snapshot, err
+}
+
+// diffWatchSnapshots returns the events which turn last into current, in order of names.
+// A file removed and another created as the same file are reported as a rename.
+func diffWatchSnapshots(last, current map[string]os.FileInfo) []WatchEvent {
+	var created, removed []string
+	var events []WatchEvent
+	for name, fi := range current {
+		lastFi, ok := last[name]
+		if !ok {
+			created = append(created, name)
+			continue
+		}
+		var op WatchOp
+		if !fi.IsDir() && (fi.Size() != lastFi.Size() || !fi.ModTime().Equal(lastFi.ModTime())) {
+			op |= WatchWrite
+		}
+		if fi.Mode() != lastFi.Mode() {
+			op |= WatchChmod
+		}
+		if op != 0 {
+			events = append(events, WatchEvent{Name: name, Op: op})
+		}
+	}
+	for name := range last {
+		if _, ok := current[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(created)
+	sort.Strings(removed)
+
+	renamed := make(map[string]bool)
+	for _, name := range created {
+		var oldName string
+		for _, old := range removed {
+			if !renamed[old] && os.SameFile(last[old], current[name]) {
+				oldName = old
+				break
+			}
+		}
+		if oldName == "" {
+			events = append(events, WatchEvent{Name: name, Op: WatchCreate})
+			continue
+		}
+		renamed[oldName] = true
+		events = append(events, WatchEvent{Name: name, OldName: oldName, Op: WatchRename})
+	}
+	for _, name := range removed {
+		if !renamed[name] {
+			events = append(events, WatchEvent{Name: name, Op: WatchRemove})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
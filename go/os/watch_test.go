@@ -0,0 +1,121 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"context"
+	"iter"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	os_ "github.com/searKing/golang/go/os"
+)
+
+func newTestWatcher(t *testing.T, root string, opts ...os_.WatcherOption) func() (os_.WatchEvent, error) {
+	t.Helper()
+	w, err := os_.NewWatcher(opts...)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	if err := w.Add(root); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	next, stop := iter.Pull2(w.Events(ctx))
+	t.Cleanup(func() {
+		cancel()
+		stop()
+		if err := w.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	})
+	return func() (os_.WatchEvent, error) {
+		event, err, ok := next()
+		if !ok {
+			t.Fatalf("Events ended")
+		}
+		return event, err
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	for _, polling := range []bool{false, true} {
+		t.Run(map[bool]string{false: "notify", true: "poll"}[polling], func(t *testing.T) {
+			dir := t.TempDir()
+			next := newTestWatcher(t, dir, os_.WithWatcherRecursive(true),
+				os_.WithWatcherForcePolling(polling), os_.WithWatcherPollInterval(20*time.Millisecond))
+			// waits for the event expected, skipping the others, such as CHMOD or WRITE along with CREATE
+			expect := func(want os_.WatchEvent) {
+				t.Helper()
+				for {
+					event, err := next()
+					if err != nil {
+						t.Fatalf("Events: %v", err)
+					}
+					if event.Name == want.Name && event.OldName == want.OldName && event.Op.Has(want.Op) {
+						return
+					}
+				}
+			}
+			a := filepath.Join(dir, "a")
+			b := filepath.Join(dir, "b")
+			sub := filepath.Join(dir, "sub")
+			c := filepath.Join(sub, "c")
+
+			if err := os.WriteFile(a, nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: a, Op: os_.WatchCreate})
+			if err := os.WriteFile(a, []byte("a"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: a, Op: os_.WatchWrite})
+			if err := os.Rename(a, b); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: b, OldName: a, Op: os_.WatchRename})
+			if err := os.Mkdir(sub, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: sub, Op: os_.WatchCreate})
+			if err := os.WriteFile(c, []byte("c"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: c, Op: os_.WatchCreate})
+			if err := os.Remove(b); err != nil {
+				t.Fatal(err)
+			}
+			expect(os_.WatchEvent{Name: b, Op: os_.WatchRemove})
+		})
+	}
+}
+
+func TestWatcherDebounce(t *testing.T) {
+	dir := t.TempDir()
+	next := newTestWatcher(t, dir, os_.WithWatcherDebounce(100*time.Millisecond))
+	name := filepath.Join(dir, "config.yaml")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := f.WriteString("key: value\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := next()
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	if event.Name != name || !event.Op.Has(os_.WatchCreate) || !event.Op.Has(os_.WatchWrite) {
+		t.Errorf("Events = %v, want CREATE|WRITE %s", event, name)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adaptive
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+//go:generate go-option -type "aimd"
+type aimd struct {
+	// InitialLimit is the limit before any sample.
+	InitialLimit int
+	// MinLimit is the lower bound of the limit.
+	MinLimit int
+	// MaxLimit is the upper bound of the limit.
+	MaxLimit int
+	// BackoffRatio in (0, 1) multiplies the limit on a drop.
+	BackoffRatio float64
+	// Timeout counts a request as dropped if its rtt exceeds Timeout.
+	// take effects if only Timeout is bigger than 0.
+	Timeout time.Duration
+}
+
+// AIMDLimit is a Limit with Additive Increase and Multiplicative Decrease.
+// The limit grows by one for a request completed in time while the limit is in use,
+// and is multiplied by BackoffRatio for a request dropped or timed out.
+// See https://en.wikipedia.org/wiki/Additive_increase/multiplicative_decrease for more about AIMD.
+type AIMDLimit struct {
+	opts aimd
+
+	mu    sync.Mutex
+	limit float64
+}
+
+// NewAIMDLimit returns a new AIMDLimit.
+func NewAIMDLimit(opts ...AimdOption) *AIMDLimit {
+	l := &AIMDLimit{opts: aimd{
+		InitialLimit: 20,
+		MinLimit:     1,
+		MaxLimit:     200,
+		BackoffRatio: 0.9,
+		Timeout:      5 * time.Second,
+	}}
+	l.opts.ApplyOptions(opts...)
+	l.limit = float64(l.opts.InitialLimit)
+	return l
+}
+
+// Limit returns the current estimated limit.
+func (l *AIMDLimit) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// OnSample updates the estimated limit with a sample of a request.
+func (l *AIMDLimit) OnSample(start time.Time, rtt time.Duration, inflight int, dropped bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if dropped || (l.opts.Timeout > 0 && rtt > l.opts.Timeout) {
+		l.limit = math.Floor(l.limit * l.opts.BackoffRatio)
+	} else if inflight*2 >= int(l.limit) {
+		// grow only if the limit is in use, not if the application is the bottleneck
+		l.limit++
+	}
+	l.limit = clamp(l.limit, l.opts.MinLimit, l.opts.MaxLimit)
+}
+
+func clamp(limit float64, minLimit, maxLimit int) float64 {
+	if maxLimit > 0 && limit > float64(maxLimit) {
+		limit = float64(maxLimit)
+	}
+	if limit < float64(minLimit) {
+		limit = float64(minLimit)
+	}
+	return limit
+}
@@ -0,0 +1,86 @@
+// Code generated by "go-option -type aimd"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package adaptive
+
+import "time"
+
+// A AimdOption sets options.
+type AimdOption interface {
+	apply(*aimd)
+}
+
+// EmptyAimdOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyAimdOption struct{}
+
+func (EmptyAimdOption) apply(*aimd) {}
+
+// AimdOptionFunc wraps a function that modifies aimd into an
+// implementation of the AimdOption interface.
+type AimdOptionFunc func(*aimd)
+
+func (f AimdOptionFunc) apply(do *aimd) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *aimd) ApplyOptions(options ...AimdOption) *aimd {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withAimd sets aimd.
+func withAimd(v aimd) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		*o = v
+	})
+}
+
+// WithAimdInitialLimit sets InitialLimit in aimd.
+// InitialLimit is the limit before any sample.
+func WithAimdInitialLimit(v int) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		o.InitialLimit = v
+	})
+}
+
+// WithAimdMinLimit sets MinLimit in aimd.
+// MinLimit is the lower bound of the limit.
+func WithAimdMinLimit(v int) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		o.MinLimit = v
+	})
+}
+
+// WithAimdMaxLimit sets MaxLimit in aimd.
+// MaxLimit is the upper bound of the limit.
+func WithAimdMaxLimit(v int) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		o.MaxLimit = v
+	})
+}
+
+// WithAimdBackoffRatio sets BackoffRatio in aimd.
+// BackoffRatio in (0, 1) multiplies the limit on a drop.
+func WithAimdBackoffRatio(v float64) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		o.BackoffRatio = v
+	})
+}
+
+// WithAimdTimeout sets Timeout in aimd.
+// Timeout counts a request as dropped if its rtt exceeds Timeout.
+// take effects if only Timeout is bigger than 0.
+func WithAimdTimeout(v time.Duration) AimdOption {
+	return AimdOptionFunc(func(o *aimd) {
+		o.Timeout = v
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adaptive
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+//go:generate go-option -type "gradient2"
+type gradient2 struct {
+	// InitialLimit is the limit before any sample.
+	InitialLimit int
+	// MinLimit is the lower bound of the limit.
+	MinLimit int
+	// MaxLimit is the upper bound of the limit.
+	MaxLimit int
+	// Smoothing in (0, 1] weighs a new limit against the current one.
+	Smoothing float64
+	// RttTolerance >= 1 is the ratio of short-term rtt to long-term rtt tolerated before
+	// the limit is reduced.
+	RttTolerance float64
+	// LongWindow is the number of samples averaged by the long-term rtt.
+	LongWindow int
+	// QueueSize returns the number of requests allowed to queue for a limit.
+	// math.Sqrt is used if QueueSize is nil.
+	QueueSize func(limit float64) float64
+}
+
+// Gradient2Limit is a Limit driven by the gradient between a long-term exponential moving
+// average of rtt and the short-term rtt, which tracks a rising latency trend rather than
+// an absolute minimum latency, and so tolerates a drifting baseline.
+type Gradient2Limit struct {
+	opts gradient2
+
+	mu      sync.Mutex
+	limit   float64
+	longRtt float64 // exponential moving average of rtt in nanoseconds, 0 before the first sample
+	samples int
+}
+
+// NewGradient2Limit returns a new Gradient2Limit.
+func NewGradient2Limit(opts ...Gradient2Option) *Gradient2Limit {
+	l := &Gradient2Limit{opts: gradient2{
+		InitialLimit: 20,
+		MinLimit:     20,
+		MaxLimit:     200,
+		Smoothing:    0.2,
+		RttTolerance: 1.5,
+		LongWindow:   600,
+	}}
+	l.opts.ApplyOptions(opts...)
+	l.limit = float64(l.opts.InitialLimit)
+	return l
+}
+
+// Limit returns the current estimated limit.
+func (l *Gradient2Limit) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// OnSample updates the estimated limit with a sample of a request.
+func (l *Gradient2Limit) OnSample(start time.Time, rtt time.Duration, inflight int, dropped bool) {
+	if rtt <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	shortRtt := float64(rtt)
+	l.samples++
+	if l.longRtt == 0 {
+		l.longRtt = shortRtt
+	} else {
+		// warm up with a simple average before the window is full
+		window := min(l.samples, max(l.opts.LongWindow, 1))
+		factor := 2 / float64(window+1)
+		l.longRtt = l.longRtt*(1-factor) + shortRtt*factor
+	}
+	// If the long-term rtt has drifted far above the short-term rtt, such as after a load spike,
+	// decay it quickly, so that the limit can grow again.
+	if l.longRtt/shortRtt > 2 {
+		l.longRtt *= 0.95
+	}
+
+	// the application is the bottleneck, not the limit
+	if float64(inflight) < l.limit/2 && !dropped {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, l.opts.RttTolerance*l.longRtt/shortRtt))
+	if dropped {
+		gradient = 0.5
+	}
+	queueSize := math.Sqrt(l.limit)
+	if l.opts.QueueSize != nil {
+		queueSize = l.opts.QueueSize(l.limit)
+	}
+	newLimit := l.limit*gradient + queueSize
+	newLimit = l.limit*(1-l.opts.Smoothing) + newLimit*l.opts.Smoothing
+	l.limit = clamp(newLimit, l.opts.MinLimit, l.opts.MaxLimit)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package adaptive provides a concurrency limiter which estimates the limit from
+// observed latency and drops, instead of a static limit tuned by hand.
+// The key observation is borrowed from
+// https://github.com/Netflix/concurrency-limits
+package adaptive
+
+import (
+	"sync"
+	"time"
+)
+
+// Limit is an algorithm estimating the concurrency limit.
+type Limit interface {
+	// Limit returns the current estimated limit.
+	Limit() int
+
+	// OnSample updates the estimated limit with a sample of a request.
+	// rtt is the round trip time of the request, inflight is the number of requests in flight
+	// when the request started, dropped reports whether the request was dropped, as timed out
+	// or rejected by a downstream for overload.
+	OnSample(start time.Time, rtt time.Duration, inflight int, dropped bool)
+}
+
+// Limiter admits requests as long as the requests in flight are fewer than the estimated Limit.
+type Limiter struct {
+	limit Limit
+
+	mu       sync.Mutex
+	inflight int
+}
+
+// NewLimiter returns a new Limiter which estimates the concurrency limit by limit.
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{limit: limit}
+}
+
+// Limit returns the current estimated limit.
+func (l *Limiter) Limit() int {
+	return l.limit.Limit()
+}
+
+// Inflight returns the number of requests in flight.
+func (l *Limiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+// Acquire admits a request without blocking.
+// On success, returns a Listener which must be completed by exactly one of
+// OnSuccess, OnDropped or OnIgnore. On failure, returns false and the request
+// should be rejected.
+func (l *Limiter) Acquire() (*Listener, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inflight >= l.limit.Limit() {
+		return nil, false
+	}
+	l.inflight++
+	return &Listener{limiter: l, start: time.Now(), inflight: l.inflight}, true
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+}
+
+// Listener completes a request admitted by Limiter, sampling its latency.
+type Listener struct {
+	limiter  *Limiter
+	start    time.Time
+	inflight int
+
+	once sync.Once
+}
+
+// OnSuccess completes the request, sampling its latency to update the limit.
+func (ln *Listener) OnSuccess() {
+	ln.once.Do(func() {
+		ln.limiter.release()
+		ln.limiter.limit.OnSample(ln.start, time.Since(ln.start), ln.inflight, false)
+	})
+}
+
+// OnDropped completes the request which was dropped, timed out or rejected for overload,
+// sampling it to back off the limit.
+func (ln *Listener) OnDropped() {
+	ln.once.Do(func() {
+		ln.limiter.release()
+		ln.limiter.limit.OnSample(ln.start, time.Since(ln.start), ln.inflight, true)
+	})
+}
+
+// OnIgnore completes the request without sampling it, such as a request failed
+// before it reached the protected resource.
+func (ln *Listener) OnIgnore() {
+	ln.once.Do(ln.limiter.release)
+}
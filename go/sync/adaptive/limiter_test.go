@@ -0,0 +1,86 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adaptive_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/sync/adaptive"
+)
+
+func TestLimiterAcquire(t *testing.T) {
+	l := adaptive.NewLimiter(adaptive.NewAIMDLimit(adaptive.WithAimdInitialLimit(2)))
+	ln1, ok := l.Acquire()
+	if !ok {
+		t.Fatal("Acquire() = false; want true")
+	}
+	ln2, ok := l.Acquire()
+	if !ok {
+		t.Fatal("Acquire() = false; want true")
+	}
+	if _, ok := l.Acquire(); ok {
+		t.Fatal("Acquire() = true over the limit; want false")
+	}
+	ln1.OnSuccess()
+	ln1.OnDropped() // completed already, no effect
+	if got := l.Inflight(); got != 1 {
+		t.Fatalf("Inflight() = %d; want 1", got)
+	}
+	if got := l.Limit(); got != 3 {
+		t.Fatalf("Limit() = %d; want 3 after a success at full use", got)
+	}
+	ln2.OnDropped()
+	if got := l.Limit(); got != 2 {
+		t.Fatalf("Limit() = %d; want 2 after a drop", got)
+	}
+}
+
+func TestAIMDLimit(t *testing.T) {
+	l := adaptive.NewAIMDLimit(adaptive.WithAimdInitialLimit(10), adaptive.WithAimdMaxLimit(11), adaptive.WithAimdTimeout(time.Second))
+	l.OnSample(time.Now(), time.Millisecond, 1, false)
+	if got := l.Limit(); got != 10 {
+		t.Fatalf("Limit() = %d; want 10 as the application is the bottleneck", got)
+	}
+	l.OnSample(time.Now(), time.Millisecond, 10, false)
+	l.OnSample(time.Now(), time.Millisecond, 10, false)
+	if got := l.Limit(); got != 11 {
+		t.Fatalf("Limit() = %d; want 11 as the max limit", got)
+	}
+	l.OnSample(time.Now(), 2*time.Second, 10, false)
+	if got := l.Limit(); got != 9 {
+		t.Fatalf("Limit() = %d; want 9 after a timeout", got)
+	}
+}
+
+func TestVegasLimit(t *testing.T) {
+	l := adaptive.NewVegasLimit(adaptive.WithVegasInitialLimit(10), adaptive.WithVegasMaxLimit(20))
+	l.OnSample(time.Now(), 10*time.Millisecond, 10, false) // no-load rtt
+	l.OnSample(time.Now(), 10*time.Millisecond, 10, false)
+	if got := l.Limit(); got != 16 {
+		t.Fatalf("Limit() = %d; want 16 as no queue is built", got)
+	}
+	l.OnSample(time.Now(), 100*time.Millisecond, 16, false)
+	if got := l.Limit(); got != 14 {
+		t.Fatalf("Limit() = %d; want 14 as a queue is built", got)
+	}
+}
+
+func TestGradient2Limit(t *testing.T) {
+	l := adaptive.NewGradient2Limit(adaptive.WithGradient2InitialLimit(100), adaptive.WithGradient2MinLimit(10))
+	for i := 0; i < 100; i++ {
+		l.OnSample(time.Now(), 10*time.Millisecond, 100, false)
+	}
+	grown := l.Limit()
+	if grown <= 100 {
+		t.Fatalf("Limit() = %d; want grown over 100 with a steady rtt", grown)
+	}
+	for i := 0; i < 10; i++ {
+		l.OnSample(time.Now(), 100*time.Millisecond, grown, false)
+	}
+	if got := l.Limit(); got >= grown {
+		t.Fatalf("Limit() = %d; want shrunk below %d with a rising rtt", got, grown)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adaptive
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+//go:generate go-option -type "vegas"
+type vegas struct {
+	// InitialLimit is the limit before any sample.
+	InitialLimit int
+	// MinLimit is the lower bound of the limit.
+	MinLimit int
+	// MaxLimit is the upper bound of the limit.
+	MaxLimit int
+	// Smoothing in (0, 1] weighs a new limit against the current one.
+	Smoothing float64
+	// ProbeMultiplier resets the no-load rtt every ProbeMultiplier*limit samples,
+	// so that the no-load rtt follows a changing baseline latency.
+	// take effects if only ProbeMultiplier is bigger than 0.
+	ProbeMultiplier int
+}
+
+// VegasLimit is a Limit inspired by TCP Vegas congestion control.
+// It estimates the queue size as limit * (1 - rttNoLoad/rtt), where rttNoLoad is the
+// minimum rtt observed, grows the limit while the queue is small and shrinks it
+// while the queue is large.
+// See https://en.wikipedia.org/wiki/TCP_Vegas for more about TCP Vegas.
+type VegasLimit struct {
+	opts vegas
+
+	mu                    sync.Mutex
+	limit                 float64
+	rttNoLoad             time.Duration
+	samplesUntilNextProbe int
+}
+
+// NewVegasLimit returns a new VegasLimit.
+func NewVegasLimit(opts ...VegasOption) *VegasLimit {
+	l := &VegasLimit{opts: vegas{
+		InitialLimit:    20,
+		MinLimit:        1,
+		MaxLimit:        1000,
+		Smoothing:       1.0,
+		ProbeMultiplier: 30,
+	}}
+	l.opts.ApplyOptions(opts...)
+	l.limit = float64(l.opts.InitialLimit)
+	l.resetProbeLocked()
+	return l
+}
+
+// Limit returns the current estimated limit.
+func (l *VegasLimit) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// OnSample updates the estimated limit with a sample of a request.
+func (l *VegasLimit) OnSample(start time.Time, rtt time.Duration, inflight int, dropped bool) {
+	if rtt <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.opts.ProbeMultiplier > 0 {
+		l.samplesUntilNextProbe--
+		if l.samplesUntilNextProbe <= 0 {
+			l.resetProbeLocked()
+			l.rttNoLoad = rtt
+			return
+		}
+	}
+	if l.rttNoLoad <= 0 || rtt < l.rttNoLoad {
+		l.rttNoLoad = rtt
+		return
+	}
+
+	logLimit := math.Max(math.Log10(l.limit), 1)
+	alpha, beta, threshold := 3*logLimit, 6*logLimit, logLimit
+	queueSize := math.Ceil(l.limit * (1 - float64(l.rttNoLoad)/float64(rtt)))
+
+	newLimit := l.limit
+	switch {
+	case dropped:
+		newLimit = l.limit - logLimit
+	case float64(inflight)*2 < l.limit:
+		// the application is the bottleneck, not the limit
+		return
+	case queueSize <= threshold:
+		newLimit = l.limit + beta
+	case queueSize < alpha:
+		newLimit = l.limit + logLimit
+	case queueSize > beta:
+		newLimit = l.limit - logLimit
+	default:
+		return
+	}
+	newLimit = clamp(newLimit, l.opts.MinLimit, l.opts.MaxLimit)
+	l.limit = (1-l.opts.Smoothing)*l.limit + l.opts.Smoothing*newLimit
+}
+
+func (l *VegasLimit) resetProbeLocked() {
+	l.samplesUntilNextProbe = l.opts.ProbeMultiplier * int(l.limit)
+}
@@ -0,0 +1,85 @@
+// Code generated by "go-option -type vegas"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package adaptive
+
+// A VegasOption sets options.
+type VegasOption interface {
+	apply(*vegas)
+}
+
+// EmptyVegasOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyVegasOption struct{}
+
+func (EmptyVegasOption) apply(*vegas) {}
+
+// VegasOptionFunc wraps a function that modifies vegas into an
+// implementation of the VegasOption interface.
+type VegasOptionFunc func(*vegas)
+
+func (f VegasOptionFunc) apply(do *vegas) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *vegas) ApplyOptions(options ...VegasOption) *vegas {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withVegas sets vegas.
+func withVegas(v vegas) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		*o = v
+	})
+}
+
+// WithVegasInitialLimit sets InitialLimit in vegas.
+// InitialLimit is the limit before any sample.
+func WithVegasInitialLimit(v int) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		o.InitialLimit = v
+	})
+}
+
+// WithVegasMinLimit sets MinLimit in vegas.
+// MinLimit is the lower bound of the limit.
+func WithVegasMinLimit(v int) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		o.MinLimit = v
+	})
+}
+
+// WithVegasMaxLimit sets MaxLimit in vegas.
+// MaxLimit is the upper bound of the limit.
+func WithVegasMaxLimit(v int) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		o.MaxLimit = v
+	})
+}
+
+// WithVegasSmoothing sets Smoothing in vegas.
+// Smoothing in (0, 1] weighs a new limit against the current one.
+func WithVegasSmoothing(v float64) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		o.Smoothing = v
+	})
+}
+
+// WithVegasProbeMultiplier sets ProbeMultiplier in vegas.
+// ProbeMultiplier resets the no-load rtt every ProbeMultiplier*limit samples,
+// so that the no-load rtt follows a changing baseline latency.
+// take effects if only ProbeMultiplier is bigger than 0.
+func WithVegasProbeMultiplier(v int) VegasOption {
+	return VegasOptionFunc(func(o *vegas) {
+		o.ProbeMultiplier = v
+	})
+}
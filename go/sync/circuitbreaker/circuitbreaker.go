@@ -0,0 +1,268 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package circuitbreaker provides a circuit breaker which stops calling an unhealthy
+// upstream for a while, instead of hammering it with requests and retries.
+// See https://martinfowler.com/bliki/CircuitBreaker.html for more about circuit breakers.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrOpenState is returned when the CircuitBreaker is open.
+	ErrOpenState = errors.New("circuitbreaker: circuit breaker is open")
+	// ErrTooManyRequests is returned when the CircuitBreaker is half-open and
+	// the probe requests are exhausted.
+	ErrTooManyRequests = errors.New("circuitbreaker: too many requests in half-open state")
+)
+
+//go:generate go-option -type "breaker"
+type breaker struct {
+	// Window is the duration of the rolling window in which results are counted.
+	Window time.Duration
+	// WindowBuckets is the number of buckets the rolling window is split into.
+	WindowBuckets int
+	// MinRequests is the minimum number of requests in the rolling window
+	// before the failure and slow call rates are evaluated.
+	MinRequests int
+	// FailureRateThreshold in (0, 1] opens the circuit breaker if the rate of failures reaches it.
+	// take effects if only FailureRateThreshold is bigger than 0.
+	FailureRateThreshold float64
+	// SlowCallDuration counts a request as slow if it takes longer.
+	// take effects if only SlowCallDuration is bigger than 0.
+	SlowCallDuration time.Duration
+	// SlowCallRateThreshold in (0, 1] opens the circuit breaker if the rate of slow calls reaches it.
+	// take effects if only SlowCallRateThreshold is bigger than 0.
+	SlowCallRateThreshold float64
+	// OpenTimeout is the duration of the open state, after which the circuit breaker becomes half-open.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of probe requests allowed in the half-open state,
+	// the circuit breaker closes if all of them succeed.
+	HalfOpenMaxRequests int
+	// IsFailure reports whether the result of a request is a failure, err != nil if IsFailure is nil.
+	IsFailure func(err error) bool
+	// OnStateChange is called whenever the state of the circuit breaker changes, without holding
+	// the lock of the circuit breaker, so it may call the methods of the circuit breaker.
+	OnStateChange func(name string, from State, to State)
+	// Logger logs state changes, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+// Counts holds the numbers of requests in the rolling window.
+type Counts struct {
+	Requests  int
+	Failures  int
+	SlowCalls int
+}
+
+// CircuitBreaker is a state machine to prevent sending requests that are likely to fail.
+//
+// Closed: requests are allowed, and their results are counted in a rolling window.
+// The circuit breaker opens if the failure rate or the slow call rate reaches its threshold.
+// Open: requests are rejected with ErrOpenState until OpenTimeout expires.
+// HalfOpen: a limited number of probe requests are allowed, the circuit breaker
+// closes if all of them succeed, and opens again if any of them fails.
+type CircuitBreaker struct {
+	name string
+	opts breaker
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // increased on every state change, to ignore results of a former state
+	expiry     time.Time
+	window     window
+
+	halfOpenRequests  int
+	halfOpenSuccesses int
+
+	transitions []stateTransition // state changes to notify once unlocked
+}
+
+type stateTransition struct {
+	from, to State
+}
+
+// NewCircuitBreaker returns a new CircuitBreaker in closed state.
+func NewCircuitBreaker(name string, opts ...BreakerOption) *CircuitBreaker {
+	cb := &CircuitBreaker{name: name, opts: breaker{
+		Window:               time.Minute,
+		WindowBuckets:        10,
+		MinRequests:          20,
+		FailureRateThreshold: 0.5,
+		OpenTimeout:          time.Minute,
+		HalfOpenMaxRequests:  1,
+	}}
+	cb.opts.ApplyOptions(opts...)
+	if cb.opts.WindowBuckets <= 0 {
+		cb.opts.WindowBuckets = 1
+	}
+	cb.window = newWindow(cb.opts.Window, cb.opts.WindowBuckets)
+	return cb
+}
+
+// Name returns the name of the CircuitBreaker.
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
+}
+
+// State returns the current state of the CircuitBreaker.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.unlock()
+	state, _ := cb.currentStateLocked(time.Now())
+	return state
+}
+
+// Counts returns the numbers of requests in the rolling window.
+func (cb *CircuitBreaker) Counts() Counts {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.window.counts(time.Now())
+}
+
+// Allow checks if a request can proceed.
+// On success, returns a done func which must be called with the result of the request.
+// On failure, returns ErrOpenState or ErrTooManyRequests.
+func (cb *CircuitBreaker) Allow() (done func(err error), err error) {
+	cb.mu.Lock()
+	defer cb.unlock()
+	now := time.Now()
+	state, generation := cb.currentStateLocked(now)
+	switch state {
+	case StateOpen:
+		return nil, ErrOpenState
+	case StateHalfOpen:
+		if cb.halfOpenRequests >= max(cb.opts.HalfOpenMaxRequests, 1) {
+			return nil, ErrTooManyRequests
+		}
+		cb.halfOpenRequests++
+	}
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { cb.done(generation, now, err) })
+	}, nil
+}
+
+// Execute runs fn if the CircuitBreaker allows, and records its result.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	done, err := cb.Allow()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			done(errors.New("circuitbreaker: panic"))
+			panic(e)
+		}
+	}()
+	err = fn()
+	done(err)
+	return err
+}
+
+func (cb *CircuitBreaker) done(generation uint64, start time.Time, err error) {
+	cb.mu.Lock()
+	defer cb.unlock()
+	now := time.Now()
+	state, current := cb.currentStateLocked(now)
+	if generation != current {
+		return
+	}
+	failure := err != nil
+	if cb.opts.IsFailure != nil {
+		failure = cb.opts.IsFailure(err)
+	}
+	slow := cb.opts.SlowCallDuration > 0 && now.Sub(start) > cb.opts.SlowCallDuration
+
+	switch state {
+	case StateClosed:
+		cb.window.add(now, failure, slow)
+		if cb.shouldTripLocked(now) {
+			cb.setStateLocked(StateOpen, now)
+		}
+	case StateHalfOpen:
+		if failure || slow {
+			cb.setStateLocked(StateOpen, now)
+			return
+		}
+		cb.halfOpenSuccesses++
+		if cb.halfOpenSuccesses >= max(cb.opts.HalfOpenMaxRequests, 1) {
+			cb.setStateLocked(StateClosed, now)
+		}
+	}
+}
+
+func (cb *CircuitBreaker) shouldTripLocked(now time.Time) bool {
+	counts := cb.window.counts(now)
+	if counts.Requests == 0 || counts.Requests < cb.opts.MinRequests {
+		return false
+	}
+	if t := cb.opts.FailureRateThreshold; t > 0 && float64(counts.Failures)/float64(counts.Requests) >= t {
+		return true
+	}
+	if t := cb.opts.SlowCallRateThreshold; t > 0 && float64(counts.SlowCalls)/float64(counts.Requests) >= t {
+		return true
+	}
+	return false
+}
+
+// currentStateLocked returns the current state, and moves open state to half-open once expired.
+func (cb *CircuitBreaker) currentStateLocked(now time.Time) (State, uint64) {
+	if cb.state == StateOpen && !now.Before(cb.expiry) {
+		cb.setStateLocked(StateHalfOpen, now)
+	}
+	return cb.state, cb.generation
+}
+
+func (cb *CircuitBreaker) setStateLocked(state State, now time.Time) {
+	if cb.state == state {
+		return
+	}
+	prev := cb.state
+	cb.state = state
+	cb.generation++
+	cb.halfOpenRequests = 0
+	cb.halfOpenSuccesses = 0
+	cb.expiry = time.Time{}
+	switch state {
+	case StateClosed:
+		cb.window.reset()
+	case StateOpen:
+		cb.expiry = now.Add(cb.opts.OpenTimeout)
+	}
+
+	cb.transitions = append(cb.transitions, stateTransition{from: prev, to: state})
+}
+
+// unlock unlocks cb.mu, and then logs and notifies the state changes happened while locked.
+func (cb *CircuitBreaker) unlock() {
+	transitions := cb.transitions
+	cb.transitions = nil
+	cb.mu.Unlock()
+	if len(transitions) == 0 {
+		return
+	}
+
+	logger := cb.opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	for _, t := range transitions {
+		level := slog.LevelInfo
+		if t.to == StateOpen {
+			level = slog.LevelWarn
+		}
+		logger.Log(context.Background(), level, "circuit breaker state changed",
+			slog.String("name", cb.name), slog.String("from", t.from.String()), slog.String("to", t.to.String()))
+		if cb.opts.OnStateChange != nil {
+			cb.opts.OnStateChange(cb.name, t.from, t.to)
+		}
+	}
+}
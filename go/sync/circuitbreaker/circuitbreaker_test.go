@@ -0,0 +1,110 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/sync/circuitbreaker"
+)
+
+var errFailed = errors.New("failed")
+
+func TestCircuitBreaker(t *testing.T) {
+	var transitions []string
+	cb := circuitbreaker.NewCircuitBreaker("test",
+		circuitbreaker.WithBreakerMinRequests(4),
+		circuitbreaker.WithBreakerFailureRateThreshold(0.5),
+		circuitbreaker.WithBreakerOpenTimeout(20*time.Millisecond),
+		circuitbreaker.WithBreakerHalfOpenMaxRequests(2),
+		circuitbreaker.WithBreakerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		circuitbreaker.WithBreakerOnStateChange(func(name string, from, to circuitbreaker.State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}))
+
+	for _, err := range []error{nil, errFailed, nil} {
+		if got := cb.Execute(func() error { return err }); got != err {
+			t.Fatalf("Execute() = %v; want %v", got, err)
+		}
+	}
+	if got := cb.State(); got != circuitbreaker.StateClosed {
+		t.Fatalf("State() = %v; want %v before MinRequests", got, circuitbreaker.StateClosed)
+	}
+	_ = cb.Execute(func() error { return errFailed })
+	if got := cb.State(); got != circuitbreaker.StateOpen {
+		t.Fatalf("State() = %v; want %v at failure rate 0.5", got, circuitbreaker.StateOpen)
+	}
+	if err := cb.Execute(func() error { return nil }); !errors.Is(err, circuitbreaker.ErrOpenState) {
+		t.Fatalf("Execute() = %v; want %v", err, circuitbreaker.ErrOpenState)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if got := cb.State(); got != circuitbreaker.StateHalfOpen {
+		t.Fatalf("State() = %v; want %v after OpenTimeout", got, circuitbreaker.StateHalfOpen)
+	}
+	done1, err := cb.Allow()
+	if err != nil {
+		t.Fatalf("Allow() = %v; want nil", err)
+	}
+	done2, err := cb.Allow()
+	if err != nil {
+		t.Fatalf("Allow() = %v; want nil", err)
+	}
+	if _, err := cb.Allow(); !errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+		t.Fatalf("Allow() = %v; want %v", err, circuitbreaker.ErrTooManyRequests)
+	}
+	done1(nil)
+	done2(nil)
+	if got := cb.State(); got != circuitbreaker.StateClosed {
+		t.Fatalf("State() = %v; want %v after probes succeeded", got, circuitbreaker.StateClosed)
+	}
+	if got := cb.Counts(); got.Requests != 0 {
+		t.Fatalf("Counts() = %+v; want reset after closed", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v; want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transitions = %v; want %v", transitions, want)
+		}
+	}
+}
+
+func TestCircuitBreakerSlowCall(t *testing.T) {
+	cb := circuitbreaker.NewCircuitBreaker("slow",
+		circuitbreaker.WithBreakerMinRequests(1),
+		circuitbreaker.WithBreakerSlowCallDuration(time.Millisecond),
+		circuitbreaker.WithBreakerSlowCallRateThreshold(1),
+		circuitbreaker.WithBreakerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	_ = cb.Execute(func() error { time.Sleep(5 * time.Millisecond); return nil })
+	if got := cb.State(); got != circuitbreaker.StateOpen {
+		t.Fatalf("State() = %v; want %v after a slow call", got, circuitbreaker.StateOpen)
+	}
+}
+
+func TestCircuitBreakerOnStateChangeReentrant(t *testing.T) {
+	var cb *circuitbreaker.CircuitBreaker
+	var states []circuitbreaker.State
+	cb = circuitbreaker.NewCircuitBreaker("test",
+		circuitbreaker.WithBreakerMinRequests(1),
+		circuitbreaker.WithBreakerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		circuitbreaker.WithBreakerOnStateChange(func(name string, from, to circuitbreaker.State) {
+			// calls back into the circuit breaker
+			states = append(states, cb.State())
+			_ = cb.Counts()
+		}))
+
+	_ = cb.Execute(func() error { return errFailed })
+	if len(states) != 1 || states[0] != circuitbreaker.StateOpen {
+		t.Fatalf("states seen by OnStateChange = %v; want [%v]", states, circuitbreaker.StateOpen)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import (
+	"maps"
+	"sync"
+)
+
+// Group holds a CircuitBreaker per name, such as per host or per method,
+// all created with the same options.
+type Group struct {
+	opts []BreakerOption
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewGroup returns a new Group which creates CircuitBreakers with opts.
+func NewGroup(opts ...BreakerOption) *Group {
+	return &Group{opts: opts, breakers: make(map[string]*CircuitBreaker)}
+}
+
+// Get returns the CircuitBreaker of name, creating it if absent.
+func (g *Group) Get(name string) *CircuitBreaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cb, ok := g.breakers[name]
+	if !ok {
+		cb = NewCircuitBreaker(name, g.opts...)
+		g.breakers[name] = cb
+	}
+	return cb
+}
+
+// Range calls f sequentially for each CircuitBreaker in the Group.
+// If f returns false, range stops the iteration.
+func (g *Group) Range(f func(name string, cb *CircuitBreaker) bool) {
+	g.mu.Lock()
+	breakers := maps.Clone(g.breakers)
+	g.mu.Unlock()
+	for name, cb := range breakers {
+		if !f(name, cb) {
+			return
+		}
+	}
+}
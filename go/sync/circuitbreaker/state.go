@@ -0,0 +1,29 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import "strconv"
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	StateClosed   State = iota // requests are allowed
+	StateHalfOpen              // a limited number of probe requests are allowed
+	StateOpen                  // requests are rejected
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "State(" + strconv.Itoa(int(s)) + ")"
+	}
+}
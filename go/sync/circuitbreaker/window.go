@@ -0,0 +1,63 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import "time"
+
+// window counts results in a rolling window, split into fixed-duration buckets.
+type window struct {
+	bucketDuration time.Duration
+	buckets        []windowBucket
+}
+
+type windowBucket struct {
+	epoch int64 // index of the bucket since the Unix epoch
+	Counts
+}
+
+func newWindow(d time.Duration, n int) window {
+	bucketDuration := d / time.Duration(n)
+	if bucketDuration <= 0 {
+		bucketDuration = 1
+	}
+	return window{bucketDuration: bucketDuration, buckets: make([]windowBucket, n)}
+}
+
+func (w *window) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(w.bucketDuration)
+}
+
+func (w *window) add(now time.Time, failure, slow bool) {
+	epoch := w.epoch(now)
+	b := &w.buckets[epoch%int64(len(w.buckets))]
+	if b.epoch != epoch {
+		*b = windowBucket{epoch: epoch}
+	}
+	b.Requests++
+	if failure {
+		b.Failures++
+	}
+	if slow {
+		b.SlowCalls++
+	}
+}
+
+func (w *window) counts(now time.Time) Counts {
+	epoch := w.epoch(now)
+	var counts Counts
+	for _, b := range w.buckets {
+		if b.epoch <= epoch-int64(len(w.buckets)) || b.epoch > epoch {
+			continue
+		}
+		counts.Requests += b.Requests
+		counts.Failures += b.Failures
+		counts.SlowCalls += b.SlowCalls
+	}
+	return counts
+}
+
+func (w *window) reset() {
+	clear(w.buckets)
+}
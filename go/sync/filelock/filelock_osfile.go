@@ -8,8 +8,6 @@ import (
 	"io"
 	"io/fs"
 	"os"
-
-	os_ "github.com/searKing/golang/go/os"
 )
 
 // OpenFile is like os.OpenFile, but returns a locked file.
@@ -30,7 +28,7 @@ func Open(name string) (*LockedFile[*os.File], error) {
 
 // Create is like os.Create, but returns a write-locked file.
 func Create(name string) (*LockedFile[*os.File], error) {
-	return OpenFile(name, os_.DefaultFlagCreateTruncate, os_.DefaultPermissionFile)
+	return OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 }
 
 // Edit creates the named file with mode 0666 (before umask),
@@ -39,7 +37,7 @@ func Create(name string) (*LockedFile[*os.File], error) {
 // If Edit succeeds, methods on the returned File can be used for I/O.
 // The associated file descriptor has mode O_RDWR and the file is write-locked.
 func Edit(name string) (*LockedFile[*os.File], error) {
-	return OpenFile(name, os_.DefaultFlagCreateIfNotExist, os_.DefaultPermissionFile)
+	return OpenFile(name, os.O_RDWR|os.O_CREATE, 0666)
 }
 
 // Read opens the named file with a read-lock and returns its contents.
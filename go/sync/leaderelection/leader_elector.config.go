@@ -7,6 +7,8 @@ package leaderelection
 import (
 	"context"
 	"time"
+
+	time_ "github.com/searKing/golang/go/time"
 )
 
 type Config struct {
@@ -51,6 +53,10 @@ type Config struct {
 
 	// Name is the name of the resource lock for debugging
 	Name string
+
+	// Clock tells the time to stamp and expire leases, and to wait between tries of actions.
+	// time_.RealClock if nil.
+	Clock time_.Clock
 }
 
 // LeaderCallbacks are callbacks that are triggered during certain
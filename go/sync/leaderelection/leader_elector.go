@@ -76,6 +76,7 @@ func NewLeaderElector(lec Config) (*LeaderElector, error) {
 	if lec.Lock == nil {
 		return nil, fmt.Errorf("lock must not be nil")
 	}
+	lec.Clock = time_.ClockOrDefault(lec.Clock)
 	le := LeaderElector{
 		config: lec,
 	}
@@ -180,7 +181,8 @@ func (le *LeaderElector) acquire(ctx context.Context) bool {
 		le.config.Lock.RecordEvent(le.config.Name, EventBecameLeader)
 		le.logf("successfully acquired lease %v", desc)
 		cancel()
-	}, true, time_.WithExponentialBackOffOptionRandomizationFactor(JitterFactor))
+	}, true, time_.WithExponentialBackOffOptionRandomizationFactor(JitterFactor),
+		time_.WithExponentialBackOffOptionClock(le.config.Clock))
 	return succeeded
 }
 
@@ -189,7 +191,7 @@ func (le *LeaderElector) renew(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	time_.Until(ctx, func(ctx context.Context) {
-		timeoutCtx, timeoutCancel := context.WithTimeout(ctx, le.config.RenewTimeout)
+		timeoutCtx, timeoutCancel := le.withTimeout(ctx, le.config.RenewTimeout)
 		defer timeoutCancel()
 		var leader bool
 		// block until leader elector finished
@@ -198,7 +200,7 @@ func (le *LeaderElector) renew(ctx context.Context) {
 				leader = true
 				timeoutCancel()
 			}
-		}, le.config.RenewTimeout)
+		}, le.config.RenewTimeout, time_.WithExponentialBackOffOptionClock(le.config.Clock))
 		// leader elector finished
 
 		// maybe report leader changed
@@ -211,10 +213,10 @@ func (le *LeaderElector) renew(ctx context.Context) {
 		}
 		// I'm a follower now
 		le.config.Lock.RecordEvent(le.config.Name, EventStoppedLeading)
-		le.logf("failed to renew lease %v: %v", desc, timeoutCtx.Err())
+		le.logf("failed to renew lease %v: %v", desc, context.Cause(timeoutCtx))
 		cancel()
 
-	}, le.config.RetryPeriod)
+	}, le.config.RetryPeriod, time_.WithExponentialBackOffOptionClock(le.config.Clock))
 
 	// if we hold the lease, give it up
 	// unlock if I'm a leader
@@ -229,7 +231,7 @@ func (le *LeaderElector) release() bool {
 	if !le.IsLeader() {
 		return true
 	}
-	now := time.Now()
+	now := le.config.Clock.Now()
 	leaderElectionRecord := Record{
 		LeaderTransitions: le.observedRecord.LeaderTransitions,
 		LeaseDuration:     time.Second,
@@ -249,7 +251,7 @@ func (le *LeaderElector) release() bool {
 // else it tries to renew the lease if it has already been acquired. Returns true
 // on success else returns false.
 func (le *LeaderElector) tryAcquireOrRenew(ctx context.Context) bool {
-	now := time.Now()
+	now := le.config.Clock.Now()
 	leaderElectionRecord := Record{
 		HolderIdentity: le.config.Lock.Identity(),
 		LeaseDuration:  le.config.LeaseDuration,
@@ -329,7 +331,10 @@ func (le *LeaderElector) Check(maxTolerableExpiredLease time.Duration) error {
 	// If we are more than timeout seconds after the lease duration that is past the timeout
 	// on the lease renew. Time to start reporting ourselves as unhealthy. We should have
 	// died but conditions like deadlock can prevent this. (See #70819)
-	if time.Since(le.observedTime) > le.config.LeaseDuration+maxTolerableExpiredLease {
+	le.observedRecordLock.Lock()
+	observedTime := le.observedTime
+	le.observedRecordLock.Unlock()
+	if le.config.Clock.Now().Sub(observedTime) > le.config.LeaseDuration+maxTolerableExpiredLease {
 		return fmt.Errorf("failed election to renew leadership on lease %s", le.config.Name)
 	}
 
@@ -343,7 +348,7 @@ func (le *LeaderElector) setObservedRecord(observedRecord *Record) {
 	defer le.observedRecordLock.Unlock()
 
 	le.observedRecord = *observedRecord
-	le.observedTime = time.Now()
+	le.observedTime = le.config.Clock.Now()
 }
 
 // getObservedRecord returns observersRecord.
@@ -360,3 +365,14 @@ func (le *LeaderElector) getObservedRecord() Record {
 func (le *LeaderElector) observedRecordExpired(now time.Time) bool {
 	return le.observedTime.Add(le.config.LeaseDuration).After(now)
 }
+
+// withTimeout returns a copy of ctx canceled when timeout elapses on the clock of le,
+// as context.WithTimeout does on the real clock.
+func (le *LeaderElector) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := le.config.Clock.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+	return ctx, func() {
+		timer.Stop()
+		cancel(context.Canceled)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leaderelection_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/sync/leaderelection"
+	time_ "github.com/searKing/golang/go/time"
+)
+
+// memoryLock is a ResourceLocker in memory, which fails to update if broken.
+type memoryLock struct {
+	identity string
+	broken   atomic.Bool
+
+	mu     sync.Mutex
+	record *leaderelection.Record
+}
+
+func (l *memoryLock) Get(ctx context.Context) (*leaderelection.Record, []byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.record == nil {
+		return nil, nil, errors.New("no record")
+	}
+	r := *l.record
+	return &r, []byte(r.HolderIdentity + r.RenewTime.String()), nil
+}
+
+func (l *memoryLock) Create(ctx context.Context, ler leaderelection.Record) error {
+	return l.Update(ctx, ler)
+}
+
+func (l *memoryLock) Update(ctx context.Context, ler leaderelection.Record) error {
+	if l.broken.Load() {
+		return errors.New("lock is broken")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.record = &ler
+	return nil
+}
+
+func (l *memoryLock) renewTime() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.record.RenewTime
+}
+
+func (l *memoryLock) RecordEvent(name, event string) {}
+func (l *memoryLock) Identity() string               { return l.identity }
+func (l *memoryLock) Describe() string               { return "memory lock " + l.identity }
+
+func TestLeaderElectorRenewWithClock(t *testing.T) {
+	clock := time_.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	lock := &memoryLock{identity: "a"}
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	le, err := leaderelection.NewLeaderElector(leaderelection.Config{
+		Lock:          lock,
+		LeaseDuration: 15 * time.Second,
+		RenewTimeout:  10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) { close(started) },
+			OnStoppedLeading: func() { close(stopped) },
+		},
+		Clock: clock,
+	})
+	if err != nil {
+		t.Fatalf("NewLeaderElector: %v", err)
+	}
+	le.ErrorLog = log.New(io.Discard, "", 0)
+
+	go le.Run(context.Background())
+	<-started
+	if !le.IsLeader() {
+		t.Fatal("IsLeader() = false after started leading")
+	}
+
+	// renewals succeed on the fake clock, every RetryPeriod
+	for range 100 {
+		clock.BlockUntil(1)
+		clock.Step(2 * time.Second)
+		now := clock.Now()
+		deadline := time.Now().Add(10 * time.Second)
+		for lock.renewTime().Before(now) {
+			if time.Now().After(deadline) {
+				t.Fatalf("lease is not renewed at %v", now)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if !le.IsLeader() {
+		t.Fatal("IsLeader() = false while renewals succeed")
+	}
+	if err := le.Check(0); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	// renewals fail, leadership is given up once RenewTimeout elapses on the fake clock
+	lock.broken.Store(true)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		select {
+		case <-stopped:
+			return
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("leadership is not given up after renewals failed")
+		}
+		clock.Step(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package semaphore provides a weighted semaphore implementation with priority classes.
+// The key observation and some code is borrowed from
+// golang.org/x/sync/semaphore/semaphore.go
+package semaphore
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+//go:generate go-option -type "weighted"
+type weighted struct {
+	// A waiter is promoted by one priority class every AgingInterval it waits,
+	// so that low priority waiters are not starved by a steady high priority load.
+	// take effects if only AgingInterval is bigger than 0.
+	AgingInterval time.Duration
+}
+
+type waiter struct {
+	n        int64
+	priority int
+	seq      uint64 // enqueue order, FIFO inside a priority class
+	enqueued time.Time
+	ready    chan<- struct{} // Closed when semaphore acquired.
+
+	elem      *list.Element // in the queue of priority, or in oversized
+	oversized bool          // weight larger than the capacity, not queued
+}
+
+// Stats is a snapshot of a Weighted semaphore.
+type Stats struct {
+	Capacity    int64       // maximum combined weight for concurrent access
+	InUse       int64       // weight held by acquirers now
+	Waiters     int         // number of waiters now, the ones of weights larger than the capacity included
+	QueueLength map[int]int // number of waiters queued now, by priority
+
+	Acquired        uint64        // total number of successful acquisitions
+	Canceled        uint64        // total number of acquisitions abandoned by context
+	WaitDuration    time.Duration // total time spent waiting by queued acquirers that were admitted
+	MaxWaitDuration time.Duration // longest time spent waiting by a queued acquirer that was admitted
+}
+
+// Weighted provides a way to bound concurrent access to a resource.
+// The callers can request access with a given weight and priority.
+//
+// Waiters are admitted in priority order, higher priority first,
+// and in FIFO order inside each priority class.
+// A waiter which can not be satisfied blocks all waiters behind it,
+// so that large requests are not starved by a stream of smaller ones.
+type Weighted struct {
+	opts weighted
+
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	seq     uint64
+	waiters map[int]*list.List // priority -> FIFO of *waiter
+	queued  int
+	// waiters of weights larger than the capacity, not queued until the capacity grows,
+	// so that they block no other waiters
+	oversized *list.List
+
+	acquired        uint64
+	canceled        uint64
+	waitDuration    time.Duration
+	maxWaitDuration time.Duration
+}
+
+// NewWeighted creates a new weighted semaphore with the given
+// maximum combined weight for concurrent access.
+func NewWeighted(n int64, opts ...WeightedOption) *Weighted {
+	w := &Weighted{size: n, waiters: make(map[int]*list.List), oversized: list.New()}
+	w.opts.ApplyOptions(opts...)
+	return w
+}
+
+// Acquire acquires the semaphore with a weight of n and a priority, blocking until resources
+// are available or ctx is done. On success, returns nil. On failure, returns
+// ctx.Err() and leaves the semaphore unchanged.
+//
+// If ctx is already done, Acquire may still succeed without blocking.
+// A weight of n larger than the capacity is not queued, and blocks no other waiters,
+// until the capacity grows by SetCapacity.
+func (s *Weighted) Acquire(ctx context.Context, n int64, priority int) error {
+	done := ctx.Done()
+
+	s.mu.Lock()
+	select {
+	case <-done:
+		// ctx becoming done has "happened before" acquiring the semaphore,
+		// whether it became done before the call began or while we were
+		// waiting for the mutex. We prefer to fail even if we could acquire
+		// the mutex without blocking.
+		s.mu.Unlock()
+		return ctx.Err()
+	default:
+	}
+	if s.size-s.cur >= n && s.queued == 0 {
+		// Since we hold s.mu and haven't synchronized since checking done, if
+		// ctx becomes done before we return here, it becoming done must have
+		// "happened concurrently" with this call - it cannot "happen before"
+		// we return in this branch. So, we're ok to always acquire here.
+		s.cur += n
+		s.acquired++
+		s.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	w := &waiter{n: n, priority: priority, seq: s.seq, enqueued: time.Now(), ready: ready}
+	s.seq++
+	if n > s.size {
+		// Don't make other Acquire calls block on one that's doomed to fail.
+		w.oversized = true
+		w.elem = s.oversized.PushBack(w)
+	} else {
+		s.pushLocked(w)
+		// a waiter of higher priority than the front may fit right now
+		s.notifyWaitersLocked()
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-done:
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// Acquired the semaphore after we were canceled.
+			// Pretend we didn't and put the tokens back.
+			s.cur -= n
+			s.acquired--
+		default:
+			if w.oversized {
+				s.oversized.Remove(w.elem)
+			} else {
+				s.removeLocked(s.waiters[w.priority], w.elem)
+			}
+		}
+		s.canceled++
+		s.notifyWaitersLocked()
+		s.mu.Unlock()
+		return ctx.Err()
+
+	case <-ready:
+		// Acquired the semaphore. Check that ctx isn't already done.
+		// We check the done channel instead of calling ctx.Err because we
+		// already have the channel, and ctx.Err is O(n) with the nesting
+		// depth of ctx.
+		select {
+		case <-done:
+			s.Release(n)
+			s.mu.Lock()
+			s.acquired--
+			s.canceled++
+			s.mu.Unlock()
+			return ctx.Err()
+		default:
+		}
+		return nil
+	}
+}
+
+// TryAcquire acquires the semaphore with a weight of n and a priority without blocking.
+// On success, returns true. On failure, returns false and leaves the semaphore unchanged.
+func (s *Weighted) TryAcquire(n int64, priority int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// waiters of lower priority do not block a higher priority acquirer
+	if s.size-s.cur < n {
+		return false
+	}
+	if best := s.frontLocked(time.Now()); best != nil && s.effectivePriority(best, time.Now()) >= priority {
+		return false
+	}
+	s.cur += n
+	s.acquired++
+	return true
+}
+
+// Release releases the semaphore with a weight of n.
+func (s *Weighted) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+	s.notifyWaitersLocked()
+}
+
+// Capacity returns the maximum combined weight for concurrent access.
+func (s *Weighted) Capacity() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// SetCapacity sets a new maximum combined weight for concurrent access.
+// Shrinking the capacity does not revoke weights already held, the semaphore
+// admits no more waiters until enough weight is released.
+// Waiters of weights larger than the new capacity stop blocking the others,
+// and the ones fitting the new capacity are queued again in their order.
+func (s *Weighted) SetCapacity(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.size = n
+	for _, q := range s.waiters {
+		for e := q.Front(); e != nil; {
+			next := e.Next()
+			if w := e.Value.(*waiter); w.n > s.size {
+				s.removeLocked(q, e)
+				w.oversized = true
+				w.elem = s.oversized.PushBack(w)
+			}
+			e = next
+		}
+	}
+	for e := s.oversized.Front(); e != nil; {
+		next := e.Next()
+		if w := e.Value.(*waiter); w.n <= s.size {
+			s.oversized.Remove(e)
+			s.pushLocked(w)
+		}
+		e = next
+	}
+	s.notifyWaitersLocked()
+}
+
+// Stats returns a snapshot of the semaphore.
+func (s *Weighted) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := Stats{
+		Capacity:        s.size,
+		InUse:           s.cur,
+		Waiters:         s.queued + s.oversized.Len(),
+		QueueLength:     make(map[int]int, len(s.waiters)),
+		Acquired:        s.acquired,
+		Canceled:        s.canceled,
+		WaitDuration:    s.waitDuration,
+		MaxWaitDuration: s.maxWaitDuration,
+	}
+	for priority, q := range s.waiters {
+		stats.QueueLength[priority] = q.Len()
+	}
+	return stats
+}
+
+// effectivePriority returns the priority of w, promoted by the time it has waited.
+func (s *Weighted) effectivePriority(w *waiter, now time.Time) int {
+	if s.opts.AgingInterval <= 0 {
+		return w.priority
+	}
+	return w.priority + int(now.Sub(w.enqueued)/s.opts.AgingInterval)
+}
+
+// frontLocked returns the waiter to be admitted next, nil if no waiter is queued.
+// The oldest waiter of each priority class has the highest effective priority in its class,
+// so only the front of each class needs to be compared.
+func (s *Weighted) frontLocked(now time.Time) *waiter {
+	var best *waiter
+	var bestPriority int
+	for _, q := range s.waiters {
+		w := q.Front().Value.(*waiter)
+		p := s.effectivePriority(w, now)
+		if best == nil || p > bestPriority || (p == bestPriority && w.seq < best.seq) {
+			best, bestPriority = w, p
+		}
+	}
+	return best
+}
+
+// pushLocked queues w in the FIFO of its priority, in order of seq.
+func (s *Weighted) pushLocked(w *waiter) {
+	q, ok := s.waiters[w.priority]
+	if !ok {
+		q = list.New()
+		s.waiters[w.priority] = q
+	}
+	e := q.Back()
+	for e != nil && e.Value.(*waiter).seq > w.seq {
+		e = e.Prev()
+	}
+	if e == nil {
+		w.elem = q.PushFront(w)
+	} else {
+		w.elem = q.InsertAfter(w, e)
+	}
+	w.oversized = false
+	s.queued++
+}
+
+func (s *Weighted) removeLocked(q *list.List, elem *list.Element) {
+	w := q.Remove(elem).(*waiter)
+	s.queued--
+	if q.Len() == 0 && s.waiters[w.priority] == q {
+		delete(s.waiters, w.priority)
+	}
+}
+
+func (s *Weighted) notifyWaitersLocked() {
+	now := time.Now()
+	for {
+		w := s.frontLocked(now)
+		if w == nil {
+			break // No more waiters blocked.
+		}
+
+		if s.size-s.cur < w.n {
+			// Not enough tokens for the next waiter.  We could keep going (to try to
+			// find a waiter with a smaller request), but under load that could cause
+			// starvation for large requests; instead, we leave all remaining waiters
+			// blocked.
+			break
+		}
+
+		s.cur += w.n
+		s.acquired++
+		wait := now.Sub(w.enqueued)
+		s.waitDuration += wait
+		if wait > s.maxWaitDuration {
+			s.maxWaitDuration = wait
+		}
+		q := s.waiters[w.priority]
+		s.removeLocked(q, q.Front())
+		close(w.ready)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semaphore_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/sync/semaphore"
+)
+
+func TestWeightedPriorityOrder(t *testing.T) {
+	sem := semaphore.NewWeighted(1)
+	if err := sem.Acquire(context.Background(), 1, 0); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	for i, priority := range []int{0, 0, 2, 1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background(), 1, priority); err != nil {
+				t.Errorf("Acquire: %v", err)
+				return
+			}
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+			sem.Release(1)
+		}()
+		// wait until queued, to make FIFO order inside a class deterministic
+		for sem.Stats().Waiters != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	sem.Release(1)
+	wg.Wait()
+
+	want := []int{2, 4, 3, 0, 1}
+	if len(got) != len(want) {
+		t.Fatalf("admitted %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("admitted %v; want %v", got, want)
+		}
+	}
+}
+
+func TestWeightedAging(t *testing.T) {
+	sem := semaphore.NewWeighted(1, semaphore.WithWeightedAgingInterval(10*time.Millisecond))
+	if !sem.TryAcquire(1, 0) {
+		t.Fatal("TryAcquire = false; want true")
+	}
+	low := make(chan struct{})
+	go func() {
+		_ = sem.Acquire(context.Background(), 1, 0)
+		close(low)
+	}()
+	for sem.Stats().Waiters != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	high := make(chan struct{})
+	go func() {
+		_ = sem.Acquire(context.Background(), 1, 1)
+		close(high)
+	}()
+	for sem.Stats().Waiters != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	sem.Release(1)
+	select {
+	case <-low:
+	case <-high:
+		t.Fatal("aged low priority waiter was starved by a higher priority waiter")
+	}
+	sem.Release(1)
+	<-high
+}
+
+func TestWeightedSetCapacity(t *testing.T) {
+	sem := semaphore.NewWeighted(0)
+	if sem.TryAcquire(1, 0) {
+		t.Fatal("TryAcquire = true; want false")
+	}
+	done := make(chan error)
+	go func() { done <- sem.Acquire(context.Background(), 2, 0) }()
+	for sem.Stats().Waiters != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	sem.SetCapacity(2)
+	if err := <-done; err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if stats := sem.Stats(); stats.InUse != 2 || stats.Acquired != 1 {
+		t.Fatalf("Stats = %+v; want InUse 2 and Acquired 1", stats)
+	}
+}
+
+func TestWeightedAcquireCanceled(t *testing.T) {
+	sem := semaphore.NewWeighted(1)
+	if err := sem.Acquire(context.Background(), 1, 0); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx, 1, 0); err == nil {
+		t.Fatal("Acquire = nil; want context error")
+	}
+	stats := sem.Stats()
+	if stats.Waiters != 0 || stats.Canceled != 1 || len(stats.QueueLength) != 0 {
+		t.Fatalf("Stats = %+v; want no waiters and 1 canceled", stats)
+	}
+	sem.Release(1)
+	if !sem.TryAcquire(1, 0) {
+		t.Fatal("TryAcquire = false; want true")
+	}
+}
+
+func TestWeightedAcquireBypassLowerPriority(t *testing.T) {
+	sem := semaphore.NewWeighted(10)
+	if err := sem.Acquire(context.Background(), 5, 0); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	lowCtx, lowCancel := context.WithCancel(context.Background())
+	defer lowCancel()
+	lowDone := make(chan error, 1)
+	go func() { lowDone <- sem.Acquire(lowCtx, 10, 0) }()
+	for sem.Stats().Waiters != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// fits right now, and is not blocked by the waiter of lower priority
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sem.Acquire(ctx, 1, 10); err != nil {
+		t.Fatalf("Acquire of higher priority: %v", err)
+	}
+	lowCancel()
+	if err := <-lowDone; err == nil {
+		t.Fatal("Acquire of lower priority = nil; want context error")
+	}
+}
+
+func TestWeightedAcquireLargerThanCapacity(t *testing.T) {
+	sem := semaphore.NewWeighted(2)
+	if err := sem.Acquire(context.Background(), 2, 0); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	bigCtx, bigCancel := context.WithCancel(context.Background())
+	defer bigCancel()
+	bigDone := make(chan error, 1)
+	go func() { bigDone <- sem.Acquire(bigCtx, 3, 10) }()
+
+	smallDone := make(chan error, 1)
+	go func() { smallDone <- sem.Acquire(context.Background(), 1, 0) }()
+	for sem.Stats().Waiters != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	// the waiter doomed to fail is not queued, and blocks no waiter behind it
+	sem.Release(1)
+	select {
+	case err := <-smallDone:
+		if err != nil {
+			t.Fatalf("Acquire of smaller weight: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Acquire of smaller weight is blocked by a weight larger than the capacity")
+	}
+	bigCancel()
+	if err := <-bigDone; err != context.Canceled {
+		t.Fatalf("Acquire larger than the capacity = %v; want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by "go-option -type weighted"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package semaphore
+
+import "time"
+
+// A WeightedOption sets options.
+type WeightedOption interface {
+	apply(*weighted)
+}
+
+// EmptyWeightedOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyWeightedOption struct{}
+
+func (EmptyWeightedOption) apply(*weighted) {}
+
+// WeightedOptionFunc wraps a function that modifies weighted into an
+// implementation of the WeightedOption interface.
+type WeightedOptionFunc func(*weighted)
+
+func (f WeightedOptionFunc) apply(do *weighted) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *weighted) ApplyOptions(options ...WeightedOption) *weighted {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withWeighted sets weighted.
+func withWeighted(v weighted) WeightedOption {
+	return WeightedOptionFunc(func(o *weighted) {
+		*o = v
+	})
+}
+
+// WithWeightedAgingInterval sets AgingInterval in weighted.
+// A waiter is promoted by one priority class every AgingInterval it waits,
+// so that low priority waiters are not starved by a steady high priority load.
+// take effects if only AgingInterval is bigger than 0.
+func WithWeightedAgingInterval(v time.Duration) WeightedOption {
+	return WeightedOptionFunc(func(o *weighted) {
+		o.AgingInterval = v
+	})
+}
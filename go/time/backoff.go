@@ -177,6 +177,9 @@ type ExponentialBackOff struct {
 	// #reset()} after which {@link #NextBackOff()} returns {@link BackOff#STOP}.
 	// It takes no effect If maxElapsedCount < 0
 	maxElapsedCount int
+
+	// The clock to measure the elapsed time, RealClock if nil.
+	clock Clock
 }
 
 func (o *ExponentialBackOff) SetDefault() {
@@ -225,7 +228,7 @@ func NewGrpcExponentialBackOff(opts ...ExponentialBackOffOption) *ExponentialBac
 func (o *ExponentialBackOff) Reset() {
 	o.currentInterval = o.initialInterval
 	o.currentCount = 0
-	o.startTime = time.Now()
+	o.startTime = o.GetClock().Now()
 }
 
 // NextBackOff This method calculates the next back off interval using the formula: randomized_interval =
@@ -295,7 +298,13 @@ func (o *ExponentialBackOff) GetMaxElapsedDuration() time.Duration {
 // created and is reset when {@link #reset()} is called.
 // The elapsed time is computed using {@link System#nanoTime()}.
 func (o *ExponentialBackOff) GetElapsedDuration() time.Duration {
-	return time.Now().Sub(o.startTime)
+	return o.GetClock().Now().Sub(o.startTime)
+}
+
+// GetClock Returns the clock to measure the elapsed time, and to wait for the back off
+// by BackoffUntil.
+func (o *ExponentialBackOff) GetClock() Clock {
+	return ClockOrDefault(o.clock)
 }
 
 // GetElapsedCount Returns the elapsed count since an {@link ExponentialBackOff} instance is
@@ -0,0 +1,164 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// FullJitterBackOff returns a back-off policy whose back-off time is chosen uniformly from
+// [0, min(maxInterval, initialInterval * multiplier^attempt)].
+// Full jitter spreads retries of many clients the most, at the cost of some short waits.
+// It takes no effect If maxInterval < 0
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/ for more about jitter.
+func FullJitterBackOff(initialInterval, maxInterval time.Duration, multiplier float64) *fullJitterBackOff {
+	return &fullJitterBackOff{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+		multiplier:      multiplier,
+	}
+}
+
+type fullJitterBackOff struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+
+	attempt int
+}
+
+func (o *fullJitterBackOff) Reset() { o.attempt = 0 }
+func (o *fullJitterBackOff) NextBackOff() (backoff time.Duration, ok bool) {
+	interval := float64(o.initialInterval) * math.Pow(o.multiplier, float64(o.attempt))
+	if o.maxInterval >= 0 && interval > float64(o.maxInterval) {
+		interval = float64(o.maxInterval)
+	}
+	if interval > float64(InfDuration) {
+		interval = float64(InfDuration)
+	}
+	o.attempt++
+	return time.Duration(rand.Float64() * interval), true
+}
+
+// DecorrelatedJitterBackOff returns a back-off policy whose back-off time is chosen uniformly from
+// [initialInterval, 3 * previous back-off time], capped by maxInterval.
+// Each back-off time grows from the previous one instead of the attempt count,
+// which decorrelates retries of many clients.
+// It takes no effect If maxInterval < 0
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/ for more about jitter.
+func DecorrelatedJitterBackOff(initialInterval, maxInterval time.Duration) *decorrelatedJitterBackOff {
+	return &decorrelatedJitterBackOff{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+		sleep:           initialInterval,
+	}
+}
+
+type decorrelatedJitterBackOff struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+
+	sleep time.Duration
+}
+
+func (o *decorrelatedJitterBackOff) Reset() { o.sleep = o.initialInterval }
+func (o *decorrelatedJitterBackOff) NextBackOff() (backoff time.Duration, ok bool) {
+	lo, hi := float64(o.initialInterval), 3*float64(o.sleep)
+	if hi < lo {
+		hi = lo
+	}
+	sleep := lo + rand.Float64()*(hi-lo)
+	if o.maxInterval >= 0 && sleep > float64(o.maxInterval) {
+		sleep = float64(o.maxInterval)
+	}
+	if sleep > float64(InfDuration) {
+		sleep = float64(InfDuration)
+	}
+	o.sleep = time.Duration(sleep)
+	return o.sleep, true
+}
+
+// FibonacciBackOff returns a back-off policy whose back-off time grows as the Fibonacci sequence,
+// initialInterval, initialInterval, 2*initialInterval, 3*initialInterval, 5*initialInterval..., capped by maxInterval.
+// It grows slower than an exponential back-off with multiplier 2.
+// It takes no effect If maxInterval < 0
+func FibonacciBackOff(initialInterval, maxInterval time.Duration) *fibonacciBackOff {
+	o := &fibonacciBackOff{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+	}
+	o.Reset()
+	return o
+}
+
+type fibonacciBackOff struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+
+	prev, curr time.Duration
+}
+
+func (o *fibonacciBackOff) Reset() { o.prev, o.curr = 0, o.initialInterval }
+func (o *fibonacciBackOff) NextBackOff() (backoff time.Duration, ok bool) {
+	backoff = o.curr
+	if o.maxInterval >= 0 && backoff >= o.maxInterval {
+		return o.maxInterval, true
+	}
+	next := o.prev + o.curr
+	if next < o.curr { // overflow
+		next = InfDuration
+	}
+	o.prev, o.curr = o.curr, next
+	return backoff, true
+}
+
+// MaxAttemptsBackOff wraps a back-off policy which stops after maxAttempts back-offs.
+// The count is reset by Reset.
+func MaxAttemptsBackOff(backoff BackOff, maxAttempts int) *maxAttemptsBackOff {
+	return &maxAttemptsBackOff{backoff: backoff, maxAttempts: maxAttempts}
+}
+
+type maxAttemptsBackOff struct {
+	backoff     BackOff
+	maxAttempts int
+
+	attempts int
+}
+
+func (o *maxAttemptsBackOff) Reset() {
+	o.attempts = 0
+	o.backoff.Reset()
+}
+func (o *maxAttemptsBackOff) NextBackOff() (backoff time.Duration, ok bool) {
+	if o.attempts >= o.maxAttempts {
+		return 0, false
+	}
+	o.attempts++
+	return o.backoff.NextBackOff()
+}
+
+// DeadlineBackOff wraps a back-off policy which stops if the back-off would end after deadline.
+func DeadlineBackOff(backoff BackOff, deadline time.Time) *deadlineBackOff {
+	return &deadlineBackOff{backoff: backoff, deadline: deadline}
+}
+
+type deadlineBackOff struct {
+	backoff  BackOff
+	deadline time.Time
+}
+
+func (o *deadlineBackOff) Reset() { o.backoff.Reset() }
+func (o *deadlineBackOff) NextBackOff() (backoff time.Duration, ok bool) {
+	backoff, ok = o.backoff.NextBackOff()
+	if !ok {
+		return backoff, false
+	}
+	if time.Now().Add(backoff).After(o.deadline) {
+		return backoff, false
+	}
+	return backoff, true
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestFibonacciBackOff(t *testing.T) {
+	b := time_.MaxAttemptsBackOff(time_.FibonacciBackOff(time.Second, 6*time.Second), 7)
+	want := []time.Duration{1, 1, 2, 3, 5, 6, 6}
+	for i, w := range want {
+		got, ok := b.NextBackOff()
+		if !ok || got != w*time.Second {
+			t.Fatalf("#%d: NextBackOff() = %v, %t; want %v, true", i, got, ok, w*time.Second)
+		}
+	}
+	if _, ok := b.NextBackOff(); ok {
+		t.Fatal("NextBackOff() = true after max attempts; want false")
+	}
+	b.Reset()
+	if got, ok := b.NextBackOff(); !ok || got != time.Second {
+		t.Fatalf("NextBackOff() = %v, %t after Reset; want %v, true", got, ok, time.Second)
+	}
+}
+
+func TestFullJitterBackOff(t *testing.T) {
+	b := time_.FullJitterBackOff(time.Second, 4*time.Second, 2)
+	caps := []time.Duration{1, 2, 4, 4}
+	for i, c := range caps {
+		got, ok := b.NextBackOff()
+		if !ok || got < 0 || got > c*time.Second {
+			t.Fatalf("#%d: NextBackOff() = %v, %t; want in [0, %v], true", i, got, ok, c*time.Second)
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackOff(t *testing.T) {
+	b := time_.DecorrelatedJitterBackOff(time.Second, 10*time.Second)
+	prev := time.Second
+	for i := 0; i < 20; i++ {
+		got, ok := b.NextBackOff()
+		if !ok || got < time.Second || got > min(3*prev, 10*time.Second) {
+			t.Fatalf("#%d: NextBackOff() = %v, %t; want in [%v, %v], true", i, got, ok, time.Second, min(3*prev, 10*time.Second))
+		}
+		prev = got
+	}
+}
+
+func TestDeadlineBackOff(t *testing.T) {
+	b := time_.DeadlineBackOff(time_.FibonacciBackOff(time.Second, -1), time.Now().Add(90*time.Second))
+	// no time elapses between back-offs here, the last one ending before the deadline is 89s
+	var last time.Duration
+	for {
+		got, ok := b.NextBackOff()
+		if !ok {
+			break
+		}
+		last = got
+	}
+	if last != 89*time.Second {
+		t.Fatalf("last NextBackOff() = %v; want %v before the deadline", last, 89*time.Second)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	b := time_.NewRetryBudget(0.5, 0, time.Minute)
+	if b.TryWithdraw() {
+		t.Fatal("TryWithdraw() = true without requests; want false")
+	}
+	for i := 0; i < 4; i++ {
+		b.Deposit()
+	}
+	if got := b.Balance(); got != 2 {
+		t.Fatalf("Balance() = %d; want 2", got)
+	}
+	if !b.TryWithdraw() || !b.TryWithdraw() {
+		t.Fatal("TryWithdraw() = false within budget; want true")
+	}
+	if b.TryWithdraw() {
+		t.Fatal("TryWithdraw() = true over budget; want false")
+	}
+}
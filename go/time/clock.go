@@ -0,0 +1,289 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time and creates timers, so that code driven by time can be
+// tested deterministically by injecting a FakeClock instead of the RealClock.
+type Clock interface {
+	// Now returns the current time, see time.Now.
+	Now() time.Time
+	// NewTimer creates a new ClockTimer that will send the current time on its channel
+	// after at least duration d, see time.NewTimer.
+	NewTimer(d time.Duration) ClockTimer
+	// NewTicker returns a new ClockTicker containing a channel that will send the current
+	// time on the channel after each tick, see time.NewTicker.
+	NewTicker(d time.Duration) ClockTicker
+	// AfterFunc waits for the duration to elapse and then calls f in its own goroutine,
+	// see time.AfterFunc. The C method of the returned ClockTimer returns nil.
+	AfterFunc(d time.Duration, f func()) ClockTimer
+	// Sleep pauses the current goroutine for at least the duration d, see time.Sleep.
+	Sleep(d time.Duration)
+}
+
+// ClockTimer is a single event created by a Clock, see time.Timer.
+type ClockTimer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the ClockTimer from firing, see time.Timer.Stop.
+	Stop() bool
+	// Reset changes the timer to expire after duration d, see time.Timer.Reset.
+	Reset(d time.Duration) bool
+}
+
+// ClockTicker delivers ticks of a clock at intervals, see time.Ticker.
+type ClockTicker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker, see time.Ticker.Stop.
+	Stop()
+	// Reset stops the ticker and resets its period to the specified duration,
+	// see time.Ticker.Reset.
+	Reset(d time.Duration)
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+var _ Clock = RealClock{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) NewTimer(d time.Duration) ClockTimer { return realTimer{time.NewTimer(d)} }
+
+func (RealClock) NewTicker(d time.Duration) ClockTicker { return realTicker{time.NewTicker(d)} }
+
+func (RealClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time   { return t.t.C }
+func (t realTicker) Stop()                 { t.t.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.t.Reset(d) }
+
+// ClockOrDefault returns c, or RealClock if c is nil.
+func ClockOrDefault(c Clock) Clock {
+	if c == nil {
+		return RealClock{}
+	}
+	return c
+}
+
+// FakeClock is a Clock whose time only moves when told to, by Step or SetTime.
+// Timers, tickers and sleeps created by a FakeClock fire as the fake time passes their
+// deadlines, which makes tests of time driven code deterministic.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond // broadcast when waiters change
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+var _ Clock = (*FakeClock)(nil)
+
+// fakeWaiter is a timer, ticker or sleep pending on a FakeClock.
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	period   time.Duration // ticks every period if positive
+	c        chan time.Time
+	f        func()
+}
+
+// NewFakeClock returns a FakeClock set to t.
+func NewFakeClock(t time.Time) *FakeClock {
+	c := &FakeClock{now: t}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer creates a timer firing once the fake time passes d from now.
+func (c *FakeClock) NewTimer(d time.Duration) ClockTimer {
+	w := &fakeWaiter{clock: c, c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(w, d)
+	return w
+}
+
+// NewTicker creates a ticker firing every time the fake time passes d.
+// The duration d must be greater than zero; if not, NewTicker will panic.
+func (c *FakeClock) NewTicker(d time.Duration) ClockTicker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{clock: c, period: d, c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(w, d)
+	return fakeTicker{w}
+}
+
+// AfterFunc calls f in its own goroutine once the fake time passes d from now.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	w := &fakeWaiter{clock: c, f: f}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(w, d)
+	return w
+}
+
+// Sleep blocks until the fake time passes d from now.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// Step moves the fake time forward by d, firing the timers and tickers due.
+func (c *FakeClock) Step(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setTimeLocked(c.now.Add(d))
+}
+
+// SetTime sets the fake time to t, firing the timers and tickers due.
+func (c *FakeClock) SetTime(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setTimeLocked(t)
+}
+
+// Waiters returns the number of pending timers, tickers and sleeps.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until there are at least n pending timers, tickers and sleeps,
+// so that a test can Step the clock only after the code under test waits on it.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) addLocked(w *fakeWaiter, d time.Duration) {
+	w.deadline = c.now.Add(d)
+	if d <= 0 && w.period <= 0 {
+		w.fireLocked(c.now)
+		return
+	}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+func (c *FakeClock) setTimeLocked(t time.Time) {
+	c.now = t
+	// fire in order of deadlines, as the real clock does
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+	var pending []*fakeWaiter
+	for _, w := range c.waiters {
+		if w.deadline.After(t) {
+			pending = append(pending, w)
+			continue
+		}
+		w.fireLocked(t)
+		if w.period > 0 {
+			// ticks are dropped to make up for slow receivers, as time.Ticker does
+			for !w.deadline.After(t) {
+				w.deadline = w.deadline.Add(w.period)
+			}
+			pending = append(pending, w)
+		}
+	}
+	c.waiters = pending
+	c.cond.Broadcast()
+}
+
+func (w *fakeWaiter) fireLocked(now time.Time) {
+	if w.f != nil {
+		go w.f()
+		return
+	}
+	select {
+	case w.c <- now:
+	default:
+	}
+}
+
+func (w *fakeWaiter) C() <-chan time.Time { return w.c }
+
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeLocked(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.clock.removeLocked(w)
+	w.drainLocked()
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.addLocked(w, d)
+	return active
+}
+
+// fakeTicker is a fakeWaiter with the methods of ClockTicker.
+type fakeTicker struct{ *fakeWaiter }
+
+func (t fakeTicker) Stop() { t.fakeWaiter.Stop() }
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.fakeWaiter.Reset(d)
+}
+
+// drainLocked discards a stale value, as time.Timer.Reset does since go1.23.
+func (w *fakeWaiter) drainLocked() {
+	if w.c == nil {
+		return
+	}
+	select {
+	case <-w.c:
+	default:
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := time_.NewFakeClock(start)
+
+	timer := clock.NewTimer(time.Second)
+	clock.Step(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+	clock.Step(time.Millisecond)
+	select {
+	case now := <-timer.C():
+		if want := start.Add(time.Second); !now.Equal(want) {
+			t.Fatalf("timer fired at %v; want %v", now, want)
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+	if timer.Stop() {
+		t.Fatal("Stop of an expired timer = true; want false")
+	}
+	if timer.Reset(time.Second) {
+		t.Fatal("Reset of an expired timer = true; want false")
+	}
+	if !timer.Stop() {
+		t.Fatal("Stop of an active timer = false; want true")
+	}
+	clock.Step(time.Hour)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+	if n := clock.Waiters(); n != 0 {
+		t.Fatalf("Waiters() = %d; want 0", n)
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := time_.NewFakeClock(time.Time{})
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		clock.Step(time.Second)
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("tick %d is not delivered", i)
+		}
+	}
+
+	// ticks are dropped for slow receivers
+	clock.Step(5 * time.Second)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("dropped tick is delivered")
+	default:
+	}
+
+	ticker.Reset(time.Minute)
+	clock.Step(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("reset ticker ticked early")
+	default:
+	}
+}
+
+func TestFakeClockSleepAndAfterFunc(t *testing.T) {
+	clock := time_.NewFakeClock(time.Time{})
+
+	fired := make(chan struct{})
+	clock.AfterFunc(time.Minute, func() { close(fired) })
+
+	slept := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Hour)
+		close(slept)
+	}()
+
+	clock.BlockUntil(2)
+	clock.Step(time.Minute)
+	<-fired
+	select {
+	case <-slept:
+		t.Fatal("Sleep returned early")
+	default:
+	}
+	clock.Step(time.Hour)
+	<-slept
+}
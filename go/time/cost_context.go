@@ -0,0 +1,62 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	runtime_ "github.com/searKing/golang/go/runtime"
+)
+
+type costTickContextKey struct{}
+
+// costTickHolder guards a CostTick shared by goroutines handling the same request.
+type costTickHolder struct {
+	mu   sync.Mutex
+	tick CostTick
+}
+
+// WithCostTick returns a copy of ctx carrying a new CostTick, which accumulates checkpoints
+// by TickContext, such as phases of a request, from any goroutine.
+func WithCostTick(ctx context.Context) context.Context {
+	return context.WithValue(ctx, costTickContextKey{}, &costTickHolder{})
+}
+
+// CostTickFromContext returns a snapshot of the CostTick carried by ctx,
+// or false if ctx carries none.
+func CostTickFromContext(ctx context.Context) (CostTick, bool) {
+	h, ok := ctx.Value(costTickContextKey{}).(*costTickHolder)
+	if !ok {
+		return CostTick{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return CostTick{
+		points:   slices.Clone(h.tick.points),
+		messages: slices.Clone(h.tick.messages),
+		costs:    slices.Clone(h.tick.costs),
+		Lesser:   h.tick.Lesser,
+	}, true
+}
+
+// TickContext records a checkpoint named msg into the CostTick carried by ctx,
+// it does nothing if ctx carries none.
+// The caller's function, file and line are used if msg is empty.
+func TickContext(ctx context.Context, msg string) {
+	h, ok := ctx.Value(costTickContextKey{}).(*costTickHolder)
+	if !ok {
+		return
+	}
+	if msg == "" {
+		caller, file, line := runtime_.GetShortCallerFuncFileLine(2)
+		msg = fmt.Sprintf("%s() %s:%d", caller, file, line)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tick.Tick(msg)
+}
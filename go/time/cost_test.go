@@ -5,7 +5,10 @@
 package time_test
 
 import (
+	"context"
 	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -76,3 +79,36 @@ func TestCostTick_Sort(t *testing.T) {
 		}
 	}
 }
+
+func TestCostTickContext(t *testing.T) {
+	ctx := context.Background()
+	time_.TickContext(ctx, "ignored")
+	if _, ok := time_.CostTickFromContext(ctx); ok {
+		t.Fatal("CostTickFromContext of a bare context = true; want false")
+	}
+
+	ctx = time_.WithCostTick(ctx)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time_.TickContext(ctx, "tick")
+		}()
+	}
+	wg.Wait()
+	time_.TickContext(ctx, "")
+
+	cost, ok := time_.CostTickFromContext(ctx)
+	if !ok || cost.Len() != 11 {
+		t.Fatalf("CostTickFromContext = %d ticks, %t; want 11, true", cost.Len(), ok)
+	}
+	var last string
+	cost.Walk(func(idx int, msg string, _ time.Duration, _ time.Time) bool {
+		last = msg
+		return true
+	})
+	if !strings.Contains(last, "TestCostTickContext") {
+		t.Fatalf("message of the empty tick = %q; want the caller", last)
+	}
+}
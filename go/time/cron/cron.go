@@ -0,0 +1,415 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cron implements a calendar based job scheduler, driven by crontab specs
+// evaluated in an IANA time zone.
+package cron
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OverlapPolicy controls what happens when a job is due while its previous run is still running.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip drops the run, if the previous run is still running.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue delays the run until the previous run returns, runs are executed one by one.
+	OverlapQueue
+	// OverlapConcurrent starts the run anyway, runs are executed concurrently.
+	OverlapConcurrent
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapSkip:
+		return "skip"
+	case OverlapQueue:
+		return "queue"
+	case OverlapConcurrent:
+		return "concurrent"
+	default:
+		return "unknown"
+	}
+}
+
+// Leader reports whether this process is the leader, only the leader runs jobs.
+// *leaderelection.LeaderElector satisfies Leader.
+type Leader interface {
+	IsLeader() bool
+}
+
+// EntryID identifies an entry within a Cron instance.
+type EntryID int
+
+//go:generate go-option -type "cron"
+type cron struct {
+	// Location is the time zone specs are evaluated in, time.Local if nil.
+	// A spec can override it with a "CRON_TZ=" prefix.
+	Location *time.Location
+	// Jitter delays every run by a random duration in [0, Jitter), to spread load
+	// of jobs scheduled at the same time.
+	// take effects if only Jitter is bigger than 0.
+	Jitter time.Duration
+	// Leader restricts runs to the leader, jobs due on a follower are dropped.
+	// take effects if only Leader is not nil.
+	Leader Leader
+	// Logger logs skipped runs and panics of jobs, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+//go:generate go-option -type "job"
+type job struct {
+	// Name of the job, used in logs.
+	Name string
+	// OverlapPolicy controls what happens when the job is due while its previous run is still running.
+	OverlapPolicy OverlapPolicy
+	// Jitter overrides the Jitter of Cron for this job.
+	// take effects if only Jitter is bigger than 0.
+	Jitter time.Duration
+	// MaxCatchUp is the maximum number of missed activations run late, in addition to the latest one.
+	// Activations are missed if the process is suspended, or restarted with LastRun.
+	// All missed activations are coalesced into a single run if MaxCatchUp is 0.
+	MaxCatchUp int
+	// LastRun is the time the job was activated last, such as before a restart.
+	// Activations after LastRun are caught up according to MaxCatchUp.
+	// take effects if only LastRun is not zero.
+	LastRun time.Time
+}
+
+// Entry is a snapshot of a job scheduled in a Cron.
+type Entry struct {
+	ID       EntryID
+	Name     string
+	Schedule Schedule
+	// Next is the time the job will run next, or the zero time if the schedule is exhausted.
+	Next time.Time
+	// Prev is the time the job was activated last, or the zero time if never.
+	Prev time.Time
+}
+
+type entry struct {
+	id       EntryID
+	schedule Schedule
+	fn       func(ctx context.Context)
+	opts     job
+
+	next time.Time
+	prev time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	running int // runs executing now
+	pending int // runs queued by OverlapQueue
+}
+
+// Cron keeps track of any number of entries, invoking the associated func as
+// specified by the schedule.
+type Cron struct {
+	opts cron
+
+	mu      sync.Mutex
+	entries map[EntryID]*entry
+	nextID  EntryID
+	change  chan struct{}
+	ctx     context.Context // ctx of Run, nil if not running
+
+	wg sync.WaitGroup
+}
+
+// New returns a new Cron job runner.
+func New(opts ...CronOption) *Cron {
+	c := &Cron{
+		entries: make(map[EntryID]*entry),
+		change:  make(chan struct{}, 1),
+	}
+	c.opts.ApplyOptions(opts...)
+	return c
+}
+
+// AddFunc adds a func to the Cron to be run on the given spec, see ParseInLocation for the spec.
+// The spec is evaluated in the Location of the Cron, unless overridden by a "CRON_TZ=" prefix.
+func (c *Cron) AddFunc(spec string, f func(ctx context.Context), opts ...JobOption) (EntryID, error) {
+	loc := c.opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	schedule, err := ParseInLocation(spec, loc)
+	if err != nil {
+		return 0, err
+	}
+	return c.Schedule(schedule, f, opts...), nil
+}
+
+// Schedule adds a func to the Cron to be run on the given schedule.
+// The job may be added to a running Cron.
+func (c *Cron) Schedule(schedule Schedule, f func(ctx context.Context), opts ...JobOption) EntryID {
+	e := &entry{schedule: schedule, fn: f}
+	e.opts.ApplyOptions(opts...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	e.id = c.nextID
+	if !e.opts.LastRun.IsZero() {
+		e.prev = e.opts.LastRun
+		e.next = schedule.Next(e.opts.LastRun)
+	} else {
+		e.next = schedule.Next(time.Now())
+	}
+	if c.ctx != nil {
+		e.ctx, e.cancel = context.WithCancel(c.ctx)
+	}
+	c.entries[e.id] = e
+	c.notifyLocked()
+	return e.id
+}
+
+// Remove removes an entry from being run in the future.
+// The context of its running runs is canceled.
+func (c *Cron) Remove(id EntryID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok {
+		return
+	}
+	delete(c.entries, id)
+	if e.cancel != nil {
+		e.cancel()
+	}
+	c.notifyLocked()
+}
+
+// Entry returns a snapshot of the given entry, or false if it couldn't be found.
+func (c *Cron) Entry(id EntryID) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok {
+		return Entry{}, false
+	}
+	return e.snapshot(), true
+}
+
+// Entries returns a snapshot of the cron entries, ordered by the time they run next.
+func (c *Cron) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, e.snapshot())
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Next.IsZero() || entries[j].Next.IsZero() {
+			return !entries[i].Next.IsZero()
+		}
+		if !entries[i].Next.Equal(entries[j].Next) {
+			return entries[i].Next.Before(entries[j].Next)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// Run runs the scheduler until ctx is done, then waits for running jobs to return.
+// The ctx passed to jobs is canceled when ctx is done, or the job is removed.
+func (c *Cron) Run(ctx context.Context) {
+	c.mu.Lock()
+	if c.ctx != nil {
+		c.mu.Unlock()
+		panic("cron: Run called twice")
+	}
+	c.ctx = ctx
+	for _, e := range c.entries {
+		e.ctx, e.cancel = context.WithCancel(ctx)
+	}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.ctx = nil
+		for _, e := range c.entries {
+			e.cancel()
+			e.ctx, e.cancel = nil, nil
+		}
+		c.mu.Unlock()
+		c.wg.Wait()
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		now := time.Now()
+		c.runDueLocked(now)
+		next := c.nextLocked()
+		c.mu.Unlock()
+
+		var timeC <-chan time.Time
+		if !next.IsZero() {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(next.Sub(now))
+			timeC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeC:
+		case <-c.change:
+		}
+	}
+}
+
+// notifyLocked wakes up Run to reevaluate the entries.
+func (c *Cron) notifyLocked() {
+	select {
+	case c.change <- struct{}{}:
+	default:
+	}
+}
+
+// nextLocked returns the earliest activation time of all entries, zero if none.
+func (c *Cron) nextLocked() time.Time {
+	var next time.Time
+	for _, e := range c.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		if next.IsZero() || e.next.Before(next) {
+			next = e.next
+		}
+	}
+	return next
+}
+
+// runDueLocked dispatches all entries due at now, and advances their schedules.
+func (c *Cron) runDueLocked(now time.Time) {
+	for _, e := range c.entries {
+		if e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+		due := 0
+		for !e.next.IsZero() && !e.next.After(now) {
+			due++
+			e.prev = e.next
+			e.next = e.schedule.Next(e.next)
+			if due > e.opts.MaxCatchUp && !e.next.IsZero() && !e.next.After(now) {
+				// the remaining missed activations are dropped, skip them in one go
+				c.logger().Warn("cron: missed activations dropped",
+					slog.Int("id", int(e.id)), slog.String("name", e.opts.Name), slog.Time("since", e.next))
+				e.next = e.schedule.Next(now)
+			}
+		}
+		runs := 1 + min(due-1, max(e.opts.MaxCatchUp, 0))
+		if c.opts.Leader != nil && !c.opts.Leader.IsLeader() {
+			c.logger().Debug("cron: run dropped, not the leader",
+				slog.Int("id", int(e.id)), slog.String("name", e.opts.Name))
+			continue
+		}
+		c.dispatch(e, runs)
+	}
+}
+
+// dispatch starts runs of e according to its OverlapPolicy.
+func (c *Cron) dispatch(e *entry, runs int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch e.opts.OverlapPolicy {
+	case OverlapConcurrent:
+		for range runs {
+			e.running++
+			c.wg.Add(1)
+			go c.run(e.ctx, e, 1)
+		}
+	case OverlapQueue:
+		e.pending += runs
+		if e.running == 0 {
+			e.running++
+			c.wg.Add(1)
+			go c.run(e.ctx, e, 0)
+		}
+	default:
+		if e.running > 0 {
+			c.logger().Info("cron: run skipped, previous run is still running",
+				slog.Int("id", int(e.id)), slog.String("name", e.opts.Name))
+			return
+		}
+		e.running++
+		c.wg.Add(1)
+		go c.run(e.ctx, e, runs)
+	}
+}
+
+// run executes runs of e one by one, and then drains the runs queued by OverlapQueue.
+func (c *Cron) run(ctx context.Context, e *entry, runs int) {
+	defer c.wg.Done()
+	for {
+		for range runs {
+			c.runOnce(ctx, e)
+		}
+		e.mu.Lock()
+		runs, e.pending = e.pending, 0
+		if runs == 0 || ctx.Err() != nil {
+			e.running--
+			e.mu.Unlock()
+			return
+		}
+		e.mu.Unlock()
+	}
+}
+
+// runOnce executes e once after the jitter, recovering from panics.
+func (c *Cron) runOnce(ctx context.Context, e *entry) {
+	jitter := c.opts.Jitter
+	if e.opts.Jitter > 0 {
+		jitter = e.opts.Jitter
+	}
+	if jitter > 0 {
+		timer := time.NewTimer(time.Duration(rand.Int63n(int64(jitter))))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger().Error("cron: panic running job",
+				slog.Int("id", int(e.id)), slog.String("name", e.opts.Name),
+				slog.Any("panic", r), slog.String("stack", string(debug.Stack())))
+		}
+	}()
+	e.fn(ctx)
+}
+
+func (c *Cron) logger() *slog.Logger {
+	if c.opts.Logger != nil {
+		return c.opts.Logger
+	}
+	return slog.Default()
+}
+
+func (e *entry) snapshot() Entry {
+	return Entry{ID: e.id, Name: e.opts.Name, Schedule: e.schedule, Next: e.next, Prev: e.prev}
+}
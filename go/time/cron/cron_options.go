@@ -0,0 +1,84 @@
+// Code generated by "go-option -type cron"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package cron
+
+import (
+	"log/slog"
+	"time"
+)
+
+// A CronOption sets options.
+type CronOption interface {
+	apply(*cron)
+}
+
+// EmptyCronOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyCronOption struct{}
+
+func (EmptyCronOption) apply(*cron) {}
+
+// CronOptionFunc wraps a function that modifies cron into an
+// implementation of the CronOption interface.
+type CronOptionFunc func(*cron)
+
+func (f CronOptionFunc) apply(do *cron) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *cron) ApplyOptions(options ...CronOption) *cron {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withCron sets cron.
+func withCron(v cron) CronOption {
+	return CronOptionFunc(func(o *cron) {
+		*o = v
+	})
+}
+
+// WithCronLocation sets Location in cron.
+// Location is the time zone specs are evaluated in, time.Local if nil.
+// A spec can override it with a "CRON_TZ=" prefix.
+func WithCronLocation(v *time.Location) CronOption {
+	return CronOptionFunc(func(o *cron) {
+		o.Location = v
+	})
+}
+
+// WithCronJitter sets Jitter in cron.
+// Jitter delays every run by a random duration in [0, Jitter), to spread load
+// of jobs scheduled at the same time.
+// take effects if only Jitter is bigger than 0.
+func WithCronJitter(v time.Duration) CronOption {
+	return CronOptionFunc(func(o *cron) {
+		o.Jitter = v
+	})
+}
+
+// WithCronLeader sets Leader in cron.
+// Leader restricts runs to the leader, jobs due on a follower are dropped.
+// take effects if only Leader is not nil.
+func WithCronLeader(v Leader) CronOption {
+	return CronOptionFunc(func(o *cron) {
+		o.Leader = v
+	})
+}
+
+// WithCronLogger sets Logger in cron.
+// Logger logs skipped runs and panics of jobs, slog.Default() if nil.
+func WithCronLogger(v *slog.Logger) CronOption {
+	return CronOptionFunc(func(o *cron) {
+		o.Logger = v
+	})
+}
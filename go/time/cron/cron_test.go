@@ -0,0 +1,194 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cron_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/time/cron"
+)
+
+// every activates every d, unlike cron.Every it is not rounded to seconds.
+type every time.Duration
+
+func (d every) Next(t time.Time) time.Time { return t.Add(time.Duration(d)) }
+
+type leader atomic.Bool
+
+func (l *leader) IsLeader() bool { return (*atomic.Bool)(l).Load() }
+
+func runCron(t *testing.T, c *cron.Cron, d time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	c.Run(ctx)
+}
+
+func TestCronOverlapPolicy(t *testing.T) {
+	tests := []struct {
+		policy  cron.OverlapPolicy
+		wantMin int32
+		wantMax int32
+		maxPar  int32
+	}{
+		// runs every 10ms, each run lasts 35ms, during 200ms
+		{cron.OverlapSkip, 3, 6, 1},
+		{cron.OverlapQueue, 4, 7, 1},
+		{cron.OverlapConcurrent, 12, 21, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var runs, running, maxRunning atomic.Int32
+			c := cron.New()
+			c.Schedule(every(10*time.Millisecond), func(ctx context.Context) {
+				runs.Add(1)
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(35 * time.Millisecond)
+			}, cron.WithJobOverlapPolicy(tt.policy))
+			runCron(t, c, 200*time.Millisecond)
+
+			if got := runs.Load(); got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("runs = %d; want [%d, %d]", got, tt.wantMin, tt.wantMax)
+			}
+			if got := maxRunning.Load(); got > tt.maxPar {
+				t.Errorf("max concurrent runs = %d; want <= %d", got, tt.maxPar)
+			}
+			if got := running.Load(); got != 0 {
+				t.Errorf("Run returned with %d runs running", got)
+			}
+		})
+	}
+}
+
+func TestCronCatchUp(t *testing.T) {
+	tests := []struct {
+		maxCatchUp int
+		want       int32
+	}{
+		{0, 1},
+		{3, 4},
+		{100, 10},
+	}
+	for _, tt := range tests {
+		var runs atomic.Int32
+		c := cron.New()
+		// 10 activations missed since the last run
+		c.Schedule(every(time.Hour), func(ctx context.Context) { runs.Add(1) },
+			cron.WithJobOverlapPolicy(cron.OverlapQueue),
+			cron.WithJobMaxCatchUp(tt.maxCatchUp),
+			cron.WithJobLastRun(time.Now().Add(-10*time.Hour-time.Minute)))
+		runCron(t, c, 50*time.Millisecond)
+		if got := runs.Load(); got != tt.want {
+			t.Errorf("MaxCatchUp %d: runs = %d; want %d", tt.maxCatchUp, got, tt.want)
+		}
+		entries := c.Entries()
+		if len(entries) != 1 || !entries[0].Next.After(time.Now()) {
+			t.Errorf("MaxCatchUp %d: Entries = %+v; want next run in future", tt.maxCatchUp, entries)
+		}
+	}
+}
+
+func TestCronLeader(t *testing.T) {
+	var l leader
+	var runs atomic.Int32
+	c := cron.New(cron.WithCronLeader(&l))
+	c.Schedule(every(5*time.Millisecond), func(ctx context.Context) { runs.Add(1) })
+	runCron(t, c, 50*time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("follower runs = %d; want 0", got)
+	}
+
+	(*atomic.Bool)(&l).Store(true)
+	c = cron.New(cron.WithCronLeader(&l))
+	c.Schedule(every(5*time.Millisecond), func(ctx context.Context) { runs.Add(1) })
+	runCron(t, c, 50*time.Millisecond)
+	if got := runs.Load(); got == 0 {
+		t.Fatal("leader runs = 0; want > 0")
+	}
+}
+
+func TestCronCancel(t *testing.T) {
+	c := cron.New()
+	started := make(chan struct{})
+	var once sync.Once
+	c.Schedule(every(time.Millisecond), func(ctx context.Context) {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+	}, cron.WithJobOverlapPolicy(cron.OverlapConcurrent))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+	<-started
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after ctx is canceled")
+	}
+}
+
+func TestCronRemove(t *testing.T) {
+	c := cron.New()
+	var runs atomic.Int32
+	canceled := make(chan struct{})
+	id := c.Schedule(every(time.Millisecond), func(ctx context.Context) {
+		if runs.Add(1) == 1 {
+			<-ctx.Done()
+			close(canceled)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+	for runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Remove(id)
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("ctx of the removed job is not canceled")
+	}
+	if _, ok := c.Entry(id); ok {
+		t.Fatal("Entry of the removed job is found")
+	}
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("runs = %d; want 1", got)
+	}
+}
+
+func TestCronAddFunc(t *testing.T) {
+	c := cron.New(cron.WithCronLocation(time.UTC))
+	if _, err := c.AddFunc("* * * *", func(ctx context.Context) {}); err == nil {
+		t.Fatal("AddFunc with a bad spec = nil error; want error")
+	}
+	id, err := c.AddFunc("0 0 * * *", func(ctx context.Context) {}, cron.WithJobName("daily"))
+	if err != nil {
+		t.Fatalf("AddFunc: %v", err)
+	}
+	e, ok := c.Entry(id)
+	if !ok || e.Name != "daily" {
+		t.Fatalf("Entry = %+v, %t; want daily", e, ok)
+	}
+	if want := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour); !e.Next.Equal(want) {
+		t.Fatalf("Next = %s; want %s", e.Next, want)
+	}
+}
@@ -0,0 +1,90 @@
+// Code generated by "go-option -type job"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package cron
+
+import "time"
+
+// A JobOption sets options.
+type JobOption interface {
+	apply(*job)
+}
+
+// EmptyJobOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyJobOption struct{}
+
+func (EmptyJobOption) apply(*job) {}
+
+// JobOptionFunc wraps a function that modifies job into an
+// implementation of the JobOption interface.
+type JobOptionFunc func(*job)
+
+func (f JobOptionFunc) apply(do *job) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *job) ApplyOptions(options ...JobOption) *job {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withJob sets job.
+func withJob(v job) JobOption {
+	return JobOptionFunc(func(o *job) {
+		*o = v
+	})
+}
+
+// WithJobName sets Name in job.
+// Name of the job, used in logs.
+func WithJobName(v string) JobOption {
+	return JobOptionFunc(func(o *job) {
+		o.Name = v
+	})
+}
+
+// WithJobOverlapPolicy sets OverlapPolicy in job.
+// OverlapPolicy controls what happens when the job is due while its previous run is still running.
+func WithJobOverlapPolicy(v OverlapPolicy) JobOption {
+	return JobOptionFunc(func(o *job) {
+		o.OverlapPolicy = v
+	})
+}
+
+// WithJobJitter sets Jitter in job.
+// Jitter overrides the Jitter of Cron for this job.
+// take effects if only Jitter is bigger than 0.
+func WithJobJitter(v time.Duration) JobOption {
+	return JobOptionFunc(func(o *job) {
+		o.Jitter = v
+	})
+}
+
+// WithJobMaxCatchUp sets MaxCatchUp in job.
+// MaxCatchUp is the maximum number of missed activations run late, in addition to the latest one.
+// Activations are missed if the process is suspended, or restarted with LastRun.
+// All missed activations are coalesced into a single run if MaxCatchUp is 0.
+func WithJobMaxCatchUp(v int) JobOption {
+	return JobOptionFunc(func(o *job) {
+		o.MaxCatchUp = v
+	})
+}
+
+// WithJobLastRun sets LastRun in job.
+// LastRun is the time the job was activated last, such as before a restart.
+// Activations after LastRun are caught up according to MaxCatchUp.
+// take effects if only LastRun is not zero.
+func WithJobLastRun(v time.Time) JobOption {
+	return JobOptionFunc(func(o *job) {
+		o.LastRun = v
+	})
+}
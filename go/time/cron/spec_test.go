@@ -0,0 +1,143 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searKing/golang/go/time/cron"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseInLocation(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"*/15 * * * *", "2026-01-01T00:07:00Z", "2026-01-01T00:15:00Z"},
+		{"30 * * * * *", "2026-01-01T00:00:30Z", "2026-01-01T00:01:30Z"},
+		{"0 9 * * mon-fri", "2026-01-02T10:00:00Z", "2026-01-05T09:00:00Z"},
+		{"0 0 1 jan,jul ?", "2026-02-01T00:00:00Z", "2026-07-01T00:00:00Z"},
+		// day-of-month or day-of-week, if both are restricted
+		{"0 0 13 * fri", "2026-02-01T00:00:00Z", "2026-02-06T00:00:00Z"},
+		{"0 0 29 2 *", "2026-01-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		{"@hourly", "2026-01-01T00:00:00Z", "2026-01-01T01:00:00Z"},
+		{"@daily", "2026-01-01T12:00:00Z", "2026-01-02T00:00:00Z"},
+		{"@weekly", "2026-01-01T12:00:00Z", "2026-01-04T00:00:00Z"},
+		{"@monthly", "2026-01-01T12:00:00Z", "2026-02-01T00:00:00Z"},
+		{"@yearly", "2026-01-01T12:00:00Z", "2027-01-01T00:00:00Z"},
+		{"@every 90s", "2026-01-01T00:00:00Z", "2026-01-01T00:01:30Z"},
+		{"CRON_TZ=Asia/Shanghai 0 9 * * *", "2026-01-01T00:00:00Z", "2026-01-01T01:00:00Z"},
+		{"0 0 30 2 *", "2026-01-01T00:00:00Z", "0001-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := cron.ParseInLocation(tt.spec, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseInLocation(%q): %v", tt.spec, err)
+			}
+			from, _ := time.Parse(time.RFC3339, tt.from)
+			want, _ := time.Parse(time.RFC3339, tt.want)
+			if got := s.Next(from); !got.Equal(want) {
+				t.Errorf("Next(%s) = %s; want %s", from, got, want)
+			}
+		})
+	}
+}
+
+func TestParseInLocationError(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@every",
+		"@every -1s",
+		"@fortnightly",
+		"CRON_TZ=Nowhere/Land * * * * *",
+	} {
+		if _, err := cron.ParseInLocation(spec, time.UTC); err == nil {
+			t.Errorf("ParseInLocation(%q) = nil error; want error", spec)
+		}
+	}
+}
+
+func TestSpecScheduleDST(t *testing.T) {
+	loc := mustLoadLocation(t, "America/New_York")
+	at := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.ParseInLocation("2006-01-02 15:04 MST", s, loc)
+		if err != nil {
+			t.Fatalf("ParseInLocation(%q): %v", s, err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want []time.Time
+	}{
+		{
+			// clocks spring forward from 02:00 EST to 03:00 EDT on 2026-03-08
+			name: "gap",
+			spec: "30 2 * * *",
+			from: at("2026-03-07 12:00 EST"),
+			want: []time.Time{at("2026-03-08 03:30 EDT"), at("2026-03-09 02:30 EDT")},
+		},
+		{
+			// clocks fall back from 02:00 EDT to 01:00 EST on 2026-11-01
+			name: "overlap",
+			spec: "30 1 * * *",
+			from: at("2026-10-31 12:00 EDT"),
+			want: []time.Time{at("2026-11-01 01:30 EDT"), at("2026-11-02 01:30 EST")},
+		},
+		{
+			name: "hourly over overlap",
+			spec: "0 * * * *",
+			from: at("2026-11-01 00:30 EDT"),
+			want: []time.Time{at("2026-11-01 01:00 EDT"), at("2026-11-01 02:00 EST"), at("2026-11-01 03:00 EST")},
+		},
+		{
+			name: "hourly over gap",
+			spec: "0 * * * *",
+			from: at("2026-03-08 00:30 EST"),
+			want: []time.Time{at("2026-03-08 01:00 EST"), at("2026-03-08 03:00 EDT"), at("2026-03-08 04:00 EDT")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := cron.ParseInLocation(tt.spec, loc)
+			if err != nil {
+				t.Fatalf("ParseInLocation(%q): %v", tt.spec, err)
+			}
+			next := tt.from
+			for i, want := range tt.want {
+				next = s.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("#%d: Next = %s; want %s", i, next, want)
+				}
+			}
+		})
+	}
+}
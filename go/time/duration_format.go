@@ -0,0 +1,364 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// durationUnits maps units accepted by ParseDuration, case-insensitively.
+var durationUnits = map[string]uint64{
+	"ns": uint64(time.Nanosecond), "nanosecond": uint64(time.Nanosecond), "nanoseconds": uint64(time.Nanosecond),
+	"us": uint64(time.Microsecond), "µs": uint64(time.Microsecond), // U+00B5 = micro symbol
+	"μs":          uint64(time.Microsecond), // U+03BC = Greek letter mu
+	"microsecond": uint64(time.Microsecond), "microseconds": uint64(time.Microsecond),
+	"ms": uint64(time.Millisecond), "millisecond": uint64(time.Millisecond), "milliseconds": uint64(time.Millisecond),
+	"s": uint64(time.Second), "sec": uint64(time.Second), "secs": uint64(time.Second),
+	"second": uint64(time.Second), "seconds": uint64(time.Second),
+	"m": uint64(time.Minute), "min": uint64(time.Minute), "mins": uint64(time.Minute),
+	"minute": uint64(time.Minute), "minutes": uint64(time.Minute),
+	"h": uint64(time.Hour), "hr": uint64(time.Hour), "hrs": uint64(time.Hour),
+	"hour": uint64(time.Hour), "hours": uint64(time.Hour),
+	"d": uint64(Day), "day": uint64(Day), "days": uint64(Day),
+	"w": uint64(Week), "week": uint64(Week), "weeks": uint64(Week),
+}
+
+var errDurationOverflow = errors.New("overflow")
+
+// ParseDuration parses a duration string, a superset of time.ParseDuration, accepting:
+//   - Go durations, such as "300ms", "-1.5h" or "2h45m";
+//   - extended units, days "d" and weeks "w", such as "1d12h" or "2w";
+//   - long unit names, optionally separated by spaces or commas, such as "1 day, 12 hours" or "2 weeks";
+//   - ISO 8601 durations, such as "P1DT2H", "PT0.5S" or "-P2W".
+//
+// A day is always 24 hours, and a week 7 days, regardless of DST.
+// ISO 8601 years and months are rejected, as they are not of a fixed length.
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var d uint64
+	var err error
+	switch {
+	case s == "0":
+		return 0, nil
+	case s == "":
+		return 0, errors.New("time: invalid duration " + strconv.Quote(orig))
+	case s[0] == 'P' || s[0] == 'p':
+		d, err = parseISO8601Duration(s[1:])
+	default:
+		d, err = parseUnitDuration(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("time: invalid duration %s: %w", strconv.Quote(orig), err)
+	}
+	if neg {
+		if d > 1<<63 {
+			return 0, fmt.Errorf("time: invalid duration %s: %w", strconv.Quote(orig), errDurationOverflow)
+		}
+		return -time.Duration(d), nil
+	}
+	if d > 1<<63-1 {
+		return 0, fmt.Errorf("time: invalid duration %s: %w", strconv.Quote(orig), errDurationOverflow)
+	}
+	return time.Duration(d), nil
+}
+
+// parseUnitDuration parses [0-9]+(\.[0-9]*)?unit sequences, such as "1d12h" or "1 day 12 hours".
+func parseUnitDuration(s string) (uint64, error) {
+	var d uint64
+	for s != "" {
+		s = strings.TrimLeft(s, " ,")
+		if s == "" {
+			break
+		}
+		v, f, scale, rest, err := leadingDecimal(s, false)
+		if err != nil {
+			return 0, err
+		}
+		rest = strings.TrimLeft(rest, " ")
+
+		// consume unit, up to the next digit, separator or sign
+		i := 0
+		for i < len(rest) && rest[i] != '.' && rest[i] != ',' && rest[i] != ' ' && (rest[i] < '0' || rest[i] > '9') {
+			i++
+		}
+		if i == 0 {
+			return 0, errors.New("missing unit")
+		}
+		unit, ok := durationUnits[strings.ToLower(rest[:i])]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %s", strconv.Quote(rest[:i]))
+		}
+		if d, err = addDuration(d, v, f, scale, unit); err != nil {
+			return 0, err
+		}
+		s = rest[i:]
+	}
+	return d, nil
+}
+
+// parseISO8601Duration parses an ISO 8601 duration after the leading "P", such as "1DT2H".
+func parseISO8601Duration(s string) (uint64, error) {
+	var d uint64
+	inTime := false
+	components := 0
+	for s != "" {
+		if s[0] == 'T' || s[0] == 't' {
+			if inTime || len(s) == 1 {
+				return 0, errors.New("misplaced time designator T")
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+		v, f, scale, rest, err := leadingDecimal(s, true)
+		if err != nil {
+			return 0, err
+		}
+		if rest == "" {
+			return 0, errors.New("missing designator")
+		}
+		var unit uint64
+		switch c := rest[0] | 0x20; {
+		case c == 'y' && !inTime, c == 'm' && !inTime:
+			return 0, errors.New("years and months are not of a fixed length")
+		case c == 'w' && !inTime:
+			unit = uint64(Week)
+		case c == 'd' && !inTime:
+			unit = uint64(Day)
+		case c == 'h' && inTime:
+			unit = uint64(time.Hour)
+		case c == 'm' && inTime:
+			unit = uint64(time.Minute)
+		case c == 's' && inTime:
+			unit = uint64(time.Second)
+		default:
+			return 0, fmt.Errorf("unknown designator %s", strconv.Quote(rest[:1]))
+		}
+		if d, err = addDuration(d, v, f, scale, unit); err != nil {
+			return 0, err
+		}
+		components++
+		s = rest[1:]
+	}
+	if components == 0 {
+		return 0, errors.New("missing components")
+	}
+	return d, nil
+}
+
+// leadingDecimal consumes a decimal number, such as "1.5", or "1,5" if comma is true, as ISO 8601 allows,
+// and returns its integer part v, fractional part f/scale and the rest of s.
+func leadingDecimal(s string, comma bool) (v, f uint64, scale float64, rest string, err error) {
+	i := 0
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		if v > (1<<63)/10 {
+			return 0, 0, 0, "", errDurationOverflow
+		}
+		v = v*10 + uint64(s[i]-'0')
+		if v > 1<<63 {
+			return 0, 0, 0, "", errDurationOverflow
+		}
+	}
+	pre := i != 0
+	scale = 1
+	post := false
+	if i < len(s) && (s[i] == '.' || (comma && s[i] == ',' && i+1 < len(s) && '0' <= s[i+1] && s[i+1] <= '9')) {
+		i++
+		overflow := false
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			post = true
+			if overflow || f > (1<<63-1)/10 {
+				// It's possible for overflow to give a positive number, so take care.
+				overflow = true
+				continue
+			}
+			f = f*10 + uint64(s[i]-'0')
+			scale *= 10
+		}
+	}
+	if !pre && !post {
+		return 0, 0, 0, "", errors.New("missing number")
+	}
+	return v, f, scale, s[i:], nil
+}
+
+// addDuration returns d + (v + f/scale) * unit, or an error on overflow.
+func addDuration(d, v, f uint64, scale float64, unit uint64) (uint64, error) {
+	if v > (1<<63)/unit {
+		return 0, errDurationOverflow
+	}
+	v *= unit
+	if f > 0 {
+		// float64 is needed to be nanosecond accurate for fractions of hours.
+		// v >= 0 && (f*unit/scale) <= 3.6e+12 (ns/h, h is the largest unit)
+		v += uint64(float64(f) * (float64(unit) / scale))
+		if v > 1<<63 {
+			return 0, errDurationOverflow
+		}
+	}
+	d += v
+	if d > 1<<63 {
+		return 0, errDurationOverflow
+	}
+	return d, nil
+}
+
+// FormatDuration formats d compactly with days as the largest unit, such as "1d12h", "90m" as "1h30m",
+// and "1.5s"; durations less than a second are formatted as time.Duration.String does, such as "500ms".
+// The output is accepted by ParseDuration.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	if u < uint64(time.Second) {
+		b.WriteString(time.Duration(u).String())
+		return b.String()
+	}
+	for _, unit := range []struct {
+		name string
+		unit uint64
+	}{{"d", uint64(Day)}, {"h", uint64(time.Hour)}, {"m", uint64(time.Minute)}} {
+		if n := u / unit.unit; n > 0 {
+			b.WriteString(strconv.FormatUint(n, 10))
+			b.WriteString(unit.name)
+			u -= n * unit.unit
+		}
+	}
+	if u > 0 {
+		writeSeconds(&b, u)
+		b.WriteByte('s')
+	}
+	return b.String()
+}
+
+// FormatISO8601Duration formats d as an ISO 8601 duration with days as the largest unit,
+// such as "P1DT12H", "PT1.5S" or "-PT30M", and "PT0S" for zero.
+func FormatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteByte('P')
+	if n := u / uint64(Day); n > 0 {
+		b.WriteString(strconv.FormatUint(n, 10))
+		b.WriteByte('D')
+		u -= n * uint64(Day)
+	}
+	if u == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if n := u / uint64(time.Hour); n > 0 {
+		b.WriteString(strconv.FormatUint(n, 10))
+		b.WriteByte('H')
+		u -= n * uint64(time.Hour)
+	}
+	if n := u / uint64(time.Minute); n > 0 {
+		b.WriteString(strconv.FormatUint(n, 10))
+		b.WriteByte('M')
+		u -= n * uint64(time.Minute)
+	}
+	if u > 0 {
+		writeSeconds(&b, u)
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// writeSeconds writes u nanoseconds as seconds, with trailing zeros of the fraction omitted.
+func writeSeconds(b *strings.Builder, u uint64) {
+	b.WriteString(strconv.FormatUint(u/uint64(time.Second), 10))
+	if frac := u % uint64(time.Second); frac > 0 {
+		s := strconv.FormatUint(frac+uint64(time.Second), 10)[1:] // zero padded to 9 digits
+		b.WriteByte('.')
+		b.WriteString(strings.TrimRight(s, "0"))
+	}
+}
+
+// humanUnits are units of HumanizeDuration, months and years are approximate.
+var humanUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"year", 365 * Day},
+	{"month", 30 * Day},
+	{"week", Week},
+	{"day", Day},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// HumanizeDuration formats d approximately in its largest unit, rounded,
+// such as "3 days", "1 hour" or "45 seconds", and "less than a second" for |d| < 1s.
+// Months are 30 days, and years 365 days.
+func HumanizeDuration(d time.Duration) string {
+	// |d| as uint64, as -math.MinInt64 overflows
+	a := uint64(d)
+	if d < 0 {
+		a = -a
+	}
+	for i, u := range humanUnits {
+		unit := uint64(u.unit)
+		if a < unit {
+			continue
+		}
+		// rounded half up, by quotient and remainder, as a+unit/2 may overflow
+		n := a / unit
+		if a%unit >= unit-unit/2 {
+			n++
+		}
+		// 59.5 minutes is rounded to 1 hour, instead of 60 minutes
+		if i > 0 && n*unit >= uint64(humanUnits[i-1].unit) {
+			u = humanUnits[i-1]
+			n = 1
+		}
+		if n == 1 {
+			return "1 " + u.name
+		}
+		return strconv.FormatUint(n, 10) + " " + u.name + "s"
+	}
+	return "less than a second"
+}
+
+// HumanizeRelative formats t relative to now approximately, such as "3 days ago" or "in 2 hours",
+// and "now" if they are less than a second apart.
+func HumanizeRelative(t, now time.Time) string {
+	d := t.Sub(now)
+	if d > -time.Second && d < time.Second {
+		return "now"
+	}
+	if d < 0 {
+		return HumanizeDuration(d) + " ago"
+	}
+	return "in " + HumanizeDuration(d)
+}
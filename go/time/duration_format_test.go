@@ -0,0 +1,170 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		// Go durations
+		{"0", 0},
+		{"300ms", 300 * time.Millisecond},
+		{"-1.5h", -90 * time.Minute},
+		{"2h45m", 2*time.Hour + 45*time.Minute},
+		{"1µs", time.Microsecond},
+		// extended units
+		{"1d12h", 36 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1w2d3h4m5s", (9*24+3)*time.Hour + 4*time.Minute + 5*time.Second},
+		{"1 day, 12 hours", 36 * time.Hour},
+		{" 2 Weeks ", 14 * 24 * time.Hour},
+		{"3 mins 2 secs", 3*time.Minute + 2*time.Second},
+		// ISO 8601
+		{"P1DT2H", 26 * time.Hour},
+		{"PT0.5S", 500 * time.Millisecond},
+		{"PT0,5S", 500 * time.Millisecond},
+		{"-P2W", -14 * 24 * time.Hour},
+		{"PT1M", time.Minute},
+		{"P1W2DT3H4M5.006S", (9*24+3)*time.Hour + 4*time.Minute + 5006*time.Millisecond},
+		{"pt36h", 36 * time.Hour},
+		// largest duration
+		{"2562047h47m16.854775807s", 1<<63 - 1},
+		{"-2562047h47m16.854775808s", -1 << 63},
+	}
+	for _, tt := range tests {
+		got, err := time_.ParseDuration(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, nil", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseDurationError(t *testing.T) {
+	for _, in := range []string{
+		"", "-", "3", "3x", "d", ".d", "1..5d", "1,5d",
+		"P", "PT", "P1Y", "P1M", "P1H", "PT1D", "P1DT", "P1", "PT1S2",
+		"2562047h47m16.854775808s", "107000w",
+	} {
+		if got, err := time_.ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, nil; want error", in, got)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d       time.Duration
+		want    string
+		iso8601 string
+	}{
+		{0, "0s", "PT0S"},
+		{500 * time.Millisecond, "500ms", "PT0.5S"},
+		{1500 * time.Millisecond, "1.5s", "PT1.5S"},
+		{90 * time.Minute, "1h30m", "PT1H30M"},
+		{36 * time.Hour, "1d12h", "P1DT12H"},
+		{14 * 24 * time.Hour, "14d", "P14D"},
+		{-(26*time.Hour + time.Nanosecond), "-1d2h0.000000001s", "-P1DT2H0.000000001S"},
+		{-1 << 63, "-106751d23h47m16.854775808s", "-P106751DT23H47M16.854775808S"},
+	}
+	for _, tt := range tests {
+		if got := time_.FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q; want %q", tt.d, got, tt.want)
+		}
+		if got := time_.FormatISO8601Duration(tt.d); got != tt.iso8601 {
+			t.Errorf("FormatISO8601Duration(%v) = %q; want %q", tt.d, got, tt.iso8601)
+		}
+		// round trip
+		for _, s := range []string{tt.want, tt.iso8601} {
+			if got, err := time_.ParseDuration(s); err != nil || got != tt.d {
+				t.Errorf("ParseDuration(%q) = %v, %v; want %v, nil", s, got, err, tt.d)
+			}
+		}
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "less than a second"},
+		{time.Second, "1 second"},
+		{-45 * time.Second, "45 seconds"},
+		{89 * time.Second, "1 minute"},
+		{59*time.Minute + 40*time.Second, "1 hour"},
+		{3*24*time.Hour + 5*time.Hour, "3 days"},
+		{15 * 24 * time.Hour, "2 weeks"},
+		{400 * 24 * time.Hour, "1 year"},
+		{90 * time.Second, "2 minutes"}, // rounded half up
+		{math.MaxInt64, "292 years"},
+		{-math.MaxInt64, "292 years"},
+		{math.MinInt64, "292 years"},
+	}
+	for _, tt := range tests {
+		if got := time_.HumanizeDuration(tt.d); got != tt.want {
+			t.Errorf("HumanizeDuration(%v) = %q; want %q", tt.d, got, tt.want)
+		}
+	}
+
+	now := time.Now()
+	for _, tt := range []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-3 * 24 * time.Hour), "3 days ago"},
+		{now.Add(2 * time.Hour), "in 2 hours"},
+		{now.Add(300 * time.Millisecond), "now"},
+	} {
+		if got := time_.HumanizeRelative(tt.t, now); got != tt.want {
+			t.Errorf("HumanizeRelative(%v) = %q; want %q", tt.t.Sub(now), got, tt.want)
+		}
+	}
+}
+
+func TestDurationUnmarshalExtended(t *testing.T) {
+	var v struct {
+		JSON time_.Duration `json:"json"`
+		Num  time_.Duration `json:"num"`
+	}
+	if err := json.Unmarshal([]byte(`{"json":"P1DT12H","num":1000}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if v.JSON != time_.Duration(36*time.Hour) || v.Num != time_.Duration(time.Microsecond) {
+		t.Fatalf("json.Unmarshal = %+v", v)
+	}
+
+	var d time_.Duration
+	if err := d.UnmarshalText([]byte("1d12h")); err != nil || d != time_.Duration(36*time.Hour) {
+		t.Fatalf("UnmarshalText = %v, %v", d, err)
+	}
+	text, err := d.MarshalText()
+	if err != nil || string(text) != "36h0m0s" {
+		t.Fatalf("MarshalText = %s, %v", text, err)
+	}
+
+	yaml, err := d.MarshalYAML()
+	if err != nil || yaml != "36h0m0s" {
+		t.Fatalf("MarshalYAML = %v, %v", yaml, err)
+	}
+	d = 0
+	err = d.UnmarshalYAML(func(v any) error {
+		*(v.(*string)) = "2w"
+		return nil
+	})
+	if err != nil || d != time_.Duration(14*24*time.Hour) {
+		t.Fatalf("UnmarshalYAML = %v, %v", d, err)
+	}
+}
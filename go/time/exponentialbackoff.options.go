@@ -42,6 +42,14 @@ func WithExponentialBackOffOptionMaxElapsedCount(count int) ExponentialBackOffOp
 	})
 }
 
+// WithExponentialBackOffOptionClock sets the clock to measure the elapsed time, and to wait
+// for the back off by BackoffUntil, such as a FakeClock in tests.
+func WithExponentialBackOffOptionClock(clock Clock) ExponentialBackOffOption {
+	return ExponentialBackOffOptionFunc(func(o *ExponentialBackOff) {
+		o.clock = clock
+	})
+}
+
 func WithExponentialBackOffOptionNoLimit() ExponentialBackOffOption {
 	return ExponentialBackOffOptionFunc(func(o *ExponentialBackOff) {
 		o.initialInterval = DefaultInitialInterval
@@ -0,0 +1,54 @@
+// Code generated by "go-option -type burstLimiter"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package rate
+
+import time_ "github.com/searKing/golang/go/time"
+
+// A BurstLimiterOption sets options.
+type BurstLimiterOption interface {
+	apply(*burstLimiter)
+}
+
+// EmptyBurstLimiterOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyBurstLimiterOption struct{}
+
+func (EmptyBurstLimiterOption) apply(*burstLimiter) {}
+
+// BurstLimiterOptionFunc wraps a function that modifies burstLimiter into an
+// implementation of the BurstLimiterOption interface.
+type BurstLimiterOptionFunc func(*burstLimiter)
+
+func (f BurstLimiterOptionFunc) apply(do *burstLimiter) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *burstLimiter) ApplyOptions(options ...BurstLimiterOption) *burstLimiter {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withBurstLimiter sets burstLimiter.
+func withBurstLimiter(v burstLimiter) BurstLimiterOption {
+	return BurstLimiterOptionFunc(func(o *burstLimiter) {
+		*o = v
+	})
+}
+
+// WithBurstLimiterClock sets Clock in burstLimiter.
+// Clock tells the time to check deadlines of contexts, and to poll tokens by Reservation.Wait.
+// time_.RealClock if nil.
+func WithBurstLimiterClock(v time_.Clock) BurstLimiterOption {
+	return BurstLimiterOptionFunc(func(o *burstLimiter) {
+		o.Clock = v
+	})
+}
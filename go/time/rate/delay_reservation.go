@@ -0,0 +1,102 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A DelayReservation holds information about events that are permitted by a Limiter to happen after a delay.
+// A DelayReservation may be canceled, which may enable the Limiter to permit additional events.
+type DelayReservation struct {
+	ok        bool
+	lim       *timeLimiter
+	n         int
+	timeToAct time.Time
+
+	cancelOnce sync.Once
+}
+
+// OK returns whether the limiter can provide the requested number of tokens
+// within the maximum wait time. If OK is false, Delay returns InfDuration, and
+// Cancel does nothing.
+func (r *DelayReservation) OK() bool {
+	return r.ok
+}
+
+// Delay is shorthand for DelayFrom(time.Now()).
+func (r *DelayReservation) Delay() time.Duration {
+	return r.DelayFrom(time.Now())
+}
+
+// InfDuration is the duration returned by Delay when a DelayReservation is not OK.
+const InfDuration = time.Duration(1<<63 - 1)
+
+// DelayFrom returns the duration for which the reservation holder must wait
+// before taking the reserved action. Zero duration means act immediately.
+// InfDuration means the limiter cannot grant the tokens requested in this
+// DelayReservation within the maximum wait time.
+func (r *DelayReservation) DelayFrom(now time.Time) time.Duration {
+	if !r.ok {
+		return InfDuration
+	}
+	delay := r.timeToAct.Sub(now)
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Wait blocks until the reserved action may be taken.
+// If ctx is done before, Wait cancels the reservation and returns ctx.Err().
+func (r *DelayReservation) Wait(ctx context.Context) error {
+	return r.waitFrom(ctx, time.Now())
+}
+
+func (r *DelayReservation) waitFrom(ctx context.Context, now time.Time) error {
+	if !r.ok {
+		return fmt.Errorf("rate: Wait(n=%d) exceeds limiter's burst", r.n)
+	}
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		// We can proceed.
+		return nil
+	case <-ctx.Done():
+		// Context was canceled before we could proceed.  Cancel the
+		// reservation, which may permit other events to proceed sooner.
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// Cancel is shorthand for CancelAt(time.Now()).
+func (r *DelayReservation) Cancel() {
+	r.CancelAt(time.Now())
+}
+
+// CancelAt indicates that the reservation holder will not perform the reserved action
+// and reverses the effects of this Reservation on the rate limit as much as possible,
+// considering that other reservations may have already been made.
+func (r *DelayReservation) CancelAt(now time.Time) {
+	if !r.ok || r.n <= 0 {
+		return
+	}
+	r.cancelOnce.Do(func() {
+		if r.timeToAct.Before(now) {
+			// the reserved action has been taken already
+			return
+		}
+		r.lim.cancel(now, r)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"time"
+)
+
+// GCRALimiter controls how frequently events are allowed to happen.
+// It implements the Generic Cell Rate Algorithm, the virtual scheduling equivalent of
+// a leaky bucket, which tracks only a theoretical arrival time instead of a token count.
+// Events are permitted at rate r with a maximum burst size of b events.
+// As a special case, if r == Inf (the infinite rate), b is ignored.
+// See https://en.wikipedia.org/wiki/Generic_cell_rate_algorithm for more about GCRA.
+type GCRALimiter struct {
+	timeLimiter
+	gcra gcra
+}
+
+// NewGCRALimiter returns a new GCRALimiter that allows events up to rate r
+// and permits bursts of at most b events.
+func NewGCRALimiter(r Limit, b int) *GCRALimiter {
+	lim := &GCRALimiter{gcra: gcra{limit: r, burst: b}}
+	lim.alg = &lim.gcra
+	return lim
+}
+
+// Limit returns the maximum overall event rate.
+func (lim *GCRALimiter) Limit() Limit {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.gcra.limit
+}
+
+// Burst returns the maximum burst size.
+func (lim *GCRALimiter) Burst() int {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.gcra.burst
+}
+
+// gcra is the algorithm of GCRALimiter.
+type gcra struct {
+	limit Limit
+	burst int
+	tat   time.Time // theoretical arrival time of the next event
+}
+
+func (g *gcra) reserve(at time.Time, n int, deadline time.Time) (time.Time, bool) {
+	if g.limit == Inf {
+		return at, true
+	}
+	if n > g.burst || g.limit <= 0 {
+		return time.Time{}, false
+	}
+	tat := g.tat
+	if tat.Before(at) {
+		tat = at
+	}
+	newTat := tat.Add(g.limit.durationFromTokens(float64(n)))
+	// events are allowed as long as the theoretical arrival time is within the burst tolerance
+	timeToAct := newTat.Add(-g.limit.durationFromTokens(float64(g.burst)))
+	if timeToAct.Before(at) {
+		timeToAct = at
+	}
+	if !deadline.IsZero() && timeToAct.After(deadline) {
+		return time.Time{}, false
+	}
+	g.tat = newTat
+	return timeToAct, true
+}
+
+func (g *gcra) cancel(now time.Time, timeToAct time.Time, lastToAct time.Time, n int) {
+	if g.limit == Inf || g.limit <= 0 {
+		return
+	}
+	// tokens reserved after this reservation are not restored
+	restore := float64(n) - g.limit.tokensFromDuration(lastToAct.Sub(timeToAct))
+	if restore <= 0 {
+		return
+	}
+	g.tat = g.tat.Add(-g.limit.durationFromTokens(restore))
+	if g.tat.Before(now) {
+		g.tat = now
+	}
+}
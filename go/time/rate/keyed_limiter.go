@@ -0,0 +1,130 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedLimiter tracks a Limiter per key, such as a tenant or a client IP.
+// Limiters are kept in an LRU, the least recently used limiter is evicted when there are more than
+// maxKeys limiters, and limiters not used for idleTimeout are evicted lazily.
+// An evicted limiter is created again by newLimiter on demand, forgetting its reservations.
+type KeyedLimiter[K comparable] struct {
+	newLimiter  func(key K) Limiter
+	maxKeys     int           // take effects if only maxKeys is bigger than 0
+	idleTimeout time.Duration // take effects if only idleTimeout is bigger than 0
+
+	mu    sync.Mutex
+	ll    *list.List // of *keyedLimiterEntry[K], most recently used at front
+	cache map[K]*list.Element
+}
+
+type keyedLimiterEntry[K comparable] struct {
+	key        K
+	limiter    Limiter
+	lastAccess time.Time
+}
+
+// NewKeyedLimiter returns a new KeyedLimiter which creates limiters by newLimiter.
+func NewKeyedLimiter[K comparable](newLimiter func(key K) Limiter, maxKeys int, idleTimeout time.Duration) *KeyedLimiter[K] {
+	return &KeyedLimiter[K]{
+		newLimiter:  newLimiter,
+		maxKeys:     maxKeys,
+		idleTimeout: idleTimeout,
+		ll:          list.New(),
+		cache:       make(map[K]*list.Element),
+	}
+}
+
+// Get is shorthand for GetAt(key, time.Now()).
+func (k *KeyedLimiter[K]) Get(key K) Limiter {
+	return k.GetAt(key, time.Now())
+}
+
+// GetAt returns the limiter of key, creating it if absent, and marks it as used at now.
+func (k *KeyedLimiter[K]) GetAt(key K, now time.Time) Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.evictIdleLocked(now)
+	if e, ok := k.cache[key]; ok {
+		k.ll.MoveToFront(e)
+		entry := e.Value.(*keyedLimiterEntry[K])
+		entry.lastAccess = now
+		return entry.limiter
+	}
+	entry := &keyedLimiterEntry[K]{key: key, limiter: k.newLimiter(key), lastAccess: now}
+	k.cache[key] = k.ll.PushFront(entry)
+	for k.maxKeys > 0 && k.ll.Len() > k.maxKeys {
+		k.removeElementLocked(k.ll.Back())
+	}
+	return entry.limiter
+}
+
+// Allow is shorthand for AllowN(key, time.Now(), 1).
+func (k *KeyedLimiter[K]) Allow(key K) bool {
+	return k.AllowN(key, time.Now(), 1)
+}
+
+// AllowN reports whether n events of key may happen at time now.
+func (k *KeyedLimiter[K]) AllowN(key K, now time.Time, n int) bool {
+	return k.GetAt(key, now).AllowN(now, n)
+}
+
+// Reserve is shorthand for ReserveN(key, time.Now(), 1).
+func (k *KeyedLimiter[K]) Reserve(key K) *DelayReservation {
+	return k.ReserveN(key, time.Now(), 1)
+}
+
+// ReserveN returns a DelayReservation that indicates how long the caller must wait before n events of key happen.
+func (k *KeyedLimiter[K]) ReserveN(key K, now time.Time, n int) *DelayReservation {
+	return k.GetAt(key, now).ReserveN(now, n)
+}
+
+// Wait is shorthand for WaitN(ctx, key, 1).
+func (k *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return k.WaitN(ctx, key, 1)
+}
+
+// WaitN blocks until the limiter of key permits n events to happen.
+func (k *KeyedLimiter[K]) WaitN(ctx context.Context, key K, n int) error {
+	return k.Get(key).WaitN(ctx, n)
+}
+
+// Remove removes the limiter of key.
+func (k *KeyedLimiter[K]) Remove(key K) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if e, ok := k.cache[key]; ok {
+		k.removeElementLocked(e)
+	}
+}
+
+// Len returns the number of limiters tracked.
+func (k *KeyedLimiter[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.ll.Len()
+}
+
+func (k *KeyedLimiter[K]) evictIdleLocked(now time.Time) {
+	if k.idleTimeout <= 0 {
+		return
+	}
+	for e := k.ll.Back(); e != nil; e = k.ll.Back() {
+		if now.Sub(e.Value.(*keyedLimiterEntry[K]).lastAccess) < k.idleTimeout {
+			return
+		}
+		k.removeElementLocked(e)
+	}
+}
+
+func (k *KeyedLimiter[K]) removeElementLocked(e *list.Element) {
+	k.ll.Remove(e)
+	delete(k.cache, e.Value.(*keyedLimiterEntry[K]).key)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limiter controls how frequently events are allowed to happen over time.
+// Implemented by TokenBucketLimiter, GCRALimiter, SlidingWindowLogLimiter and SlidingWindowCounterLimiter.
+//
+// Reorder Buffer
+// Reservations are granted in the order they are made, a Reservation never acts before
+// any Reservation made earlier on the same Limiter, so waiting on Reservations commits them in-order.
+type Limiter interface {
+	// AllowN reports whether n events may happen at time now.
+	AllowN(now time.Time, n int) bool
+	// ReserveN returns a DelayReservation that indicates how long the caller must wait before n events happen.
+	ReserveN(now time.Time, n int) *DelayReservation
+	// WaitN blocks until lim permits n events to happen.
+	WaitN(ctx context.Context, n int) error
+}
+
+// algorithm is the state of a Limiter evolving over time, guarded by timeLimiter.mu.
+type algorithm interface {
+	// reserve reserves n events at the earliest time not before at, and returns that time.
+	// If the time exceeds deadline, or n events can never happen at once, reserve returns false
+	// and leaves the state unchanged. A zero deadline means no deadline.
+	reserve(at time.Time, n int, deadline time.Time) (timeToAct time.Time, ok bool)
+
+	// cancel reverses the effects of n events reserved at timeToAct as much as possible,
+	// considering that other reservations may have already been made, the latest of which
+	// acts at lastToAct.
+	cancel(now time.Time, timeToAct time.Time, lastToAct time.Time, n int)
+}
+
+// timeLimiter implements the Allow, Reserve and Wait family shared by all rate-over-time limiters.
+type timeLimiter struct {
+	mu  sync.Mutex
+	alg algorithm
+
+	last time.Time // time to act of the latest reservation, Reservations act in-order
+}
+
+// Allow is shorthand for AllowN(time.Now(), 1).
+func (lim *timeLimiter) Allow() bool {
+	return lim.AllowN(time.Now(), 1)
+}
+
+// AllowN reports whether n events may happen at time now.
+// Use this method if you intend to drop / skip events that exceed the rate limit.
+// Otherwise, use Reserve or Wait.
+func (lim *timeLimiter) AllowN(now time.Time, n int) bool {
+	return lim.reserveN(now, n, now).ok
+}
+
+// Reserve is shorthand for ReserveN(time.Now(), 1).
+func (lim *timeLimiter) Reserve() *DelayReservation {
+	return lim.ReserveN(time.Now(), 1)
+}
+
+// ReserveN returns a DelayReservation that indicates how long the caller must wait before n events happen.
+// The Limiter takes this Reservation into account when allowing future events.
+// The returned Reservation’s OK() method returns false if n exceeds the Limiter's burst size.
+// Use this method if you wish to wait and slow down in accordance with the rate limit without dropping events.
+// If you need to respect a deadline or cancel the delay, use Wait instead.
+// To drop or skip events exceeding rate limit, use Allow instead.
+func (lim *timeLimiter) ReserveN(now time.Time, n int) *DelayReservation {
+	return lim.reserveN(now, n, time.Time{})
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (lim *timeLimiter) Wait(ctx context.Context) (err error) {
+	return lim.WaitN(ctx, 1)
+}
+
+// WaitN blocks until lim permits n events to happen.
+// It returns an error if n exceeds the Limiter's burst size, the Context is
+// canceled, or the expected wait time exceeds the Context's Deadline.
+func (lim *timeLimiter) WaitN(ctx context.Context, n int) (err error) {
+	// Check if ctx is already cancelled
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	now := time.Now()
+	deadline, _ := ctx.Deadline()
+	r := lim.reserveN(now, n, deadline)
+	if !r.ok {
+		return fmt.Errorf("rate: Wait(n=%d) exceeds limiter's burst or would exceed context deadline", n)
+	}
+	return r.waitFrom(ctx, now)
+}
+
+// reserveN is a helper method for AllowN, ReserveN, and WaitN.
+// deadline specifies the latest time to act allowed, a zero deadline means no deadline.
+func (lim *timeLimiter) reserveN(now time.Time, n int, deadline time.Time) *DelayReservation {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+
+	at := now
+	if lim.last.After(at) {
+		at = lim.last
+	}
+	r := &DelayReservation{lim: lim, n: n}
+	if n <= 0 {
+		r.ok = true
+		r.timeToAct = at
+		return r
+	}
+	timeToAct, ok := lim.alg.reserve(at, n, deadline)
+	if !ok {
+		return r
+	}
+	r.ok = true
+	r.timeToAct = timeToAct
+	lim.last = timeToAct
+	return r
+}
+
+func (lim *timeLimiter) cancel(now time.Time, r *DelayReservation) {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.alg.cancel(now, r.timeToAct, lim.last, r.n)
+}
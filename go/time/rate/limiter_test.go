@@ -0,0 +1,158 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var (
+	_ Limiter = (*TokenBucketLimiter)(nil)
+	_ Limiter = (*GCRALimiter)(nil)
+	_ Limiter = (*SlidingWindowLogLimiter)(nil)
+	_ Limiter = (*SlidingWindowCounterLimiter)(nil)
+)
+
+var t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type reserveAt struct {
+	at    time.Duration // since t0
+	n     int
+	delay time.Duration // expected delay, InfDuration if not ok
+}
+
+func runReserveAt(t *testing.T, lim Limiter, steps []reserveAt) {
+	t.Helper()
+	for i, step := range steps {
+		now := t0.Add(step.at)
+		r := lim.ReserveN(now, step.n)
+		if got := r.DelayFrom(now); got != step.delay {
+			t.Errorf("step %d: lim.ReserveN(t0+%v, %d).DelayFrom() = %v want %v", i, step.at, step.n, got, step.delay)
+		}
+	}
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	runReserveAt(t, NewTokenBucketLimiter(10, 2), []reserveAt{
+		{0, 1, 0},
+		{0, 1, 0},
+		{0, 1, d},
+		{0, 1, 2 * d},
+		{0, 3, InfDuration},
+		{5 * d, 2, 0}, // refilled
+	})
+}
+
+func TestGCRALimiter(t *testing.T) {
+	runReserveAt(t, NewGCRALimiter(10, 2), []reserveAt{
+		{0, 1, 0},
+		{0, 1, 0},
+		{0, 1, d},
+		{0, 1, 2 * d},
+		{0, 3, InfDuration},
+		{5 * d, 2, 0}, // refilled
+	})
+}
+
+func TestSlidingWindowLogLimiter(t *testing.T) {
+	runReserveAt(t, NewSlidingWindowLogLimiter(2, 10*d), []reserveAt{
+		{0, 1, 0},
+		{d, 1, 0},
+		{2 * d, 1, 8 * d}, // wait for the event at t0 to slide out
+		{2 * d, 1, 9 * d}, // wait for the event at t0+d to slide out
+		{2 * d, 3, InfDuration},
+		{30 * d, 2, 0},
+	})
+}
+
+func TestSlidingWindowCounterLimiter(t *testing.T) {
+	runReserveAt(t, NewSlidingWindowCounterLimiter(2, 10*d), []reserveAt{
+		{0, 1, 0},
+		{d, 1, 0},
+		{2 * d, 1, 10*d - 2*d + 5*d}, // next window, weighted previous count 2*(1-0.5) + 0 + 1 <= 2
+		{2 * d, 3, InfDuration},
+		{100 * d, 2, 0},
+	})
+}
+
+func TestLimiterAllowInOrder(t *testing.T) {
+	lim := NewTokenBucketLimiter(10, 1)
+	if !lim.AllowN(t0, 1) {
+		t.Fatal("lim.AllowN(t0, 1) = false; want true")
+	}
+	r := lim.ReserveN(t0, 1)
+	if got := r.DelayFrom(t0); got != d {
+		t.Fatalf("r.DelayFrom(t0) = %v; want %v", got, d)
+	}
+	// a token is refilled at t0+d, but it has been reserved already
+	if lim.AllowN(t0.Add(d), 1) {
+		t.Fatal("lim.AllowN(t0+d, 1) = true; want false")
+	}
+	r.CancelAt(t0)
+	if !lim.AllowN(t0.Add(d), 1) {
+		t.Fatal("lim.AllowN(t0+d, 1) = false after cancel; want true")
+	}
+}
+
+func TestLimiterCancelWithLaterReservations(t *testing.T) {
+	for name, lim := range map[string]Limiter{
+		"TokenBucket": NewTokenBucketLimiter(10, 1),
+		"GCRA":        NewGCRALimiter(10, 1),
+	} {
+		lim.ReserveN(t0, 1)
+		r1 := lim.ReserveN(t0, 1)
+		r2 := lim.ReserveN(t0, 1)
+		if got := r2.DelayFrom(t0); got != 2*d {
+			t.Fatalf("%s: r2.DelayFrom(t0) = %v; want %v", name, got, 2*d)
+		}
+		// the token of r1 is taken by r2 already, nothing is restored
+		r1.CancelAt(t0)
+		if got := lim.ReserveN(t0, 1).DelayFrom(t0); got != 3*d {
+			t.Errorf("%s: lim.ReserveN(t0, 1).DelayFrom(t0) = %v after cancel; want %v", name, got, 3*d)
+		}
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	lim := NewGCRALimiter(Every(d), 1)
+	ctx, cancel := context.WithTimeout(context.Background(), d/2)
+	defer cancel()
+	if err := lim.Wait(ctx); err != nil {
+		t.Fatalf("lim.Wait() = %v; want nil", err)
+	}
+	if err := lim.Wait(ctx); err == nil {
+		t.Fatal("lim.Wait() = nil; want error exceeding context deadline")
+	}
+}
+
+func TestKeyedLimiter(t *testing.T) {
+	var created int
+	k := NewKeyedLimiter(func(key string) Limiter {
+		created++
+		return NewTokenBucketLimiter(0, 1)
+	}, 2, 10*d)
+	if !k.AllowN("a", t0, 1) || k.AllowN("a", t0, 1) {
+		t.Fatal("limiter of key a does not allow exactly one event")
+	}
+	k.AllowN("b", t0, 1)
+	k.AllowN("c", t0, 1) // evicts a as the least recently used
+	if got := k.Len(); got != 2 {
+		t.Fatalf("k.Len() = %d; want 2", got)
+	}
+	if !k.AllowN("a", t0, 1) {
+		t.Fatal("limiter of key a is not evicted")
+	}
+	if !k.AllowN("a", t0.Add(20*d), 1) {
+		t.Fatal("limiter of key a is not evicted after idle timeout")
+	}
+	if got := k.Len(); got != 1 {
+		t.Fatalf("k.Len() = %d; want 1 after idle eviction", got)
+	}
+	if created != 5 {
+		t.Fatalf("created %d limiters; want 5", created)
+	}
+}
@@ -11,7 +11,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
+
+	time_ "github.com/searKing/golang/go/time"
 )
 
 const (
@@ -58,6 +59,8 @@ var expectTokensKey expectKeyType
 //
 // The methods AllowN, ReserveN, and WaitN consume n tokens.
 type BurstLimiter struct {
+	opts burstLimiter
+
 	mu                     sync.Mutex
 	burst                  int // bucket size, Put Must be called after Get
 	tokensChangedListeners []context.Context
@@ -82,21 +85,32 @@ func (lim *BurstLimiter) Tokens() int {
 	return lim.tokens
 }
 
+//go:generate go-option -type "burstLimiter"
+type burstLimiter struct {
+	// Clock tells the time to check deadlines of contexts, and to poll tokens by Reservation.Wait.
+	// time_.RealClock if nil.
+	Clock time_.Clock
+}
+
 // NewFullBurstLimiter returns a new BurstLimiter with full tokens that allows
 // events up to burst b and permits bursts of at most b tokens.
-func NewFullBurstLimiter(b int) *BurstLimiter {
-	return &BurstLimiter{
+func NewFullBurstLimiter(b int, opts ...BurstLimiterOption) *BurstLimiter {
+	lim := &BurstLimiter{
 		burst:  b,
 		tokens: b,
 	}
+	lim.opts.ApplyOptions(opts...)
+	return lim
 }
 
 // NewEmptyBurstLimiter returns a new BurstLimiter with zero tokens that allows
 // events up to burst b and permits bursts of at most b tokens.
-func NewEmptyBurstLimiter(b int) *BurstLimiter {
-	return &BurstLimiter{
+func NewEmptyBurstLimiter(b int, opts ...BurstLimiterOption) *BurstLimiter {
+	lim := &BurstLimiter{
 		burst: b,
 	}
+	lim.opts.ApplyOptions(opts...)
+	return lim
 }
 
 // NewReorderBuffer returns a new BurstLimiter with exactly only one token that allows
@@ -107,8 +121,8 @@ func NewEmptyBurstLimiter(b int) *BurstLimiter {
 // - Reserve Complete by `Cancel` of the Reservation self, GC Cancel supported
 // See https://en.wikipedia.org/wiki/Re-order_buffer for more about Reorder buffer.
 // See https://web.archive.org/web/20040724215416/http://lgjohn.okstate.edu/6253/lectures/reorder.pdf for more about Reorder buffer.
-func NewReorderBuffer() *BurstLimiter {
-	return NewFullBurstLimiter(1)
+func NewReorderBuffer(opts ...BurstLimiterOption) *BurstLimiter {
+	return NewFullBurstLimiter(1, opts...)
 }
 
 // SetBurst sets a new burst size for the limiter.
@@ -319,7 +333,7 @@ func (lim *BurstLimiter) reserveN(ctx context.Context, n int, wait bool, gc bool
 
 	// Decide result
 	var expired bool
-	if deadline, has := ctx.Deadline(); has && deadline.Before(time.Now()) {
+	if deadline, has := ctx.Deadline(); has && deadline.Before(lim.clock().Now()) {
 		expired = true
 	}
 
@@ -353,3 +367,7 @@ func (lim *BurstLimiter) trackReservationRemove(r *reservation) {
 	}
 	return
 }
+
+func (lim *BurstLimiter) clock() time_.Clock {
+	return time_.ClockOrDefault(lim.opts.Clock)
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"runtime"
-	"time"
 )
 
 // A Reservation holds information about events that are permitted by a BurstLimiter to happen after a delay.
@@ -84,7 +83,7 @@ func (r *Reservation) Wait(ctx context.Context) error {
 	if r.burst > burst {
 		return fmt.Errorf("rate: Wait(n=%d) exceeds limiter's burst %d", r.burst, burst)
 	}
-	timer := time.NewTimer(starvationThresholdNs)
+	timer := r.lim.clock().NewTimer(starvationThresholdNs)
 	defer timer.Stop()
 	for {
 		// fast path
@@ -96,7 +95,10 @@ func (r *Reservation) Wait(ctx context.Context) error {
 			}
 			// Wait if necessary
 			if !timer.Stop() {
-				<-timer.C
+				select {
+				case <-timer.C():
+				default:
+				}
 			}
 			timer.Reset(starvationThresholdNs)
 
@@ -106,7 +108,7 @@ func (r *Reservation) Wait(ctx context.Context) error {
 				// reservation, which may permit other events to proceed sooner.
 				r.Cancel()
 				return ctx.Err()
-			case <-timer.C:
+			case <-timer.C():
 				break
 			}
 			continue
@@ -0,0 +1,203 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"time"
+)
+
+// SlidingWindowLogLimiter controls how frequently events are allowed to happen.
+// It permits at most limit events in any sliding window of the given duration,
+// by keeping a log of the reserved events within the window.
+// The log is exact but costs memory up to limit entries.
+type SlidingWindowLogLimiter struct {
+	timeLimiter
+	log slidingWindowLog
+}
+
+// NewSlidingWindowLogLimiter returns a new SlidingWindowLogLimiter that allows
+// at most limit events in any window of duration window.
+func NewSlidingWindowLogLimiter(limit int, window time.Duration) *SlidingWindowLogLimiter {
+	lim := &SlidingWindowLogLimiter{log: slidingWindowLog{limit: limit, window: window}}
+	lim.alg = &lim.log
+	return lim
+}
+
+// Limit returns the maximum number of events in a window.
+func (lim *SlidingWindowLogLimiter) Limit() int {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.log.limit
+}
+
+// Window returns the duration of the sliding window.
+func (lim *SlidingWindowLogLimiter) Window() time.Duration {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.log.window
+}
+
+// SlidingWindowCounterLimiter controls how frequently events are allowed to happen.
+// It permits about limit events in any sliding window of the given duration,
+// by weighting the event count of the previous fixed window with its overlap of the sliding window.
+// The counter is approximate but costs constant memory.
+type SlidingWindowCounterLimiter struct {
+	timeLimiter
+	counter slidingWindowCounter
+}
+
+// NewSlidingWindowCounterLimiter returns a new SlidingWindowCounterLimiter that allows
+// about limit events in any window of duration window.
+func NewSlidingWindowCounterLimiter(limit int, window time.Duration) *SlidingWindowCounterLimiter {
+	lim := &SlidingWindowCounterLimiter{counter: slidingWindowCounter{limit: limit, window: window, counts: make(map[int64]int)}}
+	lim.alg = &lim.counter
+	return lim
+}
+
+// Limit returns the maximum number of events in a window.
+func (lim *SlidingWindowCounterLimiter) Limit() int {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.counter.limit
+}
+
+// Window returns the duration of the sliding window.
+func (lim *SlidingWindowCounterLimiter) Window() time.Duration {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.counter.window
+}
+
+type slidingWindowEntry struct {
+	t time.Time
+	n int
+}
+
+// slidingWindowLog is the algorithm of SlidingWindowLogLimiter.
+type slidingWindowLog struct {
+	limit  int
+	window time.Duration
+
+	entries []slidingWindowEntry // in time order
+}
+
+func (l *slidingWindowLog) reserve(at time.Time, n int, deadline time.Time) (time.Time, bool) {
+	if n > l.limit {
+		return time.Time{}, false
+	}
+	// drop events out of the window, they can never count again as at never goes back
+	var expired int
+	for expired < len(l.entries) && !l.entries[expired].t.After(at.Add(-l.window)) {
+		expired++
+	}
+	l.entries = append(l.entries[:0], l.entries[expired:]...)
+
+	var count int
+	for _, e := range l.entries {
+		count += e.n
+	}
+	timeToAct := at
+	if excess := count + n - l.limit; excess > 0 {
+		// wait for the oldest excess events to slide out of the window
+		for _, e := range l.entries {
+			excess -= e.n
+			if excess <= 0 {
+				timeToAct = e.t.Add(l.window)
+				break
+			}
+		}
+		if timeToAct.Before(at) {
+			timeToAct = at
+		}
+	}
+	if !deadline.IsZero() && timeToAct.After(deadline) {
+		return time.Time{}, false
+	}
+	l.entries = append(l.entries, slidingWindowEntry{t: timeToAct, n: n})
+	return timeToAct, true
+}
+
+func (l *slidingWindowLog) cancel(now time.Time, timeToAct time.Time, lastToAct time.Time, n int) {
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := &l.entries[i]
+		if !e.t.Equal(timeToAct) || e.n < n {
+			continue
+		}
+		e.n -= n
+		if e.n == 0 {
+			l.entries = append(l.entries[:i], l.entries[i+1:]...)
+		}
+		return
+	}
+}
+
+// slidingWindowCounter is the algorithm of SlidingWindowCounterLimiter.
+type slidingWindowCounter struct {
+	limit  int
+	window time.Duration
+
+	counts map[int64]int // fixed window index -> events reserved in the window
+}
+
+func (c *slidingWindowCounter) index(t time.Time) int64 {
+	return t.UnixNano() / int64(c.window)
+}
+
+func (c *slidingWindowCounter) reserve(at time.Time, n int, deadline time.Time) (time.Time, bool) {
+	if n > c.limit || c.window <= 0 {
+		return time.Time{}, false
+	}
+	w := c.index(at)
+	// drop windows which can never be weighted again as at never goes back
+	var last = w
+	for i := range c.counts {
+		if i < w-1 {
+			delete(c.counts, i)
+		} else if i > last {
+			last = i
+		}
+	}
+
+	start := at
+	var timeToAct time.Time
+	for ; w <= last+2; w++ {
+		windowStart := time.Unix(0, w*int64(c.window))
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+		cur, prev := c.counts[w], c.counts[w-1]
+		if cur+n > c.limit {
+			continue
+		}
+		if prev == 0 {
+			timeToAct = start
+			break
+		}
+		// prev * (1 - elapsed/window) + cur + n <= limit
+		elapsed := time.Duration((1 - float64(c.limit-cur-n)/float64(prev)) * float64(c.window))
+		if elapsed >= c.window {
+			continue
+		}
+		timeToAct = windowStart.Add(elapsed)
+		if timeToAct.Before(start) {
+			timeToAct = start
+		}
+		break
+	}
+	if !deadline.IsZero() && timeToAct.After(deadline) {
+		return time.Time{}, false
+	}
+	c.counts[c.index(timeToAct)] += n
+	return timeToAct, true
+}
+
+func (c *slidingWindowCounter) cancel(now time.Time, timeToAct time.Time, lastToAct time.Time, n int) {
+	w := c.index(timeToAct)
+	if c.counts[w] <= n {
+		delete(c.counts, w)
+		return
+	}
+	c.counts[w] -= n
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rate
+
+import (
+	"time"
+)
+
+// TokenBucketLimiter controls how frequently events are allowed to happen.
+// It implements a "token bucket" of size b, initially full and refilled
+// at rate r tokens per second.
+// Informally, in any large enough time interval, the TokenBucketLimiter limits the
+// rate to r tokens per second, with a maximum burst size of b events.
+// As a special case, if r == Inf (the infinite rate), b is ignored.
+// See https://en.wikipedia.org/wiki/Token_bucket for more about token buckets.
+//
+// Unlike BurstLimiter, tokens are put back by the passage of time, not by PutToken.
+type TokenBucketLimiter struct {
+	timeLimiter
+	bucket tokenBucket
+}
+
+// NewTokenBucketLimiter returns a new TokenBucketLimiter that allows events up to rate r
+// and permits bursts of at most b tokens.
+func NewTokenBucketLimiter(r Limit, b int) *TokenBucketLimiter {
+	lim := &TokenBucketLimiter{bucket: tokenBucket{limit: r, burst: b, tokens: float64(b)}}
+	lim.alg = &lim.bucket
+	return lim
+}
+
+// Limit returns the maximum overall event rate.
+func (lim *TokenBucketLimiter) Limit() Limit {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.bucket.limit
+}
+
+// Burst returns the maximum burst size.
+func (lim *TokenBucketLimiter) Burst() int {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.bucket.burst
+}
+
+// Tokens is shorthand for TokensAt(time.Now()).
+func (lim *TokenBucketLimiter) Tokens() float64 {
+	return lim.TokensAt(time.Now())
+}
+
+// TokensAt returns the number of tokens available at time t.
+func (lim *TokenBucketLimiter) TokensAt(t time.Time) float64 {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.bucket.advance(t)
+}
+
+// SetLimitAt sets a new Limit for the limiter. The new Limit, and Burst, may be violated
+// or underutilized by those which reserved (using Reserve or Wait) but did not yet act
+// before SetLimitAt was called.
+func (lim *TokenBucketLimiter) SetLimitAt(t time.Time, newLimit Limit) {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.bucket.tokens = lim.bucket.advance(t)
+	lim.bucket.last = t
+	lim.bucket.limit = newLimit
+}
+
+// SetBurstAt sets a new burst size for the limiter.
+func (lim *TokenBucketLimiter) SetBurstAt(t time.Time, newBurst int) {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.bucket.tokens = lim.bucket.advance(t)
+	lim.bucket.last = t
+	lim.bucket.burst = newBurst
+}
+
+// tokenBucket is the algorithm of TokenBucketLimiter.
+type tokenBucket struct {
+	limit  Limit
+	burst  int
+	tokens float64   // tokens at time last, negative if reserved in future
+	last   time.Time // the last time the tokens field was updated
+}
+
+// advance calculates and returns the tokens resulting from the passage of time.
+// b is not changed.
+func (b *tokenBucket) advance(t time.Time) float64 {
+	last := b.last
+	if t.Before(last) {
+		last = t
+	}
+	// Calculate the new number of tokens, due to time that passed.
+	tokens := b.tokens + b.limit.tokensFromDuration(t.Sub(last))
+	if burst := float64(b.burst); tokens > burst {
+		tokens = burst
+	}
+	return tokens
+}
+
+func (b *tokenBucket) reserve(at time.Time, n int, deadline time.Time) (time.Time, bool) {
+	if b.limit == Inf {
+		return at, true
+	}
+	if n > b.burst {
+		return time.Time{}, false
+	}
+	tokens := b.advance(at) - float64(n)
+	timeToAct := at
+	if tokens < 0 {
+		if b.limit <= 0 {
+			return time.Time{}, false
+		}
+		timeToAct = at.Add(b.limit.durationFromTokens(-tokens))
+	}
+	if !deadline.IsZero() && timeToAct.After(deadline) {
+		return time.Time{}, false
+	}
+	if at.After(b.last) {
+		b.last = at
+	}
+	b.tokens = tokens
+	return timeToAct, true
+}
+
+func (b *tokenBucket) cancel(now time.Time, timeToAct time.Time, lastToAct time.Time, n int) {
+	if b.limit == Inf {
+		return
+	}
+	// calculate tokens to restore
+	// The duration between lastToAct and timeToAct tells us how many tokens were reserved
+	// after this reservation. These tokens should not be restored.
+	restore := float64(n) - b.limit.tokensFromDuration(lastToAct.Sub(timeToAct))
+	if restore <= 0 {
+		return
+	}
+	tokens := b.advance(now) + restore
+	if burst := float64(b.burst); tokens > burst {
+		tokens = burst
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+	b.tokens = tokens
+}
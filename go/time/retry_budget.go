@@ -0,0 +1,101 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRetryBudget is the process-wide RetryBudget, allowing retries of 20% of the requests
+// in the last 10 seconds, plus 10 retries per second.
+var DefaultRetryBudget = NewRetryBudget(0.2, 10, 10*time.Second)
+
+// RetryBudget limits retries to a percentage of recent requests, so that retries can not
+// multiply the load of an upstream during an incident, as retries of retries do in a retry storm.
+// Every request deposits to the budget, and every retry withdraws from it.
+// The key observation is borrowed from Finagle's RetryBudget.
+// See https://twitter.github.io/finagle/guide/Clients.html#retries for more about retry budgets.
+type RetryBudget struct {
+	ratio               float64
+	minRetriesPerSecond int
+	ttl                 time.Duration
+
+	mu             sync.Mutex
+	bucketDuration time.Duration
+	buckets        []retryBudgetBucket
+}
+
+type retryBudgetBucket struct {
+	epoch    int64 // index of the bucket since the Unix epoch
+	requests int
+	retries  int
+}
+
+// NewRetryBudget returns a new RetryBudget which allows retries of ratio of the requests deposited in ttl,
+// plus minRetriesPerSecond retries per second, so that clients with few requests can still retry.
+func NewRetryBudget(ratio float64, minRetriesPerSecond int, ttl time.Duration) *RetryBudget {
+	const n = 10
+	bucketDuration := ttl / n
+	if bucketDuration <= 0 {
+		bucketDuration = 1
+	}
+	return &RetryBudget{
+		ratio:               ratio,
+		minRetriesPerSecond: minRetriesPerSecond,
+		ttl:                 ttl,
+		bucketDuration:      bucketDuration,
+		buckets:             make([]retryBudgetBucket, n),
+	}
+}
+
+// Deposit records a request, not a retry.
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucketLocked(time.Now()).requests++
+}
+
+// TryWithdraw reports whether a retry is allowed, withdrawing it from the budget if so.
+func (b *RetryBudget) TryWithdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.balanceLocked(now) < 1 {
+		return false
+	}
+	b.bucketLocked(now).retries++
+	return true
+}
+
+// Balance returns the number of retries allowed now.
+func (b *RetryBudget) Balance() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int(b.balanceLocked(time.Now()))
+}
+
+func (b *RetryBudget) balanceLocked(now time.Time) float64 {
+	epoch := now.UnixNano() / int64(b.bucketDuration)
+	var requests, retries int
+	for _, bucket := range b.buckets {
+		if bucket.epoch <= epoch-int64(len(b.buckets)) || bucket.epoch > epoch {
+			continue
+		}
+		requests += bucket.requests
+		retries += bucket.retries
+	}
+	reserve := float64(b.minRetriesPerSecond) * b.ttl.Seconds()
+	return reserve + b.ratio*float64(requests) - float64(retries)
+}
+
+func (b *RetryBudget) bucketLocked(now time.Time) *retryBudgetBucket {
+	epoch := now.UnixNano() / int64(b.bucketDuration)
+	bucket := &b.buckets[epoch%int64(len(b.buckets))]
+	if bucket.epoch != epoch {
+		*bucket = retryBudgetBucket{epoch: epoch}
+	}
+	return bucket
+}
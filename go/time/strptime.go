@@ -0,0 +1,545 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StrptimeError describes a problem parsing a time string by a strftime layout.
+type StrptimeError struct {
+	Layout       string
+	Value        string
+	LayoutOffset int    // byte offset of LayoutElem in Layout
+	ValueOffset  int    // byte offset in Value where parsing failed
+	LayoutElem   string // directive or literal text expected, such as "%Y"
+	Message      string
+}
+
+func (e *StrptimeError) Error() string {
+	return fmt.Sprintf("parsing time %q as %q: cannot parse %q as %q (layout offset %d, value offset %d): %s",
+		e.Value, e.Layout, e.Value[e.ValueOffset:], e.LayoutElem, e.LayoutOffset, e.ValueOffset, e.Message)
+}
+
+// Strptime is like StrptimeInLocation, but interprets a time without a time zone as UTC.
+func Strptime(layout, value string) (time.Time, error) {
+	return StrptimeInLocation(layout, value, time.UTC)
+}
+
+// StrptimeInLocation parses a formatted string by a strftime layout, such as "%Y-%m-%d %H:%M:%S",
+// and returns the time value it represents, the inverse of strftime.
+// A time without a time zone is interpreted in loc.
+//
+// All directives of strftime are supported, with locale-independent ("C" locale) behaviour:
+//   - names of weekdays and months (%a %A %b %B %h) are matched case-insensitively,
+//     both in abbreviated and full form;
+//   - %E and %O modifiers are ignored, such as %Ey is parsed as %y;
+//   - white space in the layout, %n and %t match zero or more white space in the value;
+//   - numeric fields accept leading spaces and fewer digits than printed, such as %d matches " 1";
+//   - %s, the seconds since the Epoch, overrides all other date and time directives;
+//   - %z matches "Z", "±hh", "±hhmm", "±hh:mm", "±hhmmss" and "±hh:mm:ss";
+//   - %f matches fractional seconds, 1 to 9 digits.
+//
+// The date is resolved from the parsed fields in order: month and day of month; day of year (%j);
+// week of year (%U, %W) with weekday; ISO 8601 week (%V, %G, %g) with weekday.
+// %y without %C maps 69-99 to 1969-1999 and 00-68 to 2000-2068, as POSIX does.
+// Elements omitted from the layout are assumed to be zero or, when zero is impossible,
+// one, as time.Parse does.
+func StrptimeInLocation(layout, value string, loc *time.Location) (time.Time, error) {
+	p := strptimeParser{layout: layout, value: value}
+	p.reset()
+	if err := p.parse(layout); err != nil {
+		return time.Time{}, err
+	}
+	if p.vpos < len(value) {
+		return time.Time{}, p.errorf(len(layout), "", "extra text")
+	}
+	return p.resolve(loc)
+}
+
+type strptimeParser struct {
+	layout string
+	value  string
+	vpos   int // offset in value
+
+	// directive being parsed, for errors
+	loff      int
+	lelem     string
+	composite bool         // parsing the expansion of a composite directive, such as %c
+	dateAt    strptimeMark // where the field resolving the date is parsed, for errors
+
+	year, century, yy       int
+	haveYear, haveCentury   bool
+	haveYY                  bool
+	month, day, yday        int
+	hour, min, sec, nsec    int
+	hour12                  bool
+	pm                      int // -1 if not parsed, 0 for am, 1 for pm
+	weekday                 int // -1 if not parsed, 0 for Sunday
+	weekU, weekW, isoWeek   int // -1 if not parsed
+	isoYear, isoYY          int // -1 if not parsed
+	epoch                   int64
+	haveEpoch               bool
+	zoneOffset              int
+	haveZoneOffset          bool
+	zoneName                string
+	haveMonthDay, haveYDays bool
+}
+
+// strptimeMark is a position of a directive in the layout and the value.
+type strptimeMark struct {
+	loff  int
+	lelem string
+	vpos  int
+}
+
+func (p *strptimeParser) mark() strptimeMark {
+	return strptimeMark{loff: p.loff, lelem: p.lelem, vpos: p.vpos}
+}
+
+func (p *strptimeParser) errorAt(m strptimeMark, format string, args ...any) error {
+	p.vpos = m.vpos
+	return p.errorf(m.loff, m.lelem, format, args...)
+}
+
+func (p *strptimeParser) reset() {
+	p.month, p.day = 1, 1
+	p.pm, p.weekday = -1, -1
+	p.weekU, p.weekW, p.isoWeek = -1, -1, -1
+	p.isoYear, p.isoYY = -1, -1
+}
+
+func (p *strptimeParser) errorf(loff int, lelem string, format string, args ...any) error {
+	return &StrptimeError{
+		Layout:       p.layout,
+		Value:        p.value,
+		LayoutOffset: loff,
+		ValueOffset:  p.vpos,
+		LayoutElem:   lelem,
+		Message:      fmt.Sprintf(format, args...),
+	}
+}
+
+// parse matches layout against value from p.vpos.
+func (p *strptimeParser) parse(layout string) error {
+	base := 0
+	for layout != "" {
+		prefix, std_, suffix := nextStrftimeChunk(layout)
+		if err := p.parseLiteral(prefix, base); err != nil {
+			return err
+		}
+		if std_ == 0 {
+			break
+		}
+		if !p.composite {
+			p.loff, p.lelem = base+len(prefix), layout[len(prefix):len(layout)-len(suffix)]
+		}
+		if err := p.parseStd(std_); err != nil {
+			return err
+		}
+		base += len(layout) - len(suffix)
+		layout = suffix
+	}
+	return nil
+}
+
+// parseComposite matches the expansion of a composite directive, such as %c,
+// errors are reported at the composite directive.
+func (p *strptimeParser) parseComposite(layout string) error {
+	p.composite = true
+	defer func() { p.composite = false }()
+	return p.parse(layout)
+}
+
+// parseLiteral matches literal text of the layout at offset loff.
+func (p *strptimeParser) parseLiteral(literal string, loff int) error {
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+		if c == '%' {
+			return p.errorf(loff+i, literal[i:min(i+2, len(literal))], "unsupported directive")
+		}
+		if isSpace(c) {
+			p.skipSpaces()
+			continue
+		}
+		if p.vpos >= len(p.value) || p.value[p.vpos] != c {
+			if p.composite {
+				return p.errorf(p.loff, p.lelem, "literal %q mismatch", c)
+			}
+			return p.errorf(loff+i, literal[i:i+1], "literal mismatch")
+		}
+		p.vpos++
+	}
+	return nil
+}
+
+func (p *strptimeParser) parseStd(std_ int) (err error) {
+	switch std_ &^ (stdNeedEModifier | stdNeedOModifier) & stdMask {
+	case stdWeekDay, stdLongWeekDay:
+		p.weekday, err = p.lookupName(longDayNames, shortDayNames)
+	case stdMonth, stdLongMonth:
+		var month int
+		month, err = p.lookupName(longMonthNames, shortMonthNames)
+		p.month, p.haveMonthDay = month+1, true
+	case stdDateAndTime:
+		return p.parseComposite("%a %b %e %H:%M:%S %Y")
+	case stdShortSlashDate:
+		return p.parseComposite("%m/%d/%y")
+	case stdShortDashDate:
+		return p.parseComposite("%Y-%m-%d")
+	case stdHour12ClockTime:
+		return p.parseComposite("%I:%M:%S %p")
+	case stdHourHourMinuteTime:
+		return p.parseComposite("%H:%M")
+	case stdHourClockTime, stdISO8601Time:
+		return p.parseComposite("%H:%M:%S")
+	case stdFirstTwoDigitYear:
+		p.century, err = p.getNum(0, 99, 2, "century")
+		p.haveCentury = true
+	case stdZeroDay, stdUnderDay:
+		p.dateAt = p.mark()
+		p.day, err = p.getNum(1, 31, 2, "day of month")
+		p.haveMonthDay = true
+	case stdZeroMonth:
+		p.month, err = p.getNum(1, 12, 2, "month")
+		p.haveMonthDay = true
+	case stdDayOfYear:
+		p.dateAt = p.mark()
+		p.yday, err = p.getNum(1, 366, 3, "day of year")
+		p.haveYDays = true
+	case stdISO8601WeekYear:
+		p.isoYY, err = p.getNum(0, 99, 2, "ISO 8601 week-based year")
+	case stdISO8601LongWeekYear:
+		p.isoYear, err = p.getNum(0, 9999, 4, "ISO 8601 week-based year")
+	case stdHour:
+		p.hour, err = p.getNum(0, 23, 2, "hour")
+		p.hour12 = false
+	case stdZeroHour12:
+		p.hour, err = p.getNum(1, 12, 2, "hour")
+		p.hour12 = true
+	case stdZeroMinute:
+		p.min, err = p.getNum(0, 59, 2, "minute")
+	case stdZeroSecond:
+		// 60 for leap seconds
+		p.sec, err = p.getNum(0, 60, 2, "second")
+	case stdFracSecond0, stdFracSecond9:
+		err = p.parseFraction()
+	case stdCharNewLine, stdCharHorizontalTab:
+		p.skipSpaces()
+	case stdCharPercentSign:
+		if p.vpos >= len(p.value) || p.value[p.vpos] != '%' {
+			return p.errorf(p.loff, p.lelem, "literal mismatch")
+		}
+		p.vpos++
+	case stdPM, stdpm:
+		p.pm, err = p.lookupName([]string{"am", "pm"}, []string{"a.m.", "p.m."})
+	case stdSecondsSinceEpoch:
+		err = p.parseEpoch()
+	case stdNumWeekDay:
+		var wd int
+		wd, err = p.getNum(1, 7, 1, "weekday")
+		p.weekday = wd % 7
+	case stdZeroNumWeek:
+		p.weekday, err = p.getNum(0, 6, 1, "weekday")
+	case stdSundayFirstWeekOfYear:
+		p.dateAt = p.mark()
+		p.weekU, err = p.getNum(0, 53, 2, "week of year")
+	case stdMonFirstWeekOfYear:
+		p.dateAt = p.mark()
+		p.weekW, err = p.getNum(0, 53, 2, "week of year")
+	case stdISO8601Week, stdISO8601NumWeek:
+		p.dateAt = p.mark()
+		p.isoWeek, err = p.getNum(1, 53, 2, "ISO 8601 week")
+	case stdYear:
+		p.yy, err = p.getNum(0, 99, 2, "year")
+		p.haveYY = true
+	case stdLongYear:
+		p.year, err = p.getNum(0, 9999, 4, "year")
+		p.haveYear = true
+	case stdISO8601ColonTZ, stdISO8601TZ, stdNumTZ:
+		err = p.parseZoneOffset()
+	case stdTZ:
+		err = p.parseZoneName()
+	default:
+		return p.errorf(p.loff, p.lelem, "unsupported directive")
+	}
+	return err
+}
+
+func (p *strptimeParser) skipSpaces() {
+	for p.vpos < len(p.value) && isSpace(p.value[p.vpos]) {
+		p.vpos++
+	}
+}
+
+// getNum parses a decimal number in [min, max] of 1 to maxDigits digits, after optional spaces.
+func (p *strptimeParser) getNum(min, max, maxDigits int, name string) (int, error) {
+	p.skipSpaces()
+	start := p.vpos
+	n := 0
+	for p.vpos < len(p.value) && p.vpos-start < maxDigits && isDigit(p.value, p.vpos) {
+		n = n*10 + int(p.value[p.vpos]-'0')
+		p.vpos++
+	}
+	if p.vpos == start {
+		return 0, p.errorf(p.loff, p.lelem, "expected %s", name)
+	}
+	if n < min || n > max {
+		p.vpos = start
+		return 0, p.errorf(p.loff, p.lelem, "%s out of range", name)
+	}
+	return n, nil
+}
+
+// lookupName matches one of names case-insensitively, preferring the longer ones,
+// and returns its index.
+func (p *strptimeParser) lookupName(long, short []string) (int, error) {
+	rest := p.value[p.vpos:]
+	for _, names := range [][]string{long, short} {
+		for i, name := range names {
+			if len(rest) >= len(name) && strings.EqualFold(rest[:len(name)], name) {
+				p.vpos += len(name)
+				return i, nil
+			}
+		}
+	}
+	return 0, p.errorf(p.loff, p.lelem, "bad name")
+}
+
+func (p *strptimeParser) parseFraction() error {
+	start := p.vpos
+	scale := int(1e9)
+	for p.vpos < len(p.value) && p.vpos-start < 9 && isDigit(p.value, p.vpos) {
+		scale /= 10
+		p.nsec += int(p.value[p.vpos]-'0') * scale
+		p.vpos++
+	}
+	if p.vpos == start {
+		return p.errorf(p.loff, p.lelem, "expected fractional second")
+	}
+	return nil
+}
+
+func (p *strptimeParser) parseEpoch() error {
+	p.skipSpaces()
+	start := p.vpos
+	neg := p.vpos < len(p.value) && p.value[p.vpos] == '-'
+	if neg {
+		p.vpos++
+	}
+	var n int64
+	digits := p.vpos
+	for p.vpos < len(p.value) && isDigit(p.value, p.vpos) {
+		if n > (1<<63-1)/10 {
+			p.vpos = start
+			return p.errorf(p.loff, p.lelem, "seconds since the Epoch out of range")
+		}
+		n = n*10 + int64(p.value[p.vpos]-'0')
+		p.vpos++
+	}
+	if p.vpos == digits {
+		p.vpos = start
+		return p.errorf(p.loff, p.lelem, "expected seconds since the Epoch")
+	}
+	if neg {
+		n = -n
+	}
+	p.epoch, p.haveEpoch = n, true
+	return nil
+}
+
+// parseZoneOffset parses "Z", "±hh", "±hhmm", "±hh:mm", "±hhmmss" or "±hh:mm:ss".
+func (p *strptimeParser) parseZoneOffset() error {
+	start := p.vpos
+	if p.vpos < len(p.value) && (p.value[p.vpos] == 'Z' || p.value[p.vpos] == 'z') {
+		p.vpos++
+		p.zoneOffset, p.haveZoneOffset = 0, true
+		return nil
+	}
+	if p.vpos >= len(p.value) || (p.value[p.vpos] != '+' && p.value[p.vpos] != '-') {
+		return p.errorf(p.loff, p.lelem, "expected time zone offset")
+	}
+	sign := 1
+	if p.value[p.vpos] == '-' {
+		sign = -1
+	}
+	p.vpos++
+
+	var parts [3]int
+	var n int
+	for n = 0; n < len(parts); n++ {
+		if n > 0 && p.vpos < len(p.value) && p.value[p.vpos] == ':' {
+			p.vpos++
+		}
+		if p.vpos+2 > len(p.value) || !isDigit(p.value, p.vpos) || !isDigit(p.value, p.vpos+1) {
+			break
+		}
+		parts[n] = int(p.value[p.vpos]-'0')*10 + int(p.value[p.vpos+1]-'0')
+		p.vpos += 2
+	}
+	if n == 0 {
+		p.vpos = start
+		return p.errorf(p.loff, p.lelem, "expected time zone offset")
+	}
+	if p.value[p.vpos-1] == ':' {
+		p.vpos--
+	}
+	if parts[0] > 24 || parts[1] > 59 || parts[2] > 59 {
+		p.vpos = start
+		return p.errorf(p.loff, p.lelem, "time zone offset out of range")
+	}
+	p.zoneOffset = sign * (parts[0]*3600 + parts[1]*60 + parts[2])
+	p.haveZoneOffset = true
+	return nil
+}
+
+// parseZoneName parses a time zone abbreviation, such as "UTC", "CST" or "ChST".
+func (p *strptimeParser) parseZoneName() error {
+	start := p.vpos
+	for p.vpos < len(p.value) && isLetter(p.value[p.vpos]) {
+		p.vpos++
+	}
+	if n := p.vpos - start; n < 3 || n > 5 {
+		p.vpos = start
+		return p.errorf(p.loff, p.lelem, "bad time zone name")
+	}
+	p.zoneName = p.value[start:p.vpos]
+	return nil
+}
+
+// resolve builds the time from the parsed fields.
+func (p *strptimeParser) resolve(loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if p.haveZoneOffset {
+		loc = time.FixedZone(p.zoneName, p.zoneOffset)
+	} else if p.zoneName == "UTC" || p.zoneName == "GMT" {
+		loc = time.UTC
+	}
+
+	if p.haveEpoch {
+		return time.Unix(p.epoch, int64(p.nsec)).In(loc), nil
+	}
+
+	year := p.year
+	if !p.haveYear && (p.haveCentury || p.haveYY) {
+		century := p.century
+		if !p.haveCentury {
+			century = 19
+			if p.yy < 69 {
+				century = 20
+			}
+		}
+		year = century*100 + p.yy
+	}
+
+	hour := p.hour
+	if p.hour12 {
+		hour %= 12
+		if p.pm == 1 {
+			hour += 12
+		}
+	}
+
+	var t time.Time
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, p.min, p.sec, p.nsec, loc)
+	}
+	switch {
+	case p.haveMonthDay:
+		if p.day > daysIn(time.Month(p.month), year) {
+			return time.Time{}, p.errorAt(p.dateAt, "day out of range")
+		}
+		t = date(time.Month(p.month), p.day)
+	case p.haveYDays:
+		if p.yday > daysInYear(year) {
+			return time.Time{}, p.errorAt(p.dateAt, "day of year out of range")
+		}
+		t = date(time.January, p.yday)
+	case p.weekU >= 0 || p.weekW >= 0:
+		// days since Jan 1 of the first day of week one
+		jan1 := int(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday())
+		var yday int
+		if p.weekU >= 0 {
+			weekday := max(p.weekday, 0) // Sunday by default
+			yday = (7-jan1)%7 + (p.weekU-1)*7 + weekday
+		} else {
+			weekday := 1 // Monday by default
+			if p.weekday >= 0 {
+				weekday = p.weekday
+			}
+			yday = (8-jan1)%7 + (p.weekW-1)*7 + (weekday+6)%7
+		}
+		if yday < 0 || yday >= daysInYear(year) {
+			return time.Time{}, p.errorAt(p.dateAt, "week of year out of range")
+		}
+		t = date(time.January, yday+1)
+	case p.isoWeek >= 0 || p.isoYear >= 0 || p.isoYY >= 0:
+		isoYear := year
+		if p.isoYear >= 0 {
+			isoYear = p.isoYear
+		} else if p.isoYY >= 0 {
+			isoYear = 2000 + p.isoYY
+		}
+		week := max(p.isoWeek, 1)
+		weekday := 1 // Monday by default
+		if p.weekday >= 0 {
+			weekday = p.weekday
+		}
+		// Jan 4 is always in week one of ISO 8601
+		jan4 := time.Date(isoYear, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := 4 - (int(jan4.Weekday())+6)%7
+		t = time.Date(isoYear, time.January, monday+(week-1)*7+(weekday+6)%7, hour, p.min, p.sec, p.nsec, loc)
+		if _, w := t.ISOWeek(); w != week {
+			return time.Time{}, p.errorAt(p.dateAt, "ISO 8601 week out of range")
+		}
+	default:
+		t = date(time.January, 1)
+	}
+
+	if p.zoneName != "" && !p.haveZoneOffset && loc != time.UTC {
+		// use loc if it knows the abbreviation, as time.Parse does
+		if name, _ := t.Zone(); name != p.zoneName {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+				time.FixedZone(p.zoneName, 0))
+		}
+	}
+	return t, nil
+}
+
+var longDayNames = []string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+var shortDayNames = []string{
+	"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+}
+
+var longMonthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var shortMonthNames = []string{
+	"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+}
+
+// daysIn returns the number of days in month of year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// daysInYear returns the number of days in year.
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+func isLetter(c byte) bool { return 'a' <= c|0x20 && c|0x20 <= 'z' }
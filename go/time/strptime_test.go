@@ -0,0 +1,110 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestStrptime(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  string
+		want   string // in RFC3339Nano
+	}{
+		{"%Y-%m-%d %H:%M:%S", "2001-08-23 14:55:02", "2001-08-23T14:55:02Z"},
+		{"%F %T", "2001-08-23 14:55:02", "2001-08-23T14:55:02Z"},
+		{"%c", "Thu Aug 23 14:55:02 2001", "2001-08-23T14:55:02Z"},
+		{"%Ec", "thursday AUGUST 23 14:55:02 2001", "2001-08-23T14:55:02Z"},
+		{"%D %r", "08/23/01 02:55:02 pm", "2001-08-23T14:55:02Z"},
+		{"%x %X", "08/23/69 14:55:02", "1969-08-23T14:55:02Z"},
+		{"%d/%b/%Y:%H:%M:%S %z", "23/Aug/2001:14:55:02 +0800", "2001-08-23T14:55:02+08:00"},
+		{"%Y-%m-%dT%H:%M:%S%z", "2001-08-23T14:55:02-07:30", "2001-08-23T14:55:02-07:30"},
+		{"%Y-%m-%dT%H:%M:%S%z", "2001-08-23T14:55:02Z", "2001-08-23T14:55:02Z"},
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2001-08-23T14:55:02.123+08", "2001-08-23T14:55:02.123+08:00"},
+		{"%Y%m%d%H%M", "200108231455", "2001-08-23T14:55:00Z"},
+		{"%e-%b-%Y", " 3-Aug-2001", "2001-08-03T00:00:00Z"},
+		{"%Y %j", "2024 366", "2024-12-31T00:00:00Z"},
+		{"%Y %U %a", "2001 33 Thu", "2001-08-23T00:00:00Z"},
+		{"%Y %W %u", "2001 34 4", "2001-08-23T00:00:00Z"},
+		{"%G-W%V-%u", "2001-W34-4", "2001-08-23T00:00:00Z"},
+		{"%G-W%V-%u", "2020-W53-5", "2021-01-01T00:00:00Z"},
+		{"%C%y", "1999", "1999-01-01T00:00:00Z"},
+		{"%s", "998578502", "2001-08-23T14:55:02Z"},
+		{"%I:%M %p", "12:30 AM", "0000-01-01T00:30:00Z"},
+		{"%I:%M %p", "12:30 pm", "0000-01-01T12:30:00Z"},
+		{"%H%n%M%t%S %%", "14 \t 55\n02 %", "0000-01-01T14:55:02Z"},
+		{"%Y %m %d", "2001  8  3", "2001-08-03T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, err := time_.Strptime(tt.layout, tt.value)
+			if err != nil {
+				t.Fatalf("Strptime(%q, %q): %v", tt.layout, tt.value, err)
+			}
+			if s := got.Format(time.RFC3339Nano); s != tt.want {
+				t.Errorf("Strptime(%q, %q) = %s; want %s", tt.layout, tt.value, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrptimeInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone is not available: %v", err)
+	}
+	got, err := time_.StrptimeInLocation("%Y-%m-%d %H:%M %Z", "2021-07-01 12:00 EDT", loc)
+	if err != nil {
+		t.Fatalf("StrptimeInLocation: %v", err)
+	}
+	if want := time.Date(2021, 7, 1, 12, 0, 0, 0, loc); !got.Equal(want) || got.Location() != loc {
+		t.Fatalf("StrptimeInLocation = %s; want %s", got, want)
+	}
+
+	got, err = time_.StrptimeInLocation("%Y-%m-%d %H:%M", "2021-07-01 12:00", loc)
+	if err != nil {
+		t.Fatalf("StrptimeInLocation: %v", err)
+	}
+	if want := time.Date(2021, 7, 1, 16, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("StrptimeInLocation = %s; want %s", got, want)
+	}
+}
+
+func TestStrptimeError(t *testing.T) {
+	tests := []struct {
+		layout       string
+		value        string
+		layoutOffset int
+		valueOffset  int
+		layoutElem   string
+	}{
+		{"%Y-%m-%d", "2001-13-01", 3, 5, "%m"},
+		{"%Y-%m-%d", "2001-02-30", 6, 8, "%d"},
+		{"%Y-%m-%d", "2001/02/03", 2, 4, "-"},
+		{"%Y-%m-%d", "2001-02-03 junk", 8, 10, ""},
+		{"date: %F", "date: 2001-0x-03", 6, 11, "%F"},
+		{"%Y %q", "2001 x", 3, 5, "%q"},
+		{"%a %Y", "Thx 2001", 0, 0, "%a"},
+		{"%Y %j", "2001 366", 3, 5, "%j"},
+		{"%z", "+2500", 0, 0, "%z"},
+	}
+	for _, tt := range tests {
+		_, err := time_.Strptime(tt.layout, tt.value)
+		var perr *time_.StrptimeError
+		if !errors.As(err, &perr) {
+			t.Errorf("Strptime(%q, %q) = %v; want *StrptimeError", tt.layout, tt.value, err)
+			continue
+		}
+		if perr.LayoutOffset != tt.layoutOffset || perr.ValueOffset != tt.valueOffset || perr.LayoutElem != tt.layoutElem {
+			t.Errorf("Strptime(%q, %q) = %v; want layout offset %d, value offset %d at %q",
+				tt.layout, tt.value, err, tt.layoutOffset, tt.valueOffset, tt.layoutElem)
+		}
+	}
+}
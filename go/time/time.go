@@ -34,9 +34,16 @@ func TruncateByLocation(t time.Time, d time.Duration) time.Time {
 		t.Location())
 }
 
-// Duration is alias of time.Duration for marshal and unmarshal
+// Duration is alias of time.Duration for marshal and unmarshal.
+// Duration is marshaled as time.Duration.String does, such as "36h0m0s", and unmarshalled
+// from any form accepted by ParseDuration, such as "36h", "1.5d" or "P1DT12H".
 type Duration time.Duration
 
+// String returns the duration as time.Duration.String does.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
 func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(time.Duration(d).String())
 }
@@ -55,7 +62,7 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 		*d = Duration(time.Duration(value))
 		return nil
 	case string:
-		tmp, err := time.ParseDuration(value)
+		tmp, err := ParseDuration(value)
 		if err != nil {
 			return err
 		}
@@ -73,14 +80,28 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 }
 
 func (d Duration) MarshalText() ([]byte, error) {
-	return json.Marshal(time.Duration(d).String())
+	return []byte(time.Duration(d).String()), nil
 }
 
 func (d *Duration) UnmarshalText(data []byte) error {
-	tmp, err := time.ParseDuration(string(data))
+	tmp, err := ParseDuration(string(data))
 	if err != nil {
 		return err
 	}
 	*d = Duration(tmp)
 	return nil
 }
+
+// MarshalYAML implements a YAML Marshaler for Duration
+func (d Duration) MarshalYAML() (any, error) {
+	return time.Duration(d).String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for Duration
+func (d *Duration) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+//go:generate go-option -type "timingWheel"
+type timingWheel struct {
+	// Tick is the resolution of the timing wheel, timers fire at most one Tick late.
+	// 10ms if not positive.
+	Tick time.Duration
+	// WheelSize is the number of buckets of each wheel in the hierarchy,
+	// a timer expiring within Tick*WheelSize is put into the lowest wheel,
+	// and then within Tick*WheelSize^2 into the wheel above it, and so on.
+	// 512 if less than 2.
+	WheelSize int
+}
+
+// TimingWheel is a hashed hierarchical timing wheel, which manages a large number of timers,
+// such as idle timeouts of connections, with O(1) insert and cancel, and a single goroutine
+// ticking the wheels, instead of a runtime timer each.
+// The cost is the precision, timers fire at the resolution of Tick.
+//
+// See "Hashed and Hierarchical Timing Wheels" by George Varghese and Tony Lauck.
+type TimingWheel struct {
+	opts timingWheel
+
+	mu      sync.Mutex
+	start   time.Time
+	current int64            // ticks elapsed since start, all timers before it are expired
+	wheels  [][]*wheelBucket // wheels[i] has buckets of Tick*WheelSize^i each
+	size    int              // number of pending timers
+	stopped bool
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// WheelTimer represents a single event on a TimingWheel.
+// When the WheelTimer expires, f is called in its own goroutine.
+// A WheelTimer must be created with TimingWheel.AfterFunc.
+type WheelTimer struct {
+	tw         *TimingWheel
+	f          func()
+	expiration int64 // in ticks since tw.start
+
+	// guarded by tw.mu
+	bucket     *wheelBucket
+	prev, next *WheelTimer
+}
+
+// wheelBucket is an intrusive doubly linked list of timers, so that a timer can be
+// removed in O(1) without an allocation per timer.
+type wheelBucket struct {
+	root WheelTimer // sentinel
+}
+
+// NewTimingWheel returns a new TimingWheel, which starts ticking immediately.
+// Call Stop to release its goroutine.
+func NewTimingWheel(opts ...TimingWheelOption) *TimingWheel {
+	tw := &TimingWheel{stopCh: make(chan struct{})}
+	tw.opts.ApplyOptions(opts...)
+	if tw.opts.Tick <= 0 {
+		tw.opts.Tick = 10 * time.Millisecond
+	}
+	if tw.opts.WheelSize < 2 {
+		tw.opts.WheelSize = 512
+	}
+	tw.start = time.Now()
+	tw.wheels = [][]*wheelBucket{tw.newWheel()}
+	go tw.run()
+	return tw
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own goroutine.
+// It returns a WheelTimer that can be used to cancel the call using its Stop method.
+func (tw *TimingWheel) AfterFunc(d time.Duration, f func()) *WheelTimer {
+	t := &WheelTimer{tw: tw, f: f}
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.addLocked(t, d)
+	return t
+}
+
+// Len returns the number of pending timers.
+func (tw *TimingWheel) Len() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.size
+}
+
+// Stop stops the TimingWheel, pending timers never fire after Stop returns.
+func (tw *TimingWheel) Stop() {
+	tw.stopOnce.Do(func() {
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		tw.stopped = true
+		close(tw.stopCh)
+	})
+}
+
+// Stop prevents the WheelTimer from firing.
+// It returns true if the call stops the timer, false if the timer has already
+// expired or been stopped.
+func (t *WheelTimer) Stop() bool {
+	t.tw.mu.Lock()
+	defer t.tw.mu.Unlock()
+	return t.tw.removeLocked(t)
+}
+
+// Reset changes the timer to expire after duration d.
+// It returns true if the timer had been active, false if the timer had
+// expired or been stopped.
+// Unlike time.Timer, Reset may be called on an active timer.
+func (t *WheelTimer) Reset(d time.Duration) bool {
+	t.tw.mu.Lock()
+	defer t.tw.mu.Unlock()
+	active := t.tw.removeLocked(t)
+	t.tw.addLocked(t, d)
+	return active
+}
+
+func (tw *TimingWheel) newWheel() []*wheelBucket {
+	wheel := make([]*wheelBucket, tw.opts.WheelSize)
+	for i := range wheel {
+		b := &wheelBucket{}
+		b.root.next = &b.root
+		b.root.prev = &b.root
+		wheel[i] = b
+	}
+	return wheel
+}
+
+// addLocked schedules t to expire after d, or fires it now if d has elapsed already.
+func (tw *TimingWheel) addLocked(t *WheelTimer, d time.Duration) {
+	// round up, so that a timer never fires before d elapses
+	elapsed := time.Since(tw.start) + d
+	t.expiration = int64((elapsed + tw.opts.Tick - 1) / tw.opts.Tick)
+	if d <= 0 || t.expiration <= tw.current {
+		go t.f()
+		return
+	}
+	tw.insertLocked(t)
+	tw.size++
+}
+
+// insertLocked puts t into the bucket of the lowest wheel which covers its expiration.
+func (tw *TimingWheel) insertLocked(t *WheelTimer) {
+	size := int64(tw.opts.WheelSize)
+	delta := t.expiration - tw.current
+	span := int64(1) // ticks per bucket of the wheel
+	level := 0
+	for delta >= span*size {
+		span *= size
+		level++
+		if level == len(tw.wheels) {
+			tw.wheels = append(tw.wheels, tw.newWheel())
+		}
+	}
+	b := tw.wheels[level][(t.expiration/span)%size]
+	t.bucket = b
+	t.prev = b.root.prev
+	t.next = &b.root
+	b.root.prev.next = t
+	b.root.prev = t
+}
+
+// removeLocked unlinks t from its bucket, and reports whether t was pending.
+func (tw *TimingWheel) removeLocked(t *WheelTimer) bool {
+	if t.bucket == nil {
+		return false
+	}
+	t.prev.next = t.next
+	t.next.prev = t.prev
+	t.prev, t.next, t.bucket = nil, nil, nil
+	tw.size--
+	return true
+}
+
+// takeLocked unlinks and returns all timers in b.
+func (b *wheelBucket) takeLocked() []*WheelTimer {
+	var timers []*WheelTimer
+	for t := b.root.next; t != &b.root; {
+		next := t.next
+		t.prev, t.next, t.bucket = nil, nil, nil
+		timers = append(timers, t)
+		t = next
+	}
+	b.root.next = &b.root
+	b.root.prev = &b.root
+	return timers
+}
+
+func (tw *TimingWheel) run() {
+	ticker := time.NewTicker(tw.opts.Tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tw.stopCh:
+			return
+		case now := <-ticker.C:
+			tw.advance(int64(now.Sub(tw.start) / tw.opts.Tick))
+		}
+	}
+}
+
+// advance moves the wheels forward to the tick target, firing expired timers,
+// and cascading timers of higher wheels down as their buckets come due.
+func (tw *TimingWheel) advance(target int64) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.stopped {
+		return
+	}
+	size := int64(tw.opts.WheelSize)
+	for tw.current < target {
+		tw.current++
+
+		// cascade from the highest wheel down, as timers fall through lower wheels
+		span := int64(1)
+		for range len(tw.wheels) - 1 {
+			span *= size
+		}
+		for level := len(tw.wheels) - 1; level > 0; level-- {
+			if tw.current%span == 0 {
+				for _, t := range tw.wheels[level][(tw.current/span)%size].takeLocked() {
+					if t.expiration <= tw.current {
+						tw.fireLocked(t)
+						continue
+					}
+					tw.insertLocked(t)
+				}
+			}
+			span /= size
+		}
+
+		for _, t := range tw.wheels[0][tw.current%size].takeLocked() {
+			tw.fireLocked(t)
+		}
+	}
+}
+
+func (tw *TimingWheel) fireLocked(t *WheelTimer) {
+	tw.size--
+	go t.f()
+}
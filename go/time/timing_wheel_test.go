@@ -0,0 +1,92 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+func TestTimingWheelAfterFunc(t *testing.T) {
+	// a small wheel, so that timers cascade through several wheels
+	tw := time_.NewTimingWheel(time_.WithTimingWheelTick(time.Millisecond), time_.WithTimingWheelWheelSize(4))
+	defer tw.Stop()
+
+	start := time.Now()
+	delays := []time.Duration{0, time.Millisecond, 3 * time.Millisecond, 10 * time.Millisecond,
+		17 * time.Millisecond, 50 * time.Millisecond, 130 * time.Millisecond}
+	fired := make(chan time.Duration, len(delays))
+	for _, d := range delays {
+		tw.AfterFunc(d, func() { fired <- d })
+	}
+	for range delays {
+		select {
+		case d := <-fired:
+			if elapsed := time.Since(start); elapsed < d {
+				t.Errorf("timer of %s fired early after %s", d, elapsed)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timers did not fire, %d pending", tw.Len())
+		}
+	}
+	if n := tw.Len(); n != 0 {
+		t.Fatalf("Len = %d; want 0", n)
+	}
+}
+
+func TestWheelTimerStopReset(t *testing.T) {
+	tw := time_.NewTimingWheel(time_.WithTimingWheelTick(time.Millisecond), time_.WithTimingWheelWheelSize(8))
+	defer tw.Stop()
+
+	var stopped atomic.Bool
+	timer := tw.AfterFunc(20*time.Millisecond, func() { stopped.Store(true) })
+	if !timer.Stop() {
+		t.Fatal("Stop = false; want true")
+	}
+	if timer.Stop() {
+		t.Fatal("Stop of a stopped timer = true; want false")
+	}
+
+	fired := make(chan time.Time, 1)
+	start := time.Now()
+	timer = tw.AfterFunc(10*time.Millisecond, func() { fired <- time.Now() })
+	// keep pushing the timer back, as an idle timeout reset on every read
+	for range 5 {
+		time.Sleep(5 * time.Millisecond)
+		if !timer.Reset(20 * time.Millisecond) {
+			t.Fatal("Reset of an active timer = false; want true")
+		}
+	}
+	select {
+	case at := <-fired:
+		if elapsed := at.Sub(start); elapsed < 45*time.Millisecond {
+			t.Fatalf("reset timer fired early after %s", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("reset timer did not fire")
+	}
+	if timer.Reset(time.Millisecond) {
+		t.Fatal("Reset of an expired timer = true; want false")
+	}
+	<-fired
+
+	time.Sleep(30 * time.Millisecond)
+	if stopped.Load() {
+		t.Fatal("stopped timer fired")
+	}
+}
+
+func BenchmarkTimingWheelAfterFuncStop(b *testing.B) {
+	tw := time_.NewTimingWheel()
+	defer tw.Stop()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			tw.AfterFunc(time.Minute, func() {}).Stop()
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by "go-option -type timingWheel"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package time
+
+import "time"
+
+// A TimingWheelOption sets options.
+type TimingWheelOption interface {
+	apply(*timingWheel)
+}
+
+// EmptyTimingWheelOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyTimingWheelOption struct{}
+
+func (EmptyTimingWheelOption) apply(*timingWheel) {}
+
+// TimingWheelOptionFunc wraps a function that modifies timingWheel into an
+// implementation of the TimingWheelOption interface.
+type TimingWheelOptionFunc func(*timingWheel)
+
+func (f TimingWheelOptionFunc) apply(do *timingWheel) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *timingWheel) ApplyOptions(options ...TimingWheelOption) *timingWheel {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withTimingWheel sets timingWheel.
+func withTimingWheel(v timingWheel) TimingWheelOption {
+	return TimingWheelOptionFunc(func(o *timingWheel) {
+		*o = v
+	})
+}
+
+// WithTimingWheelTick sets Tick in timingWheel.
+// Tick is the resolution of the timing wheel, timers fire at most one Tick late.
+// 10ms if not positive.
+func WithTimingWheelTick(v time.Duration) TimingWheelOption {
+	return TimingWheelOptionFunc(func(o *timingWheel) {
+		o.Tick = v
+	})
+}
+
+// WithTimingWheelWheelSize sets WheelSize in timingWheel.
+// WheelSize is the number of buckets of each wheel in the hierarchy,
+// a timer expiring within Tick*WheelSize is put into the lowest wheel,
+// and then within Tick*WheelSize^2 into the wheel above it, and so on.
+// 512 if less than 2.
+func WithTimingWheelWheelSize(v int) TimingWheelOption {
+	return TimingWheelOptionFunc(func(o *timingWheel) {
+		o.WheelSize = v
+	})
+}
@@ -44,8 +44,8 @@ func ForeverWithReset(f func(), resetCh chan struct{}, period time.Duration) {
 // Until loops until context is done, running f every period.
 //
 // Until is syntactic sugar on top of UntilWithReset, without resetCh.
-func Until(ctx context.Context, f func(ctx context.Context), period time.Duration) {
-	UntilWithReset(ctx, f, nil, period)
+func Until(ctx context.Context, f func(ctx context.Context), period time.Duration, opts ...ExponentialBackOffOption) {
+	UntilWithReset(ctx, f, nil, period, opts...)
 }
 
 // UntilWithReset loops until context is done, running f every period.
@@ -53,6 +53,7 @@ func Until(ctx context.Context, f func(ctx context.Context), period time.Duratio
 // UntilWithReset is syntactic sugar on top of JitterUntilWithReset with zero jitter factor and
 // with sliding = true (which means the timer for period starts after the f
 // completes).
+// opts are applied after the period, such as WithExponentialBackOffOptionClock.
 // Example: time.Second for period and sleep in f
 // 2021/04/09 12:48:03 Apr  9 12:48:03
 // 2021/04/09 12:48:05 Apr  9 12:48:05
@@ -60,20 +61,20 @@ func Until(ctx context.Context, f func(ctx context.Context), period time.Duratio
 // 2021/04/09 12:48:09 Apr  9 12:48:09
 // 2021/04/09 12:48:11 Apr  9 12:48:11
 // 2021/04/09 12:48:13 Apr  9 12:48:13
-func UntilWithReset(ctx context.Context, f func(ctx context.Context), resetCh chan struct{}, period time.Duration) {
-	JitterUntilWithReset(ctx, f, resetCh, true,
+func UntilWithReset(ctx context.Context, f func(ctx context.Context), resetCh chan struct{}, period time.Duration, opts ...ExponentialBackOffOption) {
+	JitterUntilWithReset(ctx, f, resetCh, true, append([]ExponentialBackOffOption{
 		WithExponentialBackOffOptionRandomizationFactor(0),
 		WithExponentialBackOffOptionMultiplier(1),
 		WithExponentialBackOffOptionInitialInterval(period),
-		WithExponentialBackOffOptionMaxElapsedDuration(-1))
+		WithExponentialBackOffOptionMaxElapsedDuration(-1)}, opts...)...)
 }
 
 // NonSlidingUntil loops until context is done, running f every
 // period.
 //
 // NonSlidingUntil is syntactic sugar on top of NonSlidingUntilWithReset, without resetCh.
-func NonSlidingUntil(ctx context.Context, f func(ctx context.Context), period time.Duration) {
-	NonSlidingUntilWithReset(ctx, f, nil, period)
+func NonSlidingUntil(ctx context.Context, f func(ctx context.Context), period time.Duration, opts ...ExponentialBackOffOption) {
+	NonSlidingUntilWithReset(ctx, f, nil, period, opts...)
 }
 
 // NonSlidingUntilWithReset loops until context is done, running f every
@@ -82,6 +83,7 @@ func NonSlidingUntil(ctx context.Context, f func(ctx context.Context), period ti
 // NonSlidingUntilWithReset is syntactic sugar on top of JitterUntilWithReset with zero jitter
 // factor, with sliding = false (meaning the timer for period starts at the same
 // time as the function starts).
+// opts are applied after the period, such as WithExponentialBackOffOptionClock.
 // Example: time.Second for period and sleep in f
 // 2021/04/09 12:45:08 Apr  9 12:45:08
 // 2021/04/09 12:45:09 Apr  9 12:45:09
@@ -90,12 +92,12 @@ func NonSlidingUntil(ctx context.Context, f func(ctx context.Context), period ti
 // 2021/04/09 12:45:12 Apr  9 12:45:12
 // 2021/04/09 12:45:13 Apr  9 12:45:13
 // 2021/04/09 12:45:14 Apr  9 12:45:14
-func NonSlidingUntilWithReset(ctx context.Context, f func(ctx context.Context), resetCh chan struct{}, period time.Duration) {
-	JitterUntilWithReset(ctx, f, resetCh, false,
+func NonSlidingUntilWithReset(ctx context.Context, f func(ctx context.Context), resetCh chan struct{}, period time.Duration, opts ...ExponentialBackOffOption) {
+	JitterUntilWithReset(ctx, f, resetCh, false, append([]ExponentialBackOffOption{
 		WithExponentialBackOffOptionRandomizationFactor(0),
 		WithExponentialBackOffOptionMultiplier(1),
 		WithExponentialBackOffOptionInitialInterval(period),
-		WithExponentialBackOffOptionMaxElapsedDuration(-1))
+		WithExponentialBackOffOptionMaxElapsedDuration(-1)}, opts...)...)
 }
 
 // JitterUntil loops until context is done, running f every period.
@@ -108,6 +110,7 @@ func JitterUntil(ctx context.Context, f func(ctx context.Context), sliding bool,
 //
 // period set by WithExponentialBackOffOptionInitialInterval
 // jitterFactor set by WithExponentialBackOffOptionRandomizationFactor
+// clock set by WithExponentialBackOffOptionClock
 // If jitterFactor is positive, the period is jittered before every run of f.
 // If jitterFactor is not positive, the period is unchanged and not jittered.
 //
@@ -131,10 +134,16 @@ func BackoffUntil(ctx context.Context, f func(ctx context.Context), backoff Back
 // If sliding is true, the period is computed after f runs. If it is false then
 // period includes the runtime for f.
 // backoff is reset if resetCh has data
+// The back off is waited on the clock returned by GetClock of backoff if implemented, as
+// ExponentialBackOff does, or RealClock otherwise.
 func BackoffUntilWithReset(ctx context.Context,
 	f func(ctx context.Context), resetCh chan struct{}, backoff BackOff, sliding bool) {
 	var elapsed time.Duration
 	var ok bool
+	var clock Clock = RealClock{}
+	if c, has := backoff.(interface{ GetClock() Clock }); has {
+		clock = c.GetClock()
+	}
 
 	var drainResetCh = func() {
 		// To ensure the channel is empty, check the
@@ -157,9 +166,9 @@ func BackoffUntilWithReset(ctx context.Context,
 		default:
 		}
 
-		var cost Cost
+		var start time.Time
 		if !sliding {
-			cost.Start()
+			start = clock.Now()
 			elapsed, ok = backoff.NextBackOff()
 		}
 
@@ -168,7 +177,7 @@ func BackoffUntilWithReset(ctx context.Context,
 			f(ctx)
 		}()
 		if !sliding {
-			elapsed -= cost.Elapse()
+			elapsed -= clock.Now().Sub(start)
 		}
 
 		if sliding {
@@ -182,7 +191,7 @@ func BackoffUntilWithReset(ctx context.Context,
 			if elapsed <= 0 {
 				return
 			}
-			timer := time.NewTimer(elapsed)
+			timer := clock.NewTimer(elapsed)
 			defer timer.Stop()
 
 			// NOTE: b/c there is no priority selection in golang
@@ -193,7 +202,7 @@ func BackoffUntilWithReset(ctx context.Context,
 			select {
 			case <-ctx.Done():
 				return
-			case <-timer.C:
+			case <-timer.C():
 			case <-resetCh:
 				backoff.Reset()
 				drainResetCh()
@@ -104,3 +104,29 @@ func TestJitterUntilReturnsImmediately(t *testing.T) {
 		t.Errorf("JitterUntil did not return immediately when the stop chan was closed inside the func")
 	}
 }
+
+func TestUntilWithClock(t *testing.T) {
+	clock := time_.NewFakeClock(time.Time{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	called := make(chan time.Time)
+	go func() {
+		time_.Until(ctx, func(ctx context.Context) {
+			called <- clock.Now()
+		}, time.Hour, time_.WithExponentialBackOffOptionClock(clock))
+		close(called)
+	}()
+
+	for i := 0; i < 3; i++ {
+		if got, want := <-called, (time.Time{}).Add(time.Duration(i)*time.Hour); !got.Equal(want) {
+			t.Fatalf("#%d: f called at %v; want %v", i, got, want)
+		}
+		clock.BlockUntil(1)
+		clock.Step(time.Hour)
+	}
+	<-called
+	cancel()
+	for range called {
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package driver
+
+import (
+	"context"
+	"net/url"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// ProcessorURLOpener is the interface that must be implemented by a log processor
+// driver.
+// ProcessorURLOpener represents types that can open log processors based on a URL.
+// The opener must not modify the URL argument. OpenProcessorURL must be safe to
+// call from multiple goroutines.
+//
+// This interface is generally implemented by types in driver packages.
+type ProcessorURLOpener interface {
+	// OpenProcessorURL creates a new processor for the given target.
+	OpenProcessorURL(ctx context.Context, u *url.URL) (sdklog.Processor, error)
+
+	// Scheme returns the scheme supported by this log processor.
+	// Scheme is defined at https://github.com/grpc/grpc/blob/master/doc/naming.md.
+	Scheme() string
+}
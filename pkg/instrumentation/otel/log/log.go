@@ -0,0 +1,83 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+)
+
+// NewLoggerProvider returns a LoggerProvider exporting log records to ExporterEndpoints and Processors,
+// such as "stdout://localhost?allow_stdout" and "otlp-grpc://endpoint:4317?insecure".
+// Processors of ExporterEndpoints are batching, see the log processor drivers.
+//
+// Use NewSlogHandler to emit records of log/slog by the LoggerProvider.
+func NewLoggerProvider(ctx context.Context, options ...Option) (*sdklog.LoggerProvider, error) {
+	var o option
+	o.SetDefaults()
+	o.ApplyOptions(options...)
+
+	var loggerProviderOptions []sdklog.LoggerProviderOption
+	loggerProviderOptions = append(loggerProviderOptions, o.LoggerProviderOptions...)
+	{
+		res, err := sdkresource.New(ctx,
+			sdkresource.WithFromEnv(),                      // Discover and provide attributes from OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment variables.
+			sdkresource.WithTelemetrySDK(),                 // Discover and provide information about the OpenTelemetry SDK used.
+			sdkresource.WithProcess(),                      // Discover and provide process information.
+			sdkresource.WithOS(),                           // Discover and provide OS information.
+			sdkresource.WithContainer(),                    // Discover and provide container information.
+			sdkresource.WithHost(),                         // Discover and provide host information.
+			sdkresource.WithAttributes(o.ResourceAttrs...)) // Add custom resource attributes.
+		if err != nil {
+			return nil, err
+		}
+		loggerProviderOptions = append(loggerProviderOptions, sdklog.WithResource(res))
+	}
+
+	{
+		processors, err := createProcessors(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		o.Processors = append(o.Processors, processors...)
+		for _, processor := range o.Processors {
+			loggerProviderOptions = append(loggerProviderOptions, sdklog.WithProcessor(processor))
+		}
+	}
+
+	return sdklog.NewLoggerProvider(loggerProviderOptions...), nil
+}
+
+func createProcessors(ctx context.Context, opts ...Option) ([]sdklog.Processor, error) {
+	var o option
+	o.SetDefaults()
+	o.ApplyOptions(opts...)
+
+	var processors []sdklog.Processor
+	for _, v := range o.ExporterEndpoints {
+		u, err := url.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("malformed log exporter endpoint %s: %w", v, err)
+		}
+
+		{
+			opener := Get(u.Scheme)
+			if opener == nil {
+				return nil, fmt.Errorf("unknown log exporter scheme: %s", u.Scheme)
+			}
+			processor, err := opener.OpenProcessorURL(ctx, u)
+			if err != nil {
+				return nil, err
+			}
+			processors = append(processors, processor)
+		}
+	}
+
+	return processors, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+//go:generate go-option -type=option --trim
+type option struct {
+	// ExporterEndpoints is the target endpoint URL (scheme, host, port, path) the Exporter will connect to.
+	ExporterEndpoints []string
+	Processors        []sdklog.Processor
+	ResourceAttrs     []attribute.KeyValue
+
+	LoggerProviderOptions []sdklog.LoggerProviderOption
+}
+
+func (o *option) SetDefaults() {}
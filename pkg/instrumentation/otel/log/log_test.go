@@ -0,0 +1,141 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+
+	log_ "github.com/searKing/golang/pkg/instrumentation/otel/log"
+
+	_ "github.com/searKing/golang/pkg/instrumentation/otel/log/otlplog/otlploggrpc" // for otel-grpc
+	_ "github.com/searKing/golang/pkg/instrumentation/otel/log/otlplog/otlploghttp" // for otel-http
+	_ "github.com/searKing/golang/pkg/instrumentation/otel/log/stdoutlog"           // for stdout
+)
+
+func TestNewLoggerProvider(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lp, err := log_.NewLoggerProvider(ctx, log_.WithOptionExporterEndpoints(
+		"stdout://localhost?allow_stdout&pretty_print&no_timestamps&batch_export_interval=1000000000",
+		//`otlp-http://some_endpoint/some_path?compression=gzip&insecure`,
+		//`otlp-grpc://some_endpoint/some_path?compression=gzip&insecure`,
+	), log_.WithOptionResourceAttrs(
+		// the service name used to display logs in backends
+		semconv.ServiceNameKey.String("demo-client"),
+	))
+	if err != nil {
+		t.Fatalf("create logger provider failed: %s", err.Error())
+	}
+	defer func() {
+		if err := lp.Shutdown(context.Background()); err != nil {
+			t.Fatalf("shutdown logger provider failed: %s", err.Error())
+		}
+	}()
+	logger := slog.New(log_.NewSlogHandler("TestNewLoggerProvider", log_.WithSlogHandlerLoggerProvider(lp)))
+	logger.InfoContext(ctx, "hello", "k", "v")
+	if err := lp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("force flush logger provider failed: %s", err.Error())
+	}
+
+	for _, endpoint := range []string{"unknown://localhost", "stdout://localhost?batch_max_queue_size=x"} {
+		if _, err := log_.NewLoggerProvider(ctx, log_.WithOptionExporterEndpoints(endpoint)); err == nil {
+			t.Errorf("NewLoggerProvider(%q): want error", endpoint)
+		}
+	}
+}
+
+type recordExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordExporter) ForceFlush(context.Context) error { return nil }
+
+func TestSlogHandler(t *testing.T) {
+	var exporter recordExporter
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(&exporter)))
+	defer lp.Shutdown(context.Background())
+	h := log_.NewSlogHandler("test",
+		log_.WithSlogHandlerLoggerProvider(lp),
+		log_.WithSlogHandlerLevel(slog.LevelInfo),
+		log_.WithSlogHandlerAddSource(true))
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("debug records should be disabled")
+	}
+
+	tp := sdktrace.NewTracerProvider()
+	defer tp.Shutdown(context.Background())
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	logger := slog.New(h).With("svc", "api").WithGroup("req").With("id", 1).WithGroup("empty")
+	logger.DebugContext(ctx, "dropped")
+	logger.ErrorContext(ctx, "failed", slog.Any("err", errors.New("boom")), slog.Duration("cost", time.Second))
+	slog.New(h).WithGroup("empty").InfoContext(ctx, "no attrs")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("got %d records, want 2", len(exporter.records))
+	}
+	r := exporter.records[0]
+	if got, want := r.Body().AsString(), "failed"; got != want {
+		t.Errorf("body: got %q, want %q", got, want)
+	}
+	if got, want := r.Severity(), otellog.SeverityError; got != want {
+		t.Errorf("severity: got %v, want %v", got, want)
+	}
+	if got, want := r.TraceID(), span.SpanContext().TraceID(); got != want {
+		t.Errorf("trace id: got %v, want %v", got, want)
+	}
+	if got, want := r.SpanID(), span.SpanContext().SpanID(); got != want {
+		t.Errorf("span id: got %v, want %v", got, want)
+	}
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	if got, want := attrs["svc"].AsString(), "api"; got != want {
+		t.Errorf("svc: got %q, want %q", got, want)
+	}
+	want := otellog.MapValue(otellog.Int("id", 1),
+		otellog.Map("empty", otellog.String("err", "boom"), otellog.Int64("cost", int64(time.Second))))
+	if got := attrs["req"]; !got.Equal(want) {
+		t.Errorf("req: got %v, want %v", got, want)
+	}
+	if _, ok := attrs["code.file.path"]; !ok {
+		t.Errorf("missing source in %v", attrs)
+	}
+
+	r = exporter.records[1]
+	if got, want := r.Severity(), otellog.SeverityInfo; got != want {
+		t.Errorf("severity: got %v, want %v", got, want)
+	}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == "empty" {
+			t.Errorf("empty group should be ignored")
+		}
+		return true
+	})
+}
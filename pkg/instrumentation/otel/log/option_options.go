@@ -0,0 +1,107 @@
+// Code generated by "go-option -type=option --trim"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package log
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// A Option sets options.
+type Option interface {
+	apply(*option)
+}
+
+// EmptyOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyOption struct{}
+
+func (EmptyOption) apply(*option) {}
+
+// OptionFunc wraps a function that modifies option into an
+// implementation of the Option interface.
+type OptionFunc func(*option)
+
+func (f OptionFunc) apply(do *option) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *option) ApplyOptions(options ...Option) *option {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withOption sets option.
+func withOption(v option) Option {
+	return OptionFunc(func(o *option) {
+		*o = v
+	})
+}
+
+// WithOptionExporterEndpoints appends ExporterEndpoints in option.
+// ExporterEndpoints is the target endpoint URL (scheme, host, port, path) the Exporter will connect to.
+func WithOptionExporterEndpoints(v ...string) Option {
+	return OptionFunc(func(o *option) {
+		o.ExporterEndpoints = append(o.ExporterEndpoints, v...)
+	})
+}
+
+// WithOptionExporterEndpointsReplace sets ExporterEndpoints in option.
+// ExporterEndpoints is the target endpoint URL (scheme, host, port, path) the Exporter will connect to.
+func WithOptionExporterEndpointsReplace(v ...string) Option {
+	return OptionFunc(func(o *option) {
+		o.ExporterEndpoints = v
+	})
+}
+
+// WithOptionProcessors appends Processors in option.
+func WithOptionProcessors(v ...sdklog.Processor) Option {
+	return OptionFunc(func(o *option) {
+		o.Processors = append(o.Processors, v...)
+	})
+}
+
+// WithOptionProcessorsReplace sets Processors in option.
+func WithOptionProcessorsReplace(v ...sdklog.Processor) Option {
+	return OptionFunc(func(o *option) {
+		o.Processors = v
+	})
+}
+
+// WithOptionResourceAttrs appends ResourceAttrs in option.
+func WithOptionResourceAttrs(v ...attribute.KeyValue) Option {
+	return OptionFunc(func(o *option) {
+		o.ResourceAttrs = append(o.ResourceAttrs, v...)
+	})
+}
+
+// WithOptionResourceAttrsReplace sets ResourceAttrs in option.
+func WithOptionResourceAttrsReplace(v ...attribute.KeyValue) Option {
+	return OptionFunc(func(o *option) {
+		o.ResourceAttrs = v
+	})
+}
+
+// WithOptionLoggerProviderOptions appends LoggerProviderOptions in option.
+func WithOptionLoggerProviderOptions(v ...sdklog.LoggerProviderOption) Option {
+	return OptionFunc(func(o *option) {
+		o.LoggerProviderOptions = append(o.LoggerProviderOptions, v...)
+	})
+}
+
+// WithOptionLoggerProviderOptionsReplace sets LoggerProviderOptions in option.
+func WithOptionLoggerProviderOptionsReplace(v ...sdklog.LoggerProviderOption) Option {
+	return OptionFunc(func(o *option) {
+		o.LoggerProviderOptions = v
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploggrpc
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	_ "google.golang.org/grpc/encoding/gzip" // open gzip
+)
+
+// OpenExporter opens a log exporter specified by its log exporter name and a
+// exporter-specific data source name, usually consisting of at least a
+// log exporter name and connection information.
+func OpenExporter(ctx context.Context, opts ...Option) (*otlploggrpc.Exporter, error) {
+	var opt option
+	opt.SetDefaults()
+	opt.ApplyOptions(opts...)
+	return otlploggrpc.New(ctx, opt.OtlpOptions...)
+}
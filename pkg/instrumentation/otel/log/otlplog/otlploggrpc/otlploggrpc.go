@@ -0,0 +1,16 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploggrpc
+
+import (
+	"github.com/searKing/golang/pkg/instrumentation/otel/log"
+	"github.com/searKing/golang/pkg/instrumentation/otel/log/driver"
+)
+
+var _ driver.ProcessorURLOpener = (*URLOpener)(nil)
+
+func init() {
+	log.Register(&URLOpener{})
+}
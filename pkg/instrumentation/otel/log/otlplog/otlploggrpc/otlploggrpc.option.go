@@ -0,0 +1,18 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploggrpc
+
+import (
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+//go:generate go-option -type=option --trim
+type option struct {
+	OtlpOptions           []otlploggrpc.Option
+	BatchProcessorOptions []sdklog.BatchProcessorOption
+}
+
+func (o *option) SetDefaults() {}
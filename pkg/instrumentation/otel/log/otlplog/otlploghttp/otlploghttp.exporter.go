@@ -0,0 +1,21 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploghttp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+)
+
+// OpenExporter opens a log exporter specified by its log exporter name and a
+// exporter-specific data source name, usually consisting of at least a
+// log exporter name and connection information.
+func OpenExporter(ctx context.Context, opts ...Option) (*otlploghttp.Exporter, error) {
+	var opt option
+	opt.SetDefaults()
+	opt.ApplyOptions(opts...)
+	return otlploghttp.New(ctx, opt.OtlpOptions...)
+}
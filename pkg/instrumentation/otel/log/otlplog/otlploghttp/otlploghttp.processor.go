@@ -0,0 +1,26 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploghttp
+
+import (
+	"context"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// OpenProcessor opens a log processor specified by its log exporter name and a
+// exporter-specific data source name, usually consisting of at least a
+// log exporter name and connection information.
+func OpenProcessor(ctx context.Context, opts ...Option) (sdklog.Processor, error) {
+	var opt option
+	opt.SetDefaults()
+	opt.ApplyOptions(opts...)
+	exporter, err := OpenExporter(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// handle exporter, as batching processor
+	return sdklog.NewBatchProcessor(exporter, opt.BatchProcessorOptions...), nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otlploghttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	url_ "github.com/searKing/golang/pkg/instrumentation/otel/url"
+)
+
+// URLOpener opens OTLP Log URLs like "otlp-http://endpoint:4318?compression=gzip&insecure".
+type URLOpener struct {
+	// Options specifies the options to pass to OpenProcessor.
+	Options []Option
+}
+
+// Scheme returns the scheme supported by this log processor.
+func (o *URLOpener) Scheme() string { return "otlp-http" }
+
+// OpenProcessorURL opens a log.Processor based on u.
+func (o *URLOpener) OpenProcessorURL(ctx context.Context, u *url.URL) (sdklog.Processor, error) {
+	q := u.Query()
+	u.RawQuery = ""
+	u.RawFragment = ""
+
+	{
+		scheme, err := parseScheme(q)
+		if err != nil {
+			return nil, err
+		}
+		u.Scheme = scheme
+	}
+	opts := o.Options
+	{
+		var otlpOpts []otlploghttp.Option
+		otlpOpts = append(otlpOpts, otlploghttp.WithEndpointURL(u.String()))
+		{
+			var err error
+			otlpOpts, err = parseOtlpOpts(q, otlpOpts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, WithOptionOtlpOptions(otlpOpts...))
+	}
+	{
+		processorOpts, err := parseProcessorOpts(q)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithOptionBatchProcessorOptions(processorOpts...))
+	}
+	return OpenProcessor(ctx, opts...)
+}
+
+func parseScheme(q url.Values) (scheme string, err error) {
+	b, err := url_.ParseBoolFromValues(q, "insecure")
+	if err != nil {
+		return "", err
+	}
+	if b {
+		scheme = "http"
+	} else {
+		scheme = "https"
+	}
+	q.Del("insecure")
+	return
+}
+
+func parseOtlpOpts(q url.Values, opts ...otlploghttp.Option) ([]otlploghttp.Option, error) {
+	{
+		v := q.Get("compression")
+		switch v {
+		case "gzip":
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		case "none":
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.NoCompression))
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown quary parameter compression: %s", v)
+		}
+		q.Del("compression")
+	}
+	{
+		b, err := url_.ParseBoolFromValues(q, "no_proxy")
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			// No Proxy
+			opts = append(opts, otlploghttp.WithProxy(func(_ *http.Request) (*url.URL, error) { return nil, nil }))
+		}
+		q.Del("no_proxy")
+	}
+	{
+		headers, err := parseHeaders(q)
+		if err != nil {
+			return nil, err
+		}
+		if len(headers) > 0 {
+			opts = append(opts, otlploghttp.WithHeaders(headers))
+		}
+	}
+	return opts, nil
+}
+
+func parseHeaders(q url.Values) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, data := range q["headers"] {
+		err := json.Unmarshal([]byte(data), &headers)
+		if err != nil {
+			return nil, fmt.Errorf("unknown quary parameter headers: %w", err)
+		}
+	}
+	q.Del("headers")
+	return headers, nil
+}
+
+func parseProcessorOpts(q url.Values, opts ...sdklog.BatchProcessorOption) ([]sdklog.BatchProcessorOption, error) {
+	{
+		d, err := url_.ParseTimeDurationFromValues(q, "batch_export_interval")
+		if err != nil {
+			return nil, err
+		}
+		if d != 0 {
+			opts = append(opts, sdklog.WithExportInterval(d))
+		}
+		q.Del("batch_export_interval")
+	}
+	{
+		d, err := url_.ParseTimeDurationFromValues(q, "batch_export_timeout")
+		if err != nil {
+			return nil, err
+		}
+		if d != 0 {
+			opts = append(opts, sdklog.WithExportTimeout(d))
+		}
+		q.Del("batch_export_timeout")
+	}
+	{
+		n, err := url_.ParseIntFromValues(q, "batch_max_queue_size")
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			opts = append(opts, sdklog.WithMaxQueueSize(n))
+		}
+		q.Del("batch_max_queue_size")
+	}
+	{
+		n, err := url_.ParseIntFromValues(q, "batch_export_max_batch_size")
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			opts = append(opts, sdklog.WithExportMaxBatchSize(n))
+		}
+		q.Del("batch_export_max_batch_size")
+	}
+	return opts, nil
+}
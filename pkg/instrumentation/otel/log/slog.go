@@ -0,0 +1,198 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+//go:generate go-option -type=slogHandler
+type slogHandler struct {
+	// LoggerProvider provides the Logger records are emitted by, global.GetLoggerProvider() if nil.
+	LoggerProvider otellog.LoggerProvider
+	// LoggerOptions are the options of the Logger, such as the instrumentation version.
+	LoggerOptions []otellog.LoggerOption
+	// Level is the minimum level of records emitted, all levels enabled by the Logger if nil.
+	Level slog.Leveler
+	// AddSource emits the source of records as code.* attributes.
+	AddSource bool
+}
+
+// NewSlogHandler returns a slog.Handler emitting records as OpenTelemetry log records by the Logger
+// of name, the instrumentation scope.
+// The trace ID and span ID of the span in the context of a record are emitted with it, which
+// correlates logs with traces, groups are emitted as map values.
+func NewSlogHandler(name string, opts ...SlogHandlerOption) slog.Handler {
+	var o slogHandler
+	o.ApplyOptions(opts...)
+	if o.LoggerProvider == nil {
+		o.LoggerProvider = global.GetLoggerProvider()
+	}
+	return &slogBridge{
+		logger:    o.LoggerProvider.Logger(name, o.LoggerOptions...),
+		level:     o.Level,
+		addSource: o.AddSource,
+	}
+}
+
+type slogBridge struct {
+	logger    otellog.Logger
+	level     slog.Leveler
+	addSource bool
+
+	goas []groupOrAttrs // attrs and groups added by WithAttrs and WithGroup, in order
+}
+
+// groupOrAttrs holds either a group name or a list of attrs.
+type groupOrAttrs struct {
+	group string
+	attrs []otellog.KeyValue
+}
+
+func (h *slogBridge) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil && level < h.level.Level() {
+		return false
+	}
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: convertLevel(level)})
+}
+
+func (h *slogBridge) Handle(ctx context.Context, r slog.Record) error {
+	var record otellog.Record
+	record.SetTimestamp(r.Time)
+	record.SetBody(otellog.StringValue(r.Message))
+	record.SetSeverity(convertLevel(r.Level))
+	record.SetSeverityText(r.Level.String())
+
+	kvs := make([]otellog.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		kvs = appendAttr(kvs, a)
+		return true
+	})
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			kvs = append(slices.Clone(goa.attrs), kvs...)
+			continue
+		}
+		// groups without attrs are ignored, as slog.Handler requires
+		if len(kvs) > 0 {
+			kvs = []otellog.KeyValue{otellog.Map(goa.group, kvs...)}
+		}
+	}
+	record.AddAttributes(kvs...)
+
+	if h.addSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		record.AddAttributes(
+			otellog.String(string(semconv.CodeFilePathKey), frame.File),
+			otellog.Int(string(semconv.CodeLineNumberKey), frame.Line),
+			otellog.String(string(semconv.CodeFunctionNameKey), frame.Function))
+	}
+	h.logger.Emit(ctx, record)
+	return nil
+}
+
+func (h *slogBridge) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var kvs []otellog.KeyValue
+	for _, a := range attrs {
+		kvs = appendAttr(kvs, a)
+	}
+	if len(kvs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.goas = append(slices.Clip(h.goas), groupOrAttrs{attrs: kvs})
+	return &h2
+}
+
+func (h *slogBridge) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.goas = append(slices.Clip(h.goas), groupOrAttrs{group: name})
+	return &h2
+}
+
+// convertLevel maps slog.LevelDebug, slog.LevelInfo, slog.LevelWarn and slog.LevelError to
+// SeverityDebug, SeverityInfo, SeverityWarn and SeverityError, and the levels between.
+func convertLevel(level slog.Level) otellog.Severity {
+	s := otellog.Severity(level - slog.LevelInfo + slog.Level(otellog.SeverityInfo))
+	return min(max(s, otellog.SeverityTrace1), otellog.SeverityFatal4)
+}
+
+func appendAttr(kvs []otellog.KeyValue, a slog.Attr) []otellog.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return append(kvs, otellog.KeyValue{Key: a.Key, Value: convertValue(a.Value)})
+	}
+	if a.Key == "" { // inline the attrs of a group without key
+		for _, ga := range a.Value.Group() {
+			kvs = appendAttr(kvs, ga)
+		}
+		return kvs
+	}
+	var group []otellog.KeyValue
+	for _, ga := range a.Value.Group() {
+		group = appendAttr(group, ga)
+	}
+	if len(group) == 0 {
+		return kvs
+	}
+	return append(kvs, otellog.Map(a.Key, group...))
+}
+
+func convertValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindTime:
+		return otellog.Int64Value(v.Time().UnixNano())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return otellog.Int64Value(int64(u))
+		}
+		return otellog.StringValue(strconv.FormatUint(v.Uint64(), 10))
+	case slog.KindGroup:
+		var kvs []otellog.KeyValue
+		for _, a := range v.Group() {
+			kvs = appendAttr(kvs, a)
+		}
+		return otellog.MapValue(kvs...)
+	case slog.KindLogValuer:
+		return convertValue(v.Resolve())
+	}
+	switch val := v.Any().(type) {
+	case []byte:
+		return otellog.BytesValue(val)
+	case error:
+		return otellog.StringValue(val.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(val.String())
+	default:
+		return otellog.StringValue(fmt.Sprintf("%+v", val))
+	}
+}
@@ -0,0 +1,89 @@
+// Code generated by "go-option -type=slogHandler"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package log
+
+import (
+	"log/slog"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// A SlogHandlerOption sets options.
+type SlogHandlerOption interface {
+	apply(*slogHandler)
+}
+
+// EmptySlogHandlerOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptySlogHandlerOption struct{}
+
+func (EmptySlogHandlerOption) apply(*slogHandler) {}
+
+// SlogHandlerOptionFunc wraps a function that modifies slogHandler into an
+// implementation of the SlogHandlerOption interface.
+type SlogHandlerOptionFunc func(*slogHandler)
+
+func (f SlogHandlerOptionFunc) apply(do *slogHandler) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *slogHandler) ApplyOptions(options ...SlogHandlerOption) *slogHandler {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withSlogHandler sets slogHandler.
+func withSlogHandler(v slogHandler) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		*o = v
+	})
+}
+
+// WithSlogHandlerLoggerProvider sets LoggerProvider in slogHandler.
+// LoggerProvider provides the Logger records are emitted by, global.GetLoggerProvider() if nil.
+func WithSlogHandlerLoggerProvider(v otellog.LoggerProvider) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		o.LoggerProvider = v
+	})
+}
+
+// WithSlogHandlerLoggerOptions appends LoggerOptions in slogHandler.
+// LoggerOptions are the options of the Logger, such as the instrumentation version.
+func WithSlogHandlerLoggerOptions(v ...otellog.LoggerOption) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		o.LoggerOptions = append(o.LoggerOptions, v...)
+	})
+}
+
+// WithSlogHandlerLoggerOptionsReplace sets LoggerOptions in slogHandler.
+// LoggerOptions are the options of the Logger, such as the instrumentation version.
+func WithSlogHandlerLoggerOptionsReplace(v ...otellog.LoggerOption) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		o.LoggerOptions = v
+	})
+}
+
+// WithSlogHandlerLevel sets Level in slogHandler.
+// Level is the minimum level of records emitted, all levels enabled by the Logger if nil.
+func WithSlogHandlerLevel(v slog.Leveler) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		o.Level = v
+	})
+}
+
+// WithSlogHandlerAddSource sets AddSource in slogHandler.
+// AddSource emits the source of records as code.* attributes.
+func WithSlogHandlerAddSource(v bool) SlogHandlerOption {
+	return SlogHandlerOptionFunc(func(o *slogHandler) {
+		o.AddSource = v
+	})
+}
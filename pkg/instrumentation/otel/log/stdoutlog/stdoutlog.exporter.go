@@ -0,0 +1,21 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stdoutlog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+)
+
+// OpenExporter opens a log exporter specified by its log exporter name and a
+// exporter-specific data source name, usually consisting of at least a
+// log exporter name and connection information.
+func OpenExporter(ctx context.Context, opts ...Option) (*stdoutlog.Exporter, error) {
+	var opt option
+	opt.SetDefaults()
+	opt.ApplyOptions(opts...)
+	return stdoutlog.New(opt.StdoutOptions...)
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stdoutlog
+
+import (
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+//go:generate go-option -type=option --trim
+type option struct {
+	StdoutOptions         []stdoutlog.Option
+	BatchProcessorOptions []sdklog.BatchProcessorOption
+}
+
+func (o *option) SetDefaults() {}
@@ -0,0 +1,130 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stdoutlog
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	url_ "github.com/searKing/golang/pkg/instrumentation/otel/url"
+)
+
+// URLOpener opens stdout Log URLs like "stdout://localhost?allow_stdout&pretty_print&no_timestamps&batch_export_interval=1000000000".
+type URLOpener struct {
+	// Options specifies the options to pass to OpenProcessor.
+	Options []Option
+}
+
+// Scheme returns the scheme supported by this log processor.
+func (o *URLOpener) Scheme() string { return "stdout" }
+
+// OpenProcessorURL opens a log.Processor based on u.
+func (o *URLOpener) OpenProcessorURL(ctx context.Context, u *url.URL) (sdklog.Processor, error) {
+	q := u.Query()
+	u.RawQuery = ""
+	u.RawFragment = ""
+
+	opts := o.Options
+	{
+		stdoutOpts, err := parseStdoutOpts(q)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithOptionStdoutOptions(stdoutOpts...))
+	}
+	{
+		processorOpts, err := parseProcessorOpts(q)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithOptionBatchProcessorOptions(processorOpts...))
+	}
+	return OpenProcessor(ctx, opts...)
+}
+
+func parseStdoutOpts(q url.Values, opts ...stdoutlog.Option) ([]stdoutlog.Option, error) {
+	{
+		b, err := url_.ParseBoolFromValues(q, "allow_stdout")
+		if err != nil {
+			return nil, err
+		}
+		w := io.Discard
+		if b {
+			w = os.Stdout
+		}
+		opts = append(opts, stdoutlog.WithWriter(w))
+		q.Del("allow_stdout")
+	}
+	{
+		b, err := url_.ParseBoolFromValues(q, "pretty_print")
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			opts = append(opts, stdoutlog.WithPrettyPrint())
+		}
+		q.Del("pretty_print")
+	}
+	{
+		b, err := url_.ParseBoolFromValues(q, "no_timestamps")
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			opts = append(opts, stdoutlog.WithoutTimestamps())
+		}
+		q.Del("no_timestamps")
+	}
+	return opts, nil
+}
+
+func parseProcessorOpts(q url.Values, opts ...sdklog.BatchProcessorOption) ([]sdklog.BatchProcessorOption, error) {
+	{
+		d, err := url_.ParseTimeDurationFromValues(q, "batch_export_interval")
+		if err != nil {
+			return nil, err
+		}
+		if d != 0 {
+			opts = append(opts, sdklog.WithExportInterval(d))
+		}
+		q.Del("batch_export_interval")
+	}
+	{
+		d, err := url_.ParseTimeDurationFromValues(q, "batch_export_timeout")
+		if err != nil {
+			return nil, err
+		}
+		if d != 0 {
+			opts = append(opts, sdklog.WithExportTimeout(d))
+		}
+		q.Del("batch_export_timeout")
+	}
+	{
+		n, err := url_.ParseIntFromValues(q, "batch_max_queue_size")
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			opts = append(opts, sdklog.WithMaxQueueSize(n))
+		}
+		q.Del("batch_max_queue_size")
+	}
+	{
+		n, err := url_.ParseIntFromValues(q, "batch_export_max_batch_size")
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			opts = append(opts, sdklog.WithExportMaxBatchSize(n))
+		}
+		q.Del("batch_export_max_batch_size")
+	}
+	return opts, nil
+}
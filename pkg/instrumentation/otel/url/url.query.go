@@ -41,3 +41,19 @@ func ParseTimeDurationFromValues(q url.Values, key string) (time.Duration, error
 	}
 	return b, nil
 }
+
+func ParseIntFromValues(q url.Values, key string) (int, error) {
+	if !q.Has(key) {
+		return 0, nil
+	}
+	s := q.Get(key)
+	if s == "" {
+		return 0, nil
+	}
+	var b int
+	err := json.Unmarshal([]byte(s), &b)
+	if err != nil {
+		return 0, err
+	}
+	return b, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+// requestIdField is the logging field of the request ID, injected by requestid interceptors.
+const requestIdField = "request_id"
+
+// FlightRecorderKey returns the key records of ctx are kept by in a slog_.FlightRecorderHandler:
+// the trace ID of the OpenTelemetry span in ctx, or the request ID injected by requestid
+// interceptors, or "" if neither.
+func FlightRecorderKey(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	i := logging.ExtractFields(ctx).Iterator()
+	for i.Next() {
+		if k, v := i.At(); k == requestIdField {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// NewFlightRecorderHandler returns a slog_.FlightRecorderHandler wrapping h, which keeps
+// debug records per trace ID or request ID, see FlightRecorderKey.
+// Install FlightRecorderUnaryServerInterceptor and FlightRecorderStreamServerInterceptor to
+// release the records kept when a request ends, chained after the requestid interceptors.
+func NewFlightRecorderHandler(h slog.Handler, opts ...slog_.FlightRecorderOption) *slog_.FlightRecorderHandler {
+	return slog_.NewFlightRecorderHandler(h,
+		append([]slog_.FlightRecorderOption{slog_.WithFlightRecorderKey(FlightRecorderKey)}, opts...)...)
+}
+
+// FlightRecorderUnaryServerInterceptor returns a new unary server interceptor, which dumps the records
+// kept of the request by h if the request fails, or discards them if not, when the request ends.
+func FlightRecorderUnaryServerInterceptor(h *slog_.FlightRecorderHandler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		endFlightRecords(ctx, h, err)
+		return resp, err
+	}
+}
+
+// FlightRecorderStreamServerInterceptor returns a new stream server interceptor, which dumps the records
+// kept of the request by h if the request fails, or discards them if not, when the request ends.
+func FlightRecorderStreamServerInterceptor(h *slog_.FlightRecorderHandler) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		endFlightRecords(ss.Context(), h, err)
+		return err
+	}
+}
+
+func endFlightRecords(ctx context.Context, h *slog_.FlightRecorderHandler, err error) {
+	if err != nil {
+		_ = h.Dump(ctx)
+		return
+	}
+	h.Discard(ctx)
+}
@@ -0,0 +1,90 @@
+// Code generated by "go-option -type costTickRecorder"; DO NOT EDIT.
+// Install go-option by "go get install github.com/searKing/golang/tools/go-option"
+
+package otel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// A CostTickRecorderOption sets options.
+type CostTickRecorderOption interface {
+	apply(*costTickRecorder)
+}
+
+// EmptyCostTickRecorderOption does not alter the configuration. It can be embedded
+// in another structure to build custom options.
+//
+// This API is EXPERIMENTAL.
+type EmptyCostTickRecorderOption struct{}
+
+func (EmptyCostTickRecorderOption) apply(*costTickRecorder) {}
+
+// CostTickRecorderOptionFunc wraps a function that modifies costTickRecorder into an
+// implementation of the CostTickRecorderOption interface.
+type CostTickRecorderOptionFunc func(*costTickRecorder)
+
+func (f CostTickRecorderOptionFunc) apply(do *costTickRecorder) {
+	f(do)
+}
+
+// ApplyOptions call apply() for all options one by one
+func (o *costTickRecorder) ApplyOptions(options ...CostTickRecorderOption) *costTickRecorder {
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+		opt.apply(o)
+	}
+	return o
+}
+
+// withCostTickRecorder sets costTickRecorder.
+func withCostTickRecorder(v costTickRecorder) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		*o = v
+	})
+}
+
+// WithCostTickRecorderSubSpans sets SubSpans in costTickRecorder.
+// SubSpans records every phase as a child span of the span in the context, started at
+// the previous checkpoint and ended at the checkpoint, instead of a span event per checkpoint.
+func WithCostTickRecorderSubSpans(v bool) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		o.SubSpans = v
+	})
+}
+
+// WithCostTickRecorderTracerProvider sets TracerProvider in costTickRecorder.
+// TracerProvider creates the tracer of sub spans, the global TracerProvider if nil.
+func WithCostTickRecorderTracerProvider(v trace.TracerProvider) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		o.TracerProvider = v
+	})
+}
+
+// WithCostTickRecorderMeterProvider sets MeterProvider in costTickRecorder.
+// MeterProvider creates the histogram of phase durations, the global MeterProvider if nil.
+func WithCostTickRecorderMeterProvider(v metric.MeterProvider) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		o.MeterProvider = v
+	})
+}
+
+// WithCostTickRecorderAttributes appends Attributes in costTickRecorder.
+// Attributes are added to every span event, sub span and histogram record.
+func WithCostTickRecorderAttributes(v ...attribute.KeyValue) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		o.Attributes = append(o.Attributes, v...)
+	})
+}
+
+// WithCostTickRecorderAttributesReplace sets Attributes in costTickRecorder.
+// Attributes are added to every span event, sub span and histogram record.
+func WithCostTickRecorderAttributesReplace(v ...attribute.KeyValue) CostTickRecorderOption {
+	return CostTickRecorderOptionFunc(func(o *costTickRecorder) {
+		o.Attributes = v
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/searKing/golang/go/sync/circuitbreaker"
+)
+
+const circuitBreakerInstrumentationName = "github.com/searKing/golang/go/sync/circuitbreaker"
+
+// CircuitBreakerOnStateChange returns a callback to be set by circuitbreaker.WithBreakerOnStateChange,
+// which records state changes of circuit breakers as OpenTelemetry metrics:
+// circuitbreaker.state_changes counts transitions by name, from and to,
+// circuitbreaker.state gauges the current state by name, 0 for closed, 1 for half-open and 2 for open.
+// The global MeterProvider is used if mp is nil.
+func CircuitBreakerOnStateChange(mp metric.MeterProvider) (func(name string, from, to circuitbreaker.State), error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(circuitBreakerInstrumentationName)
+	changes, err := meter.Int64Counter("circuitbreaker.state_changes",
+		metric.WithDescription("Number of circuit breaker state transitions."),
+		metric.WithUnit("{transition}"))
+	if err != nil {
+		return nil, err
+	}
+	state, err := meter.Int64Gauge("circuitbreaker.state",
+		metric.WithDescription("Current circuit breaker state, 0 for closed, 1 for half-open and 2 for open."),
+		metric.WithUnit("{state}"))
+	if err != nil {
+		return nil, err
+	}
+	return func(name string, from, to circuitbreaker.State) {
+		ctx := context.Background()
+		changes.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("circuitbreaker.name", name),
+			attribute.String("circuitbreaker.from", from.String()),
+			attribute.String("circuitbreaker.to", to.String())))
+		state.Record(ctx, int64(to), metric.WithAttributes(attribute.String("circuitbreaker.name", name)))
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otel
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	time_ "github.com/searKing/golang/go/time"
+)
+
+const costTickInstrumentationName = "github.com/searKing/golang/go/time"
+
+const (
+	costMessageKey = attribute.Key("cost.message")
+	costIndexKey   = attribute.Key("cost.index")
+	costSecondsKey = attribute.Key("cost.duration_seconds")
+)
+
+//go:generate go-option -type "costTickRecorder"
+type costTickRecorder struct {
+	// SubSpans records every phase as a child span of the span in the context, started at
+	// the previous checkpoint and ended at the checkpoint, instead of a span event per checkpoint.
+	SubSpans bool
+	// TracerProvider creates the tracer of sub spans, the global TracerProvider if nil.
+	TracerProvider trace.TracerProvider
+	// MeterProvider creates the histogram of phase durations, the global MeterProvider if nil.
+	MeterProvider metric.MeterProvider
+	// Attributes are added to every span event, sub span and histogram record.
+	Attributes []attribute.KeyValue
+}
+
+// CostTickRecorder exports checkpoints of a time_.CostTick by OpenTelemetry:
+// every checkpoint as an event on the span in the context, or every phase between
+// checkpoints as a sub span of it, and the duration of every phase into the
+// cost.phase.duration histogram, keyed by the message of the checkpoint ending the phase.
+type CostTickRecorder struct {
+	opts      costTickRecorder
+	tracer    trace.Tracer
+	histogram metric.Float64Histogram
+}
+
+// NewCostTickRecorder returns a CostTickRecorder.
+func NewCostTickRecorder(opts ...CostTickRecorderOption) (*CostTickRecorder, error) {
+	r := &CostTickRecorder{}
+	r.opts.ApplyOptions(opts...)
+	tp := r.opts.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := r.opts.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	r.tracer = tp.Tracer(costTickInstrumentationName)
+	histogram, err := mp.Meter(costTickInstrumentationName).Float64Histogram("cost.phase.duration",
+		metric.WithDescription("Duration of phases between checkpoints of a CostTick."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	r.histogram = histogram
+	return r, nil
+}
+
+// Record exports checkpoints of c to the span in ctx and the histogram, with attrs
+// in addition to the Attributes of r, such as the method of a request.
+// attrs are attributes of the histogram too, so their values must be bounded, such as not sizes.
+// Events and sub spans are dropped if ctx carries no recording span.
+func (r *CostTickRecorder) Record(ctx context.Context, c time_.CostTick, attrs ...attribute.KeyValue) {
+	attrs = slices.Concat(attrs, r.opts.Attributes)
+	span := trace.SpanFromContext(ctx)
+	recording := span.IsRecording()
+	var prev time.Time
+	c.Walk(func(idx int, msg string, cost time.Duration, at time.Time) (next bool) {
+		defer func() { prev = at }()
+		if recording {
+			eventAttrs := append([]attribute.KeyValue{
+				costIndexKey.Int(idx),
+				costSecondsKey.Float64(cost.Seconds()),
+			}, attrs...)
+			if !r.opts.SubSpans {
+				span.AddEvent(msg, trace.WithTimestamp(at), trace.WithAttributes(eventAttrs...))
+			} else if idx > 0 {
+				_, sub := r.tracer.Start(ctx, msg, trace.WithTimestamp(prev), trace.WithAttributes(eventAttrs...))
+				sub.End(trace.WithTimestamp(at))
+			}
+		}
+		// the first checkpoint starts the first phase
+		if idx > 0 {
+			r.histogram.Record(ctx, cost.Seconds(), metric.WithAttributes(
+				append([]attribute.KeyValue{costMessageKey.String(msg)}, attrs...)...))
+		}
+		return true
+	})
+}
+
+// RecordContext exports the CostTick carried by ctx, see time_.WithCostTick.
+func (r *CostTickRecorder) RecordContext(ctx context.Context, attrs ...attribute.KeyValue) {
+	if c, ok := time_.CostTickFromContext(ctx); ok {
+		r.Record(ctx, c, attrs...)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	time_ "github.com/searKing/golang/go/time"
+	otel_ "github.com/searKing/golang/pkg/webserver/pkg/otel"
+)
+
+func TestCostTickRecorder(t *testing.T) {
+	for _, subSpans := range []bool{false, true} {
+		spans := tracetest.NewSpanRecorder()
+		tp := trace.NewTracerProvider(trace.WithSpanProcessor(spans))
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		r, err := otel_.NewCostTickRecorder(
+			otel_.WithCostTickRecorderSubSpans(subSpans),
+			otel_.WithCostTickRecorderTracerProvider(tp),
+			otel_.WithCostTickRecorderMeterProvider(mp))
+		if err != nil {
+			t.Fatalf("NewCostTickRecorder: %v", err)
+		}
+
+		ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+		ctx = time_.WithCostTick(ctx)
+		for _, msg := range []string{"begin", "decode", "query", "encode"} {
+			time_.TickContext(ctx, msg)
+		}
+		r.RecordContext(ctx, attribute.String("rpc.method", "/test/Method"))
+		span.End()
+
+		ended := spans.Ended()
+		if subSpans {
+			if len(ended) != 4 {
+				t.Fatalf("got %d spans; want 3 sub spans and the request", len(ended))
+			}
+			for i, name := range []string{"decode", "query", "encode"} {
+				if ended[i].Name() != name || ended[i].Parent().SpanID() != span.SpanContext().SpanID() {
+					t.Errorf("sub span #%d = %q of parent %v; want %q of the request", i, ended[i].Name(), ended[i].Parent().SpanID(), name)
+				}
+			}
+		} else {
+			events := ended[len(ended)-1].Events()
+			if len(events) != 4 || events[0].Name != "begin" || events[3].Name != "encode" {
+				t.Fatalf("span events = %v; want 4 checkpoints", events)
+			}
+		}
+
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("Collect: %v", err)
+		}
+		hist := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+		if len(hist.DataPoints) != 3 {
+			t.Fatalf("got %d histogram data points; want one per phase", len(hist.DataPoints))
+		}
+		for _, dp := range hist.DataPoints {
+			if msg, _ := dp.Attributes.Value("cost.message"); msg.AsString() == "begin" {
+				t.Errorf("the first checkpoint is recorded as a phase")
+			}
+			if method, _ := dp.Attributes.Value("rpc.method"); method.AsString() != "/test/Method" {
+				t.Errorf("rpc.method = %q; want /test/Method", method.AsString())
+			}
+		}
+	}
+}
@@ -8,23 +8,29 @@ import (
 	"context"
 
 	"google.golang.org/grpc/stats"
+
+	"github.com/searKing/golang/pkg/webserver/pkg/otel"
 )
 
 var _ stats.Handler = (*ClientHandler)(nil)
 
 // ClientHandler implements a gRPC stats.Handler for recording gRPC stats.
+// A CostTick is carried by the context of every RPC if CostTickRecorder is set, see time_.TickContext.
 // Use with gRPC clients only.
-type ClientHandler struct{}
+type ClientHandler struct {
+	// CostTickRecorder exports the CostTick accumulated per RPC when the RPC ends.
+	// No CostTick is recorded if nil.
+	CostTickRecorder *otel.CostTickRecorder
+}
 
 // TagRPC implements per-RPC context management.
 func (c ClientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
-	// no-op
-	return ctx
+	return tagRPC(ctx, info, c.CostTickRecorder)
 }
 
 // HandleRPC implements per-RPC tracing and stats instrumentation.
 func (c ClientHandler) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
-	statsHandleRPC(ctx, rpcStats)
+	statsHandleRPC(ctx, rpcStats, c.CostTickRecorder)
 }
 
 // TagConn exists to satisfy gRPC stats.Handler.
@@ -8,16 +8,46 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/stats"
 
+	time_ "github.com/searKing/golang/go/time"
 	"github.com/searKing/golang/pkg/webserver/pkg/logging"
+	"github.com/searKing/golang/pkg/webserver/pkg/otel"
 )
 
+type rpcContextKey struct{}
+
+// rpcInfo is the state of a RPC recorded, carried by the context of the RPC.
+type rpcInfo struct {
+	fullMethod string
+	// payloads are counted instead of ticked, as a stream may send and receive unlimited messages
+	inPayloads, outPayloads atomic.Int64
+	inBytes, outBytes       atomic.Int64
+}
+
+// tagRPC carries a CostTick in ctx if recorder is not nil, accumulated by statsHandleRPC and
+// handlers of the RPC, and exported by recorder when the RPC ends.
+func tagRPC(ctx context.Context, info *stats.RPCTagInfo, recorder *otel.CostTickRecorder) context.Context {
+	if recorder == nil {
+		return ctx
+	}
+	ctx = time_.WithCostTick(ctx)
+	return context.WithValue(ctx, rpcContextKey{}, &rpcInfo{fullMethod: info.FullMethodName})
+}
+
 // statsHandleRPC processes the RPC events for conn infos.
-func statsHandleRPC(ctx context.Context, s stats.RPCStats) {
+// Events but payloads are ticked into the CostTick carried by ctx, if any, which is exported
+// by recorder when the RPC ends. The numbers of payloads and bytes are set on the span in ctx,
+// instead of attrs of the metrics, as they are unbounded.
+func statsHandleRPC(ctx context.Context, s stats.RPCStats, recorder *otel.CostTickRecorder) {
+	info, _ := ctx.Value(rpcContextKey{}).(*rpcInfo)
 	switch st := s.(type) {
 	case *stats.InHeader:
+		time_.TickContext(ctx, "in header")
 		logger := slog.With(logging.Attrs[any](ctx)...)
 		if st.Client {
 			logger.InfoContext(ctx, fmt.Sprintf("gRPC Client Got Conn in for %s from %s to %s",
@@ -27,6 +57,7 @@ func statsHandleRPC(ctx context.Context, s stats.RPCStats) {
 				st.FullMethod, st.LocalAddr, st.RemoteAddr))
 		}
 	case *stats.OutHeader:
+		time_.TickContext(ctx, "out header")
 		logger := slog.With(logging.Attrs[any](ctx)...)
 		if st.Client {
 			logger.InfoContext(ctx, fmt.Sprintf("gRPC Client Got Conn out for %s from %s to %s",
@@ -35,7 +66,35 @@ func statsHandleRPC(ctx context.Context, s stats.RPCStats) {
 			logger.InfoContext(ctx, fmt.Sprintf("gRPC Server Got Conn out for %s from %s to %s",
 				st.FullMethod, st.RemoteAddr, st.LocalAddr))
 		}
-	case *stats.Begin, *stats.End, *stats.InTrailer, *stats.OutTrailer, *stats.OutPayload, *stats.InPayload, *stats.PickerUpdated:
+	case *stats.Begin:
+		time_.TickContext(ctx, "begin")
+	case *stats.InPayload:
+		if info != nil {
+			info.inPayloads.Add(1)
+			info.inBytes.Add(int64(st.WireLength))
+		}
+	case *stats.OutPayload:
+		if info != nil {
+			info.outPayloads.Add(1)
+			info.outBytes.Add(int64(st.WireLength))
+		}
+	case *stats.InTrailer:
+		time_.TickContext(ctx, "in trailer")
+	case *stats.OutTrailer:
+		time_.TickContext(ctx, "out trailer")
+	case *stats.End:
+		time_.TickContext(ctx, "end")
+		if recorder != nil && info != nil {
+			if span := trace.SpanFromContext(ctx); span.IsRecording() {
+				span.SetAttributes(
+					attribute.Int64("rpc.in_payloads", info.inPayloads.Load()),
+					attribute.Int64("rpc.in_bytes", info.inBytes.Load()),
+					attribute.Int64("rpc.out_payloads", info.outPayloads.Load()),
+					attribute.Int64("rpc.out_bytes", info.outBytes.Load()))
+			}
+			recorder.RecordContext(ctx, attribute.String("rpc.method", info.fullMethod))
+		}
+	case *stats.PickerUpdated:
 		// do nothing for client
 	default:
 		logger := slog.With(logging.Attrs[any](ctx)...)
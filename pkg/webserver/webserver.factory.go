@@ -61,20 +61,22 @@ type FactoryConfig struct {
 	PreferRegisterHTTPFromEndpoint bool             // prefer register http handler from endpoint
 
 	// grpc middlewares
-	MaxConcurrencyUnary          int                 // for concurrent parallel requests of unary server, The default is 0 (no limit is given)
-	MaxConcurrencyStream         int                 // for concurrent parallel requests of stream server, The default is 0 (no limit is given)
-	BurstLimitTimeoutUnary       time.Duration       // for concurrent parallel requests of unary server, The default is 0 (no limit is given)
-	BurstLimitTimeoutStream      time.Duration       // for concurrent parallel requests of stream server, The default is 0 (no limit is given)
-	HandledTimeoutUnary          time.Duration       // for max handing time of unary server, The default is 0 (no limit is given)
-	HandledTimeoutStream         time.Duration       // for max handing time of unary server, The default is 0 (no limit is given)
-	MaxReceiveMessageSizeInBytes int                 // sets the maximum message size in bytes the grpc server can receive, The default is 0 (no limit is given).
-	MaxSendMessageSizeInBytes    int                 // sets the maximum message size in bytes the grpc server can send, The default is 0 (no limit is given).
-	StatsHandling                bool                // log for the related stats handling (e.g., RPCs, connections).
-	Validator                    *validator.Validate // for value validations for structs and individual fields based on tags (e.g., request).
-	FillRequestId                bool                // for the field "RequestId" filling in Request and Response.
-	OtelHandling                 bool                // captures traces and metrics and send them to an observability platform by OpenTelemetry.
-	OtelHttpOptions              []otelhttp.Option   // take effect only when OtelHandling is true
-	OtelGrpcOptions              []otelgrpc.Option   // take effect only when OtelHandling is true
+	MaxConcurrencyUnary          int                     // for concurrent parallel requests of unary server, The default is 0 (no limit is given)
+	MaxConcurrencyStream         int                     // for concurrent parallel requests of stream server, The default is 0 (no limit is given)
+	BurstLimitTimeoutUnary       time.Duration           // for concurrent parallel requests of unary server, The default is 0 (no limit is given)
+	BurstLimitTimeoutStream      time.Duration           // for concurrent parallel requests of stream server, The default is 0 (no limit is given)
+	HandledTimeoutUnary          time.Duration           // for max handing time of unary server, The default is 0 (no limit is given)
+	HandledTimeoutStream         time.Duration           // for max handing time of unary server, The default is 0 (no limit is given)
+	MaxReceiveMessageSizeInBytes int                     // sets the maximum message size in bytes the grpc server can receive, The default is 0 (no limit is given).
+	MaxSendMessageSizeInBytes    int                     // sets the maximum message size in bytes the grpc server can send, The default is 0 (no limit is given).
+	StatsHandling                bool                    // log for the related stats handling (e.g., RPCs, connections).
+	CostTickRecorder             *otel_.CostTickRecorder // exports the CostTick of every RPC, take effect only when StatsHandling is true, not recorded if nil
+	Validator                    *validator.Validate     // for value validations for structs and individual fields based on tags (e.g., request).
+	FillRequestId                bool                    // for the field "RequestId" filling in Request and Response.
+	OtelHandling                 bool                    // captures traces and metrics and send them to an observability platform by OpenTelemetry.
+	OtelHttpOptions              []otelhttp.Option       // take effect only when OtelHandling is true
+	OtelGrpcOptions              []otelgrpc.Option       // take effect only when OtelHandling is true
+	VModule                      *slog_.VModule          // overrides log levels per package or file, see VModuleHandler to change them at runtime.
 
 	// Deprecated: takes no effect, use slog instead.
 	EnableLogrusMiddleware bool // disable logrus middleware
@@ -135,12 +137,25 @@ func (f *Factory) Config() FactoryConfig {
 	return f.fc
 }
 
+// VModuleHandler returns a http.Handler serving VModule by GET, and replacing it by PUT or POST,
+// nil if VModule is nil. It is not registered by the server, as it changes log levels;
+// mount it on an admin only mux, such as one listening on localhost or behind authentication.
+func (f *Factory) VModuleHandler() http.Handler {
+	if f.fc.VModule == nil {
+		return nil
+	}
+	return f.fc.VModule
+}
+
 // New creates a new server which logically combines the handling chain with the passed server.
 // name is used to differentiate for logging. The handler chain in particular can be difficult as it starts delgating.
 func (f *Factory) New() (*WebServer, error) {
 	if f.fc.OtelHandling {
 		slog.SetDefault(slog.New(otel_.NewSlogHandler(slog.Default().Handler())))
 	}
+	if f.fc.VModule != nil {
+		slog.SetDefault(slog.New(slog_.VModuleHandler(f.fc.VModule, slog.Default().Handler())))
+	}
 
 	f.fc.BindAddress = f.GetBackendBindHostPort()
 	f.fc.ExternalAddress = f.GetBackendServeHostPort(true)
@@ -21,7 +21,7 @@ func (f *Factory) ServerOptions(opts ...grpc.ServerOption) []grpc.ServerOption {
 	}
 	if f.fc.StatsHandling {
 		// log for the related stats handling (e.g., RPCs, connections).
-		s = append(s, grpc.StatsHandler(&stats.ServerHandler{}))
+		s = append(s, grpc.StatsHandler(&stats.ServerHandler{CostTickRecorder: f.fc.CostTickRecorder}))
 	}
 	if f.fc.OtelHandling {
 		s = append(s, otel.ServerOptions(f.fc.OtelGrpcOptions...)...)
@@ -44,7 +44,7 @@ func (f *Factory) DialOptions(opts ...grpc.DialOption) []grpc.DialOption {
 	}
 	if f.fc.StatsHandling {
 		// log for the related stats handling (e.g., RPCs, connections).
-		s = append(s, grpc.WithStatsHandler(&stats.ClientHandler{}))
+		s = append(s, grpc.WithStatsHandler(&stats.ClientHandler{CostTickRecorder: f.fc.CostTickRecorder}))
 	}
 	if f.fc.OtelHandling {
 		s = append(s, otel.DialOptions(f.fc.OtelGrpcOptions...)...)
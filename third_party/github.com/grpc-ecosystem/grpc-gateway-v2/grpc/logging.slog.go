@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	slog_ "github.com/searKing/golang/go/log/slog"
 	runtime_ "github.com/searKing/golang/go/runtime"
 	grpclog_ "github.com/searKing/golang/third_party/google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/grpclog"
@@ -36,7 +37,11 @@ func interceptorSlogLogger(h slog.Handler) logging.Logger {
 	})
 }
 
+// WithSlogLoggerConfig logs by h, with credentials redacted by slog_.RedactHandler, such as
+// passwords and tokens in headers and payloads of requests and responses.
+// Wrap h by slog_.RedactHandler with options to redact more.
 func WithSlogLoggerConfig(h slog.Handler, slogOpts []logging.Option) []GatewayOption {
+	h = slog_.RedactHandler(h)
 	grpclog.SetLoggerV2(grpclog_.NewSlogger(h))
 
 	// interceptor's log below
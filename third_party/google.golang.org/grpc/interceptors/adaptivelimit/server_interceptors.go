@@ -0,0 +1,61 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adaptivelimit
+
+import (
+	"context"
+
+	"github.com/searKing/golang/go/sync/adaptive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a new unary server interceptors that performs adaptive concurrency limiting.
+// Requests over the limit estimated by limiter are rejected with ResourceExhausted.
+func UnaryServerInterceptor(limiter *adaptive.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		listener, ok := limiter.Acquire()
+		if !ok {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"%s is rejected by adaptivelimit unary server middleware, please retry later", info.FullMethod)
+		}
+		// no-op if completed, release without sampling if handler panics
+		defer listener.OnIgnore()
+		resp, err := handler(ctx, req)
+		complete(listener, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that performs adaptive concurrency limiting.
+// Requests over the limit estimated by limiter are rejected with ResourceExhausted.
+func StreamServerInterceptor(limiter *adaptive.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		listener, ok := limiter.Acquire()
+		if !ok {
+			return status.Errorf(codes.ResourceExhausted,
+				"%s is rejected by adaptivelimit stream server middleware, please retry later", info.FullMethod)
+		}
+		// no-op if completed, release without sampling if handler panics
+		defer listener.OnIgnore()
+		err := handler(srv, stream)
+		complete(listener, err)
+		return err
+	}
+}
+
+// complete samples the request by its error,
+// overload errors back off the limit, and canceled requests are not sampled.
+func complete(listener *adaptive.Listener, err error) {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.ResourceExhausted, codes.Unavailable:
+		listener.OnDropped()
+	case codes.Canceled:
+		listener.OnIgnore()
+	default:
+		listener.OnSuccess()
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/searKing/golang/go/sync/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KeyFunc names the circuit breaker of a request.
+type KeyFunc func(ctx context.Context, method string, cc *grpc.ClientConn) string
+
+// KeyByMethod names the circuit breaker of a request by its full method.
+func KeyByMethod(ctx context.Context, method string, cc *grpc.ClientConn) string {
+	return method
+}
+
+// KeyByTarget names the circuit breaker of a request by the target of its connection.
+func KeyByTarget(ctx context.Context, method string, cc *grpc.ClientConn) string {
+	return cc.Target()
+}
+
+// IsFailure reports whether err returned by a RPC is counted as a failure of the upstream,
+// business errors such as InvalidArgument or NotFound are not.
+func IsFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unknown, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnaryClientInterceptor returns a new unary client interceptor that rejects requests
+// with Unavailable while the circuit breaker is not closed.
+// key names the circuit breaker of a request in group, KeyByMethod if nil.
+// A RPC is a failure if IsFailure reports so.
+func UnaryClientInterceptor(group *circuitbreaker.Group, key KeyFunc) grpc.UnaryClientInterceptor {
+	if key == nil {
+		key = KeyByMethod
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := group.Get(key(ctx, method, cc)).Allow()
+		if err != nil {
+			return status.Errorf(codes.Unavailable,
+				"%s is rejected by circuitbreaker unary client middleware, please retry later: %s", method, err)
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(failure(err))
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a new streaming client interceptor that rejects requests
+// with Unavailable while the circuit breaker is not closed.
+// key names the circuit breaker of a request in group, KeyByMethod if nil.
+// A stream is a failure if it fails to be created or ends with an error IsFailure reports so.
+func StreamClientInterceptor(group *circuitbreaker.Group, key KeyFunc) grpc.StreamClientInterceptor {
+	if key == nil {
+		key = KeyByMethod
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		done, err := group.Get(key(ctx, method, cc)).Allow()
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable,
+				"%s is rejected by circuitbreaker stream client middleware, please retry later: %s", method, err)
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			done(failure(err))
+			return nil, err
+		}
+		s := &clientStream{ClientStream: stream, done: done, serverStreams: desc.ServerStreams}
+		// the stream may be dropped by the caller, or ended by its context without RecvMsg failed
+		s.stop = context.AfterFunc(ctx, func() {
+			s.finish(status.FromContextError(ctx.Err()).Err())
+		})
+		return s, nil
+	}
+}
+
+// failure returns err if it is counted as a failure of the upstream, nil otherwise.
+func failure(err error) error {
+	if IsFailure(err) {
+		return err
+	}
+	return nil
+}
+
+// clientStream reports the result of the stream to the circuit breaker when the stream ends,
+// by RecvMsg failed, by RecvMsg of the response of a client-streaming RPC, or by the context
+// of the call done, whichever comes first.
+type clientStream struct {
+	grpc.ClientStream
+	done          func(err error)
+	stop          func() bool // stops reporting by the context
+	once          sync.Once
+	serverStreams bool // RecvMsg succeeded ends the RPC if not set
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if !s.serverStreams {
+			// the only response of a client-streaming RPC is received
+			s.stop()
+			s.finish(nil)
+		}
+	case errors.Is(err, io.EOF):
+		s.stop()
+		s.finish(nil)
+	default:
+		s.stop()
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.done(failure(err))
+	})
+}
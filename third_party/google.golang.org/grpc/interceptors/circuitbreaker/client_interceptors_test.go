@@ -0,0 +1,76 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	circuitbreaker_ "github.com/searKing/golang/go/sync/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/searKing/golang/third_party/google.golang.org/grpc/interceptors/circuitbreaker"
+)
+
+// clientStream is a stream whose RecvMsg succeeds.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (clientStream) RecvMsg(m any) error { return nil }
+
+func TestStreamClientInterceptorClientStreaming(t *testing.T) {
+	group := circuitbreaker_.NewGroup(
+		circuitbreaker_.WithBreakerMinRequests(1),
+		circuitbreaker_.WithBreakerFailureRateThreshold(1),
+		circuitbreaker_.WithBreakerOpenTimeout(10*time.Millisecond),
+		circuitbreaker_.WithBreakerHalfOpenMaxRequests(1))
+	const method = "/test.Service/Upload"
+	cb := group.Get(method)
+
+	// trips the circuit breaker, and waits for half-open
+	unary := circuitbreaker.UnaryClientInterceptor(group, nil)
+	_ = unary(context.Background(), method, nil, nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return status.Error(codes.Unavailable, "unavailable")
+		})
+	time.Sleep(20 * time.Millisecond)
+	if got := cb.State(); got != circuitbreaker_.StateHalfOpen {
+		t.Fatalf("State = %v; want %v", got, circuitbreaker_.StateHalfOpen)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	stream := circuitbreaker.StreamClientInterceptor(group, nil)
+	s, err := stream(ctx, &grpc.StreamDesc{ClientStreams: true}, nil, method,
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return clientStream{}, nil
+		})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	// CloseAndRecv of a client-streaming RPC
+	if err := s.RecvMsg(nil); err != nil {
+		t.Fatalf("RecvMsg: %v", err)
+	}
+	// the probe succeeded, and its slot is released
+	if got := cb.State(); got != circuitbreaker_.StateClosed {
+		t.Fatalf("State = %v; want %v", got, circuitbreaker_.StateClosed)
+	}
+	// the deadline of the call exceeded after the RPC ends is not a failure
+	<-ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+	if got := cb.State(); got != circuitbreaker_.StateClosed {
+		t.Fatalf("State after the deadline = %v; want %v", got, circuitbreaker_.StateClosed)
+	}
+	done, err := cb.Allow()
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	done(nil)
+}
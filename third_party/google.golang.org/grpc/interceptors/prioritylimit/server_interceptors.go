@@ -0,0 +1,84 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package prioritylimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/searKing/golang/go/sync/semaphore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PriorityFunc returns the priority class and the weight of a request, higher priority is admitted first.
+type PriorityFunc func(ctx context.Context, fullMethod string) (priority int, weight int64)
+
+// MethodPriority returns a PriorityFunc which admits methods listed in priorities with their priority,
+// and others with defaultPriority, all requests weigh 1.
+// This can be used to let health checks and admin RPCs bypass user traffic queues.
+func MethodPriority(defaultPriority int, priorities map[string]int) PriorityFunc {
+	return func(ctx context.Context, fullMethod string) (int, int64) {
+		if p, ok := priorities[fullMethod]; ok {
+			return p, 1
+		}
+		return defaultPriority, 1
+	}
+}
+
+// UnaryServerInterceptor returns a new unary server interceptors that performs request concurrency limiting by priority.
+// sem limits the combined weight of requests handled concurrently, take effect if sem is not nil
+// priority classifies the request, all requests are admitted with priority 0 and weight 1 if priority is nil
+// timeout ResourceExhausted if cost more than timeout to acquire the semaphore, take effect if timeout > 0
+func UnaryServerInterceptor(sem *semaphore.Weighted, priority PriorityFunc, timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if sem != nil {
+			release, err := acquire(ctx, sem, priority, info.FullMethod, timeout)
+			if err != nil {
+				return nil, status.Errorf(codes.ResourceExhausted,
+					"%s is rejected by prioritylimit unary server middleware, please retry later: %s", info.FullMethod, err)
+			}
+			defer release()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that performs concurrency limiting by priority.
+// sem limits the combined weight of requests handled concurrently, take effect if sem is not nil
+// priority classifies the request, all requests are admitted with priority 0 and weight 1 if priority is nil
+// timeout ResourceExhausted if cost more than timeout to acquire the semaphore, take effect if timeout > 0
+func StreamServerInterceptor(sem *semaphore.Weighted, priority PriorityFunc, timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if sem != nil {
+			release, err := acquire(stream.Context(), sem, priority, info.FullMethod, timeout)
+			if err != nil {
+				return status.Errorf(codes.ResourceExhausted,
+					"%s is rejected by prioritylimit stream server middleware, please retry later: %s", info.FullMethod, err)
+			}
+			defer release()
+		}
+		return handler(srv, stream)
+	}
+}
+
+func acquire(ctx context.Context, sem *semaphore.Weighted, priority PriorityFunc, fullMethod string, timeout time.Duration) (release func(), err error) {
+	var p int
+	var n int64 = 1
+	if priority != nil {
+		p, n = priority(ctx, fullMethod)
+	}
+	var limiterCtx = ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		limiterCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := sem.Acquire(limiterCtx, n, p); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(n) }, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	time_ "github.com/searKing/golang/go/time"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor returns a new unary client interceptor that retries failed requests by backoff.
+// budget limits retries to a percentage of recent requests, such as time_.DefaultRetryBudget, take effect if budget is not nil
+// newBackOff returns the back-off policy of a request, 3 retries of the gRPC connection backoff if nil
+// retryableCodes lists the codes to retry, codes.Unavailable if empty
+func UnaryClientInterceptor(budget *time_.RetryBudget, newBackOff func() time_.BackOff, retryableCodes ...codes.Code) grpc.UnaryClientInterceptor {
+	if newBackOff == nil {
+		newBackOff = func() time_.BackOff {
+			return time_.NewGrpcExponentialBackOff(time_.WithExponentialBackOffOptionMaxElapsedCount(3))
+		}
+	}
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable}
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if budget != nil {
+			budget.Deposit()
+		}
+		backoff := newBackOff()
+		for {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !slices.Contains(retryableCodes, status.Code(err)) {
+				return err
+			}
+			wait, ok := backoff.NextBackOff()
+			if !ok {
+				return err
+			}
+			if budget != nil && !budget.TryWithdraw() {
+				return err
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+		}
+	}
+}
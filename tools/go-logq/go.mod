@@ -0,0 +1,12 @@
+module github.com/searKing/golang/tools/go-logq
+
+go 1.23.0
+
+require github.com/searKing/golang/go v1.2.122
+
+require (
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/term v0.30.0 // indirect
+)
+
+replace github.com/searKing/golang/go => ../../go
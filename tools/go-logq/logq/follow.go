@@ -0,0 +1,97 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logq
+
+import (
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// followReader reads a file like "tail -F", once follow is set.
+// At EOF, it polls the file, reopens the file named if rotated, and reads from the start if truncated.
+// It returns io.EOF once no data is appended for a poll after data read, so that the glog entry
+// pending is yielded by an EntryReader, and reads again as it is followed.
+type followReader struct {
+	name   string
+	poll   time.Duration
+	follow bool // returns io.EOF at EOF if not set
+
+	f      *os.File
+	offset int64
+	unread bool // data read since io.EOF returned last
+}
+
+func openFollowReader(name string, poll time.Duration) (*followReader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &followReader{name: name, poll: poll, f: f}, nil
+}
+
+func (r *followReader) Read(p []byte) (int, error) {
+	var idle bool // polled once with nothing read
+	for {
+		n, err := r.f.Read(p)
+		r.offset += int64(n)
+		if n > 0 {
+			r.unread = true
+		}
+		if n > 0 || !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		if !r.follow || (idle && r.unread) {
+			r.unread = false
+			return 0, io.EOF
+		}
+		rotated, err := r.reopenIfRotated()
+		if err != nil {
+			return 0, err
+		}
+		if !rotated {
+			time.Sleep(r.poll)
+			idle = true
+		}
+	}
+}
+
+// reopenIfRotated reopens the file named if rotated, once the file rotated has been read to EOF.
+func (r *followReader) reopenIfRotated() (rotated bool, err error) {
+	fi, err := os.Stat(r.name)
+	if err != nil {
+		// removed, and not created yet
+		return false, nil
+	}
+	cur, err := r.f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if !os.SameFile(fi, cur) {
+		f, err := os.Open(r.name)
+		if err != nil {
+			return false, nil
+		}
+		// lines written between the last read and the rotation
+		if cur.Size() > r.offset {
+			_ = f.Close()
+			return true, nil
+		}
+		_ = r.f.Close()
+		r.f, r.offset = f, 0
+		return true, nil
+	}
+	if cur.Size() < r.offset {
+		// truncated, as copytruncate
+		r.offset, err = r.f.Seek(0, io.SeekStart)
+		return false, err
+	}
+	return false, nil
+}
+
+func (r *followReader) Close() error {
+	return r.f.Close()
+}
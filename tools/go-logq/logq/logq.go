@@ -0,0 +1,365 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logq
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"log/slog"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+// Usage is a replacement usage function for the flags package.
+func Usage(fs *flag.FlagSet) func() {
+	return func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage of go-logq:\n")
+		_, _ = fmt.Fprintf(fs.Output(), "\tgo-logq [flags] [file ...]\n")
+		_, _ = fmt.Fprintf(fs.Output(), "For more information, see:\n")
+		_, _ = fmt.Fprintf(fs.Output(), "\thttps://pkg.go.dev/github.com/searKing/golang/tools/go-logq\n")
+		_, _ = fmt.Fprintf(fs.Output(), "Flags:\n")
+		fs.PrintDefaults()
+	}
+}
+
+func Main() {
+	log.SetFlags(0)
+	log.SetPrefix("go-logq: ")
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
+
+// query is the filter and the output of entries, set by flags.
+type query struct {
+	level  slog.Level
+	since  time.Time
+	until  time.Time
+	attrs  attrFlags
+	grep   *regexp.Regexp
+	tail   int
+	follow bool
+	poll   time.Duration
+	output string
+	// location of times in glog lines
+	location *time.Location
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("go-logq", flag.ContinueOnError)
+	fs.Usage = Usage(fs)
+	var q query
+	level := fs.String("level", "", "minimum `level` of entries, such as DEBUG, INFO, WARN+2")
+	since := fs.String("since", "", "entries at or after `time`, RFC3339 or a duration ago, such as 1h")
+	until := fs.String("until", "", "entries before `time`, RFC3339 or a duration ago, such as 10m")
+	fs.Var(&q.attrs, "attr", "entries having attr `key=value`, or key only, keys of groups are joined by \".\"; may be repeated")
+	grep := fs.String("grep", "", "entries whose message matches the `regexp`")
+	fs.IntVar(&q.tail, "n", 0, "output the last `N` entries only, 0 for all")
+	fs.BoolVar(&q.follow, "f", false, "follow the newest file, reopened if rotated")
+	fs.DurationVar(&q.poll, "poll", 500*time.Millisecond, "`interval` to poll the file followed")
+	fs.StringVar(&q.output, "o", "text", "output `format`: text, json or glog")
+	tz := fs.String("tz", "Local", "time `zone` of times in glog lines, such as UTC, Asia/Shanghai")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	q.level = slog.Level(math.MinInt)
+	if *level != "" {
+		if err := q.level.UnmarshalText([]byte(*level)); err != nil {
+			return fmt.Errorf("malformed -level: %w", err)
+		}
+	}
+	now := time.Now()
+	var err error
+	if q.location, err = time.LoadLocation(*tz); err != nil {
+		return fmt.Errorf("malformed -tz: %w", err)
+	}
+	if q.since, err = parseTime(*since, now); err != nil {
+		return fmt.Errorf("malformed -since: %w", err)
+	}
+	if q.until, err = parseTime(*until, now); err != nil {
+		return fmt.Errorf("malformed -until: %w", err)
+	}
+	if *grep != "" {
+		if q.grep, err = regexp.Compile(*grep); err != nil {
+			return fmt.Errorf("malformed -grep: %w", err)
+		}
+	}
+	h, err := q.handler(stdout)
+	if err != nil {
+		return err
+	}
+	return q.run(fs.Args(), stdin, h)
+}
+
+func (q *query) run(names []string, stdin io.Reader, h slog.Handler) error {
+	if len(names) == 0 {
+		if q.follow {
+			return errors.New("-f requires a file")
+		}
+		r := slog_.NewEntryReader(stdin, slog_.WithEntryReaderLocation(q.location))
+		if err := q.print(r.All(), h, q.tail); err != nil {
+			return err
+		}
+		return r.Err()
+	}
+
+	var followed *followReader
+	var followedEntries *slog_.EntryReader
+	var readers []*slog_.EntryReader
+	var seqs []iter.Seq[slog_.Entry]
+	newest, err := newestFile(names)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		var r io.Reader
+		if q.follow && name == newest {
+			followed, err = openFollowReader(name, q.poll)
+			r = followed
+		} else {
+			r, err = openFile(name)
+		}
+		if err != nil {
+			return err
+		}
+		if c, ok := r.(io.Closer); ok {
+			defer c.Close()
+		}
+		// a partial last line of the followed file is kept until the rest is appended
+		er := slog_.NewEntryReader(r, slog_.WithEntryReaderLocation(q.location),
+			slog_.WithEntryReaderFollow(q.follow && name == newest))
+		if q.follow && name == newest {
+			followedEntries = er
+		}
+		readers = append(readers, er)
+		seqs = append(seqs, er.All())
+	}
+	if err := q.print(slog_.MergeEntries(seqs...), h, q.tail); err != nil {
+		return err
+	}
+	for _, er := range readers {
+		if err := er.Err(); err != nil {
+			return err
+		}
+	}
+	if followed == nil {
+		return nil
+	}
+	// entries of the followed file have been read to EOF, continue from there
+	followed.follow = true
+	for {
+		// the followed file returns io.EOF once idle, which yields the glog entry pending
+		if err := q.print(followedEntries.All(), h, 0); err != nil {
+			return err
+		}
+		if err := followedEntries.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// print outputs entries of seq filtered, the last tail entries only if tail > 0.
+func (q *query) print(seq iter.Seq[slog_.Entry], h slog.Handler, tail int) error {
+	var ring []slog_.Entry
+	var next int
+	for e := range seq {
+		if !q.match(e) {
+			continue
+		}
+		if tail <= 0 {
+			if err := writeEntry(h, e); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(ring) < tail {
+			ring = append(ring, e)
+			continue
+		}
+		ring[next] = e
+		next = (next + 1) % tail
+	}
+	for _, e := range append(ring[next:], ring[:next]...) {
+		if err := writeEntry(h, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *query) match(e slog_.Entry) bool {
+	if e.Level < q.level {
+		return false
+	}
+	if !q.since.IsZero() && e.Time.Before(q.since) {
+		return false
+	}
+	if !q.until.IsZero() && !e.Time.Before(q.until) {
+		return false
+	}
+	if q.grep != nil && !q.grep.MatchString(e.Message) {
+		return false
+	}
+	if len(q.attrs) == 0 {
+		return true
+	}
+	values := make(map[string]string)
+	flattenAttrs(values, "", e.Attrs)
+	for _, a := range q.attrs {
+		v, ok := values[a.key]
+		if !ok || (a.hasValue && v != a.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *query) handler(w io.Writer) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: slog.Level(math.MinInt)}
+	switch q.output {
+	case "text":
+		return slog.NewTextHandler(w, opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	case "glog":
+		return slog_.NewGlogHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", q.output)
+	}
+}
+
+// writeEntry outputs e by h, with the source of e as an attr, as e has no pc.
+func writeEntry(h slog.Handler, e slog_.Entry) error {
+	r := e.Record()
+	if e.Source != nil {
+		source := e.Source.File
+		if e.Source.Line > 0 {
+			source += ":" + strconv.Itoa(e.Source.Line)
+		}
+		if e.Source.Function != "" {
+			source += "(" + e.Source.Function + ")"
+		}
+		r.AddAttrs(slog.String(slog.SourceKey, source))
+	}
+	return h.Handle(context.Background(), r)
+}
+
+// flattenAttrs flattens attrs into values, keys of groups are joined by ".".
+func flattenAttrs(values map[string]string, prefix string, attrs []slog.Attr) {
+	for _, a := range attrs {
+		key := a.Key
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		v := a.Value.Resolve()
+		if v.Kind() == slog.KindGroup {
+			flattenAttrs(values, key, v.Group())
+			continue
+		}
+		values[key] = v.String()
+	}
+}
+
+// parseTime parses s as RFC3339, or as a duration before now, zero time if s is empty.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+type attrFlag struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// attrFlags is a flag.Value of repeated key=value.
+type attrFlags []attrFlag
+
+func (a *attrFlags) String() string {
+	var s []string
+	for _, f := range *a {
+		if f.hasValue {
+			s = append(s, f.key+"="+f.value)
+		} else {
+			s = append(s, f.key)
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+func (a *attrFlags) Set(s string) error {
+	key, value, hasValue := strings.Cut(s, "=")
+	if key == "" {
+		return fmt.Errorf("missing key of attr %q", s)
+	}
+	*a = append(*a, attrFlag{key: key, value: value, hasValue: hasValue})
+	return nil
+}
+
+// openFile opens the file named, decompressed if gzipped.
+func openFile(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &gzipFile{Reader: zr, f: f}, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (f *gzipFile) Close() error {
+	return errors.Join(f.Reader.Close(), f.f.Close())
+}
+
+// newestFile returns the name of the latest modified file of names, gzipped files excluded.
+func newestFile(names []string) (string, error) {
+	var newest string
+	var modTime time.Time
+	for _, name := range names {
+		if strings.HasSuffix(name, ".gz") {
+			continue
+		}
+		fi, err := os.Stat(name)
+		if err != nil {
+			return "", err
+		}
+		if newest == "" || fi.ModTime().After(modTime) {
+			newest, modTime = name, fi.ModTime()
+		}
+	}
+	return newest, nil
+}
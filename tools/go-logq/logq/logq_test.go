@@ -0,0 +1,143 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logq
+
+import (
+	"compress/gzip"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	slog_ "github.com/searKing/golang/go/log/slog"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	glogFile := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(glogFile, []byte(`Log file created at: 2026/10/18 21:40:20
+I20261018 21:40:20.000001 1 main.go:1] a1, svc=api
+W20261018 21:40:20.000003 1 main.go:3] a3
+panic: boom, svc=api, req.id=7
+E20261018 21:40:20.000005 1 main.go:5] a5, svc=web
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gzFile := filepath.Join(dir, "app.log.1.gz")
+	f, err := os.Create(gzFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	_, _ = zw.Write([]byte(`{"time":"2026-10-18T21:40:20.000002Z","level":"WARN","msg":"b2","svc":"api","req":{"id":7}}
+{"time":"2026-10-18T21:40:20.000004Z","level":"ERROR","msg":"b4","svc":"api"}
+`))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, []string{"a1", "b2", "a3", "b4", "a5"}},
+		{[]string{"-level", "WARN", "-attr", "svc=api"}, []string{"b2", "a3", "b4"}},
+		{[]string{"-attr", "req.id=7"}, []string{"b2", "a3"}},
+		{[]string{"-grep", "^a", "-n", "2"}, []string{"a3", "a5"}},
+		{[]string{"-until", "2026-10-18T21:40:20.000003Z"}, []string{"a1", "b2"}},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		args := append([]string{"-tz", "UTC", "-o", "json"}, tt.args...)
+		args = append(args, glogFile, gzFile)
+		if err := run(args, nil, &out); err != nil {
+			t.Fatalf("run(%q): %v", args, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		if len(lines) != len(tt.want) {
+			t.Fatalf("run(%q): got %q, want messages %q", args, out.String(), tt.want)
+		}
+		for i, msg := range tt.want {
+			if !strings.Contains(lines[i], `"msg":"`+msg) {
+				t.Errorf("run(%q): line %d: got %q, want message %q", args, i, lines[i], msg)
+			}
+		}
+	}
+}
+
+func TestFollowReaderFlushesIdleEntry(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := openFollowReader(name, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	r.follow = true
+
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("I20261018 21:40:20.000001 1 main.go:1] a1, svc=api\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	// no line beginning the next entry is written, a1 is yielded once the file is idle
+	next, stop := iter.Pull(slog_.NewEntryReader(r, slog_.WithEntryReaderLocation(time.UTC)).All())
+	defer stop()
+	e, ok := next()
+	if !ok || e.Message != "a1" {
+		t.Fatalf("got %v %v, want entry a1", e, ok)
+	}
+}
+
+func TestFollowReaderKeepsPartialLine(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := openFollowReader(name, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	r.follow = true
+
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	er := slog_.NewEntryReader(r, slog_.WithEntryReaderLocation(time.UTC), slog_.WithEntryReaderFollow(true))
+	messages := func() []string {
+		var msgs []string
+		for e := range er.All() {
+			msgs = append(msgs, e.Message)
+		}
+		return msgs
+	}
+
+	// idle in the middle of the line beginning a2
+	if _, err := f.WriteString("I20261018 21:40:20.000001 1 main.go:1] a1\nI20261018 21:40:20.000002 1 main.go:2] a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := messages(); len(got) != 0 {
+		t.Fatalf("got %q, want no entries at a partial line", got)
+	}
+	if _, err := f.WriteString("2, svc=api\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := messages(), []string{"a1", "a2"}; !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The searKing Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go-logq Queries log files written by GlogHandler, GlogHumanHandler or slog.JSONHandler of
+// github.com/searKing/golang/go/log/slog, rotated and gzipped files included.
+// Given the log files, go-logq merges entries of the files in time order, filters them by level,
+// time, attrs and message, and outputs them as text, json or glog.
+//
+// For example, running this command
+//
+//	go-logq -level WARN -since 1h -attr svc=api -attr req.id -n 100 app.log.*
+//
+// outputs the last 100 entries of app.log.* at or above WARN in the last hour, having the attr svc
+// of "api" and the attr id in group req.
+//
+// With no files, it reads from the standard input.
+// With -f, it continues reading the newest file as it grows, like "tail -F", reopening the file if rotated.
+package main
+
+import "github.com/searKing/golang/tools/go-logq/logq"
+
+func main() {
+	logq.Main()
+}